@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// analytics is a library of the questions we ask every week, so nobody
+// re-derives the SQL (or gets it subtly wrong) each time. Queries run
+// against the analytics tables the module maintains; results render as
+// a terminal table by default and as CSV with --csv for spreadsheets.
+// Adding a query = adding an entry to cannedQueries.
+
+type cannedQuery struct {
+	summary string
+	sql     string
+}
+
+var cannedQueries = map[string]cannedQuery{
+	"dau": {
+		summary: "daily active anglers over the last 14 days",
+		sql: `SELECT day, COUNT(DISTINCT player_id) AS active_players
+		      FROM session_day ORDER BY day DESC LIMIT 14`,
+	},
+	"retention": {
+		summary: "D1/D7/D30 retention by weekly signup cohort",
+		sql: `SELECT cohort_week, d1_pct, d7_pct, d30_pct
+		      FROM retention_cohort ORDER BY cohort_week DESC LIMIT 12`,
+	},
+	"top-fish": {
+		summary: "most-caught fish this week",
+		sql: `SELECT fish_id, COUNT(*) AS catches
+		      FROM catch_event WHERE caught_unix > (strftime('%s','now') - 604800)
+		      GROUP BY fish_id ORDER BY catches DESC LIMIT 20`,
+	},
+	"revenue": {
+		summary: "daily coin sink/source totals over the last 14 days",
+		sql: `SELECT day, coins_earned, coins_spent
+		      FROM economy_day ORDER BY day DESC LIMIT 14`,
+	},
+}
+
+// analytics rows come back with arbitrary columns, so they are handled
+// as ordered maps rather than structs like the leaderboard rows.
+func fetchAnalyticsRows(env environment, sql string) ([]string, []map[string]any, error) {
+	out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module,
+		strings.Join(strings.Fields(sql), " "), "--json").Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("querying analytics on %s: %w", env.Name, err)
+	}
+	var rows []map[string]any
+	if err := json.Unmarshal(out, &rows); err != nil {
+		return nil, nil, fmt.Errorf("parsing analytics rows: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil, nil
+	}
+	columns := make([]string, 0, len(rows[0]))
+	for column := range rows[0] {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns, rows, nil
+}
+
+func formatCell(v any) string {
+	switch v := v.(type) {
+	case float64:
+		if v == float64(int64(v)) {
+			return fmt.Sprintf("%d", int64(v))
+		}
+		return fmt.Sprintf("%.2f", v)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func printAnalyticsTable(columns []string, rows []map[string]any) {
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = len(column)
+		for _, row := range rows {
+			if n := len(formatCell(row[column])); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	for i, column := range columns {
+		fmt.Printf("%-*s  ", widths[i], column)
+	}
+	fmt.Println()
+	for i := range columns {
+		fmt.Print(strings.Repeat("-", widths[i]), "  ")
+	}
+	fmt.Println()
+	for _, row := range rows {
+		for i, column := range columns {
+			fmt.Printf("%-*s  ", widths[i], formatCell(row[column]))
+		}
+		fmt.Println()
+	}
+}
+
+func writeAnalyticsCSV(columns []string, rows []map[string]any) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = formatCell(row[column])
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func analyticsUsage() {
+	fmt.Fprintln(os.Stderr, "usage: lurelands analytics <query> [--env <name>] [--csv]")
+	fmt.Fprintln(os.Stderr, "\nqueries:")
+	names := make([]string, 0, len(cannedQueries))
+	for name := range cannedQueries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, cannedQueries[name].summary)
+	}
+}
+
+func runAnalytics(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 {
+		analyticsUsage()
+		return exitUsage
+	}
+	query, ok := cannedQueries[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown query %q\n\n", args[0])
+		analyticsUsage()
+		return exitUsage
+	}
+
+	flags := flag.NewFlagSet("analytics "+args[0], flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	asCSV := flags.Bool("csv", false, "emit CSV instead of a table")
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "prod"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	columns, rows, err := fetchAnalyticsRows(env, query.sql)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if len(rows) == 0 {
+		fmt.Println("no rows")
+		return 0
+	}
+	if *asCSV {
+		if err := writeAnalyticsCSV(columns, rows); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		return 0
+	}
+	fmt.Println(query.summary)
+	fmt.Println()
+	printAnalyticsTable(columns, rows)
+	return 0
+}
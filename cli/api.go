@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// api is an opt-in, localhost-only HTTP server so editor integrations
+// (VS Code, neovim) can drive the CLI without scraping a terminal.
+// Commands run as child `lurelands` processes — re-execing ourselves
+// keeps their output capturable per run without any command needing to
+// know it is being driven remotely. Logs are fetched by offset polling
+// rather than a streaming protocol; editors poll every few hundred ms
+// and that is plenty for build output.
+//
+//	GET  /v1/tasks               command tree as JSON
+//	GET  /v1/runs                all runs this session
+//	POST /v1/runs                {"args": ["content","validate"]} -> {"id": 1}
+//	GET  /v1/runs/{id}/logs?offset=N   lines from N on, plus done/exit
+//	POST /v1/runs/{id}/stop      cancel a running command
+
+// Registered in init: handleTasks walks commandTree, which would be an
+// initialization cycle as a literal tree entry (same as shell).
+func init() {
+	commandTree = append(commandTree, &command{
+		name: "api", summary: "localhost control API for editor integrations", run: runAPI,
+	})
+}
+
+// apiRunTailBytes bounds per-run in-memory capture; the full output
+// always lands in .lurelands/runs/<id>.log regardless.
+const apiRunTailBytes = 256 * 1024
+
+type apiRun struct {
+	ID      int      `json:"id"`
+	Args    []string `json:"args"`
+	Done    bool     `json:"done"`
+	Exit    int      `json:"exit"`
+	LogFile string   `json:"log_file"`
+	tail    *runner.Tail
+	cancel  context.CancelFunc
+}
+
+type apiServer struct {
+	mu      sync.Mutex
+	ctx     context.Context
+	rootDir string
+	nextID  int
+	runs    map[int]*apiRun
+}
+
+func (s *apiServer) startRun(args []string) (int, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+	runCtx, cancel := context.WithCancel(s.ctx)
+
+	s.mu.Lock()
+	s.nextID++
+	run := &apiRun{ID: s.nextID, Args: args, cancel: cancel, tail: runner.NewTail(apiRunTailBytes)}
+	s.runs[run.ID] = run
+	s.mu.Unlock()
+
+	// Full output streams to a per-run log file; memory holds only the
+	// tail, so a runaway verbose child can't OOM a long-lived server.
+	logsDir := filepath.Join(s.rootDir, ".lurelands", "runs")
+	os.MkdirAll(logsDir, 0o755)
+	logPath := filepath.Join(logsDir, fmt.Sprintf("%d.log", run.ID))
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		cancel()
+		return 0, err
+	}
+	run.LogFile = logPath
+
+	go func() {
+		defer logFile.Close()
+		result, _ := runner.RunStreaming(runCtx, runner.Spec{Name: self, Args: args},
+			runner.WriterSink(logFile),
+			func(line string, _ bool) {
+				s.mu.Lock()
+				run.tail.Add(line)
+				s.mu.Unlock()
+			})
+		s.mu.Lock()
+		run.Done = true
+		run.Exit = result.ExitCode
+		s.mu.Unlock()
+		cancel()
+	}()
+	return run.ID, nil
+}
+
+func (s *apiServer) handleTasks(w http.ResponseWriter, r *http.Request) {
+	type task struct {
+		Path    string `json:"path"`
+		Summary string `json:"summary"`
+	}
+	var tasks []task
+	var walk func(prefix string, nodes []*command)
+	walk = func(prefix string, nodes []*command) {
+		for _, node := range nodes {
+			path := strings.TrimSpace(prefix + " " + node.name)
+			if node.run != nil {
+				tasks = append(tasks, task{Path: path, Summary: node.summary})
+			}
+			walk(path, node.children)
+		}
+	}
+	walk("", commandTree)
+	writeJSON(w, tasks)
+}
+
+func (s *apiServer) handleRuns(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		var body struct {
+			Args []string `json:"args"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Args) == 0 {
+			http.Error(w, "body must be {\"args\": [...]}", http.StatusBadRequest)
+			return
+		}
+		id, err := s.startRun(body.Args)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]int{"id": id})
+		return
+	}
+	s.mu.Lock()
+	runs := make([]*apiRun, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, run)
+	}
+	s.mu.Unlock()
+	writeJSON(w, runs)
+}
+
+func (s *apiServer) handleRun(w http.ResponseWriter, r *http.Request) {
+	// /v1/runs/{id}/logs or /v1/runs/{id}/stop
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/runs/"), "/")
+	id, err := strconv.Atoi(parts[0])
+	if err != nil || len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	s.mu.Lock()
+	run := s.runs[id]
+	s.mu.Unlock()
+	if run == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch parts[1] {
+	case "logs":
+		// offset is a global line index; lines that already fell out of
+		// the in-memory tail are only in log_file.
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		s.mu.Lock()
+		lines := run.tail.Lines()
+		seen := run.tail.Seen()
+		first := seen - len(lines)
+		truncated := false
+		switch {
+		case offset < first:
+			truncated = offset >= 0 // a poller this far behind lost lines
+		case offset > seen:
+			offset = seen
+		}
+		if offset < first {
+			offset = first
+		}
+		resp := map[string]any{
+			"lines":     append([]string(nil), lines[offset-first:]...),
+			"next":      seen,
+			"truncated": truncated,
+			"log_file":  run.LogFile,
+			"done":      run.Done,
+			"exit":      run.Exit,
+		}
+		s.mu.Unlock()
+		writeJSON(w, resp)
+	case "stop":
+		if r.Method != http.MethodPost {
+			http.Error(w, "POST required", http.StatusMethodNotAllowed)
+			return
+		}
+		run.cancel()
+		writeJSON(w, map[string]bool{"ok": true})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func runAPI(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("api", flag.ContinueOnError)
+	port := flags.Int("port", 7777, "port to listen on (localhost only)")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	server := &apiServer{ctx: ctx, rootDir: rootDir, runs: map[int]*apiRun{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/tasks", server.handleTasks)
+	mux.HandleFunc("/v1/runs", server.handleRuns)
+	mux.HandleFunc("/v1/runs/", server.handleRun)
+
+	httpServer := &http.Server{Addr: fmt.Sprintf("127.0.0.1:%d", *port), Handler: mux}
+	go func() {
+		<-ctx.Done()
+		httpServer.Close()
+	}()
+
+	fmt.Printf("control API on http://%s — ctrl+c to stop\n", httpServer.Addr)
+	if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
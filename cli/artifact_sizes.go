@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// After the release build steps, each shippable artifact gets a size
+// line with a diff against the previous successful build, recorded in
+// state.json. Asset bloat ships because nobody stats an .aab by hand;
+// a +18% next to the number is hard to miss. Growth beyond the warn
+// threshold ([release] size-warn, percent, default 10) is called out
+// loudly but does not fail the release — that is a judgment call, not
+// a build error.
+
+type releaseArtifact struct {
+	name string
+	path string // a file or a directory to total up
+}
+
+func releaseArtifacts(rootDir string) []releaseArtifact {
+	appBuild := filepath.Join(appDir(rootDir), "build")
+	return []releaseArtifact{
+		{name: "appbundle", path: filepath.Join(appBuild, "app", "outputs", "bundle", "release", "app-release.aab")},
+		{name: "ipa", path: filepath.Join(appBuild, "ios", "ipa")},
+		{name: "web", path: filepath.Join(appBuild, "web")},
+		{name: "bridge dist", path: filepath.Join(bridgeDir(rootDir), "dist")},
+	}
+}
+
+func artifactSize(path string) (int64, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, false
+	}
+	if !info.IsDir() {
+		return info.Size(), true
+	}
+	_, total, err := measureDir(path)
+	return total, err == nil
+}
+
+func sizeWarnPercent(rootDir string) int {
+	value := loadConfigSections(rootDir)["release"]["size-warn"]
+	if n, err := strconv.Atoi(strings.TrimSuffix(value, "%")); err == nil && n > 0 {
+		return n
+	}
+	return 10
+}
+
+// sizeDelta renders the change against the previous build, or "new"
+// when there is no baseline.
+func sizeDelta(prev, size int64) string {
+	switch {
+	case prev == 0:
+		return "new"
+	case size == prev:
+		return "±0"
+	case size > prev:
+		return fmt.Sprintf("+%s (+%.0f%%)", formatBytes(size-prev), growthPercent(prev, size))
+	default:
+		return fmt.Sprintf("-%s (-%.0f%%)", formatBytes(prev-size), -growthPercent(prev, size))
+	}
+}
+
+func growthPercent(prev, size int64) float64 {
+	return float64(size-prev) / float64(prev) * 100
+}
+
+// reportArtifactSizes prints the size table and updates the recorded
+// baselines. With names given only those artifacts are reported (the
+// bridge deploy build cares about dist, not the app bundles).
+// Artifacts that were not built this time (ipa on Linux, bridge not
+// checked out) keep their previous baseline so the next real build
+// still diffs against something meaningful.
+func reportArtifactSizes(rootDir string, names ...string) {
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+	state := loadUserState(rootDir)
+	warnAt := sizeWarnPercent(rootDir)
+	printed := false
+	for _, artifact := range releaseArtifacts(rootDir) {
+		if len(wanted) > 0 && !wanted[artifact.name] {
+			continue
+		}
+		size, ok := artifactSize(artifact.path)
+		if !ok {
+			continue
+		}
+		if !printed {
+			fmt.Println("\nartifact sizes:")
+			printed = true
+		}
+		prev := int64(0)
+		if state.ArtifactSizes != nil {
+			prev = state.ArtifactSizes[artifact.name]
+		}
+		fmt.Printf("  %-12s %8s  %s\n", artifact.name, formatBytes(size), sizeDelta(prev, size))
+		if prev > 0 && growthPercent(prev, size) > float64(warnAt) {
+			fmt.Printf("  ! %s grew more than %d%% since the last build — check what got in\n", artifact.name, warnAt)
+		}
+		if state.ArtifactSizes == nil {
+			state.ArtifactSizes = map[string]int64{}
+		}
+		state.ArtifactSizes[artifact.name] = size
+	}
+	if printed {
+		saveUserState(rootDir, state)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSizeDelta(t *testing.T) {
+	cases := []struct {
+		prev, size int64
+		want       string
+	}{
+		{0, 5 << 20, "new"},
+		{5 << 20, 5 << 20, "±0"},
+		{4 << 20, 5 << 20, "+1.0MB (+25%)"},
+		{5 << 20, 4 << 20, "-1.0MB (-20%)"},
+	}
+	for _, c := range cases {
+		if got := sizeDelta(c.prev, c.size); got != c.want {
+			t.Errorf("sizeDelta(%d, %d) = %q, want %q", c.prev, c.size, got, c.want)
+		}
+	}
+}
+
+func TestSizeWarnPercentConfig(t *testing.T) {
+	root := t.TempDir()
+	if got := sizeWarnPercent(root); got != 10 {
+		t.Errorf("default = %d, want 10", got)
+	}
+	config := "[release]\nsize-warn = \"25%\"\n"
+	if err := os.WriteFile(filepath.Join(root, ".lurelands.toml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := sizeWarnPercent(root); got != 25 {
+		t.Errorf("configured = %d, want 25", got)
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/workspace"
+)
+
+// assets:build mirrors the app's image assets into build/assets with
+// compression applied, and packs loose per-item sprites into texture
+// atlases with frame metadata for the Flutter layer. Unchanged inputs are
+// skipped via a hash cache under .lurelands/cache.
+
+// Atlas groups: directory of loose sprites -> atlas name. Tilesheets are
+// managed in Tiled and are never repacked (see AGENTS.md).
+var atlasGroups = map[string]string{
+	"assets/items": "items",
+}
+
+func appDir(rootDir string) string {
+	return (&workspace.Workspace{Root: rootDir}).AppDir()
+}
+
+func cacheDir(rootDir string) string {
+	return (&workspace.Workspace{Root: rootDir}).CacheDir()
+}
+
+type assetCache struct {
+	path   string
+	Hashes map[string]string `json:"hashes"`
+}
+
+func loadAssetCache(rootDir string) *assetCache {
+	cache := &assetCache{
+		path:   filepath.Join(cacheDir(rootDir), "asset_hashes.json"),
+		Hashes: map[string]string{},
+	}
+	readJSONFile(cache.path, cache) // a missing cache just means a full build
+	if cache.Hashes == nil {
+		cache.Hashes = map[string]string{}
+	}
+	return cache
+}
+
+func (c *assetCache) save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return writeJSONFile(c.path, c)
+}
+
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// combinedHash hashes a set of files as one unit (order-sensitive, so
+// callers pass a sorted list).
+func combinedHash(paths []string) (string, error) {
+	hash := sha256.New()
+	for _, path := range paths {
+		fileHash, err := hashFile(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s\x00%s\x00", filepath.Base(path), fileHash)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// compressPNG writes src to dst, running it through pngquant when available
+// and keeping whichever output is smaller. Returns true when pngquant ran.
+func compressPNG(src, dst string) (bool, error) {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return false, err
+	}
+	if _, err := exec.LookPath("pngquant"); err == nil {
+		cmd := exec.Command("pngquant", "--force", "--skip-if-larger", "--output", dst, "--", src)
+		if err := cmd.Run(); err == nil {
+			if _, statErr := os.Stat(dst); statErr == nil {
+				return true, nil
+			}
+		}
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return false, err
+	}
+	return false, os.WriteFile(dst, data, 0o644)
+}
+
+type atlasFrame struct {
+	Name string `json:"name"`
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+	W    int    `json:"w"`
+	H    int    `json:"h"`
+}
+
+// packAtlas lays sprites out on a simple grid (sprites in one group share
+// dimensions in practice) and writes atlas PNG + frame metadata JSON.
+func packAtlas(sprites []string, outPNG, outJSON string) error {
+	type loaded struct {
+		name string
+		img  image.Image
+	}
+	var images []loaded
+	maxW, maxH := 0, 0
+	for _, path := range sprites {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		img, err := png.Decode(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		bounds := img.Bounds()
+		if bounds.Dx() > maxW {
+			maxW = bounds.Dx()
+		}
+		if bounds.Dy() > maxH {
+			maxH = bounds.Dy()
+		}
+		name := strings.TrimSuffix(filepath.Base(path), ".png")
+		images = append(images, loaded{name: name, img: img})
+	}
+	if len(images) == 0 {
+		return fmt.Errorf("no sprites to pack")
+	}
+
+	columns := 1
+	for columns*columns < len(images) {
+		columns++
+	}
+	rows := (len(images) + columns - 1) / columns
+
+	atlas := image.NewRGBA(image.Rect(0, 0, columns*maxW, rows*maxH))
+	var frames []atlasFrame
+	for i, sprite := range images {
+		x := (i % columns) * maxW
+		y := (i / columns) * maxH
+		bounds := sprite.img.Bounds()
+		draw.Draw(atlas, image.Rect(x, y, x+bounds.Dx(), y+bounds.Dy()), sprite.img, bounds.Min, draw.Src)
+		frames = append(frames, atlasFrame{Name: sprite.name, X: x, Y: y, W: bounds.Dx(), H: bounds.Dy()})
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outPNG), 0o755); err != nil {
+		return err
+	}
+	out, err := os.Create(outPNG)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if err := png.Encode(out, atlas); err != nil {
+		return err
+	}
+	meta := struct {
+		Image  string       `json:"image"`
+		Frames []atlasFrame `json:"frames"`
+	}{Image: filepath.Base(outPNG), Frames: frames}
+	return writeJSONFile(outJSON, meta)
+}
+
+func listPNGs(dir string) ([]string, error) {
+	var pngs []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".png") {
+			pngs = append(pngs, path)
+		}
+		return nil
+	})
+	sort.Strings(pngs)
+	return pngs, err
+}
+
+func runAssetsBuild(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("assets:build", flag.ContinueOnError)
+	force := flags.Bool("force", false, "reprocess all inputs, ignoring the cache")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	cache := loadAssetCache(rootDir)
+	outDir := filepath.Join(rootDir, "build", "assets")
+	processed, skipped, compressed := 0, 0, 0
+
+	if _, err := exec.LookPath("pngquant"); err != nil {
+		fmt.Println("note: pngquant not found; copying images without compression")
+	}
+
+	for _, sub := range []string{"assets/images", "assets/items"} {
+		srcDir := filepath.Join(appDir(rootDir), sub)
+		pngs, err := listPNGs(srcDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		for _, src := range pngs {
+			rel, _ := filepath.Rel(appDir(rootDir), src)
+			hash, err := hashFile(src)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				return 1
+			}
+			dst := filepath.Join(outDir, rel)
+			if !*force && cache.Hashes[rel] == hash {
+				if _, err := os.Stat(dst); err == nil {
+					skipped++
+					continue
+				}
+			}
+			didCompress, err := compressPNG(src, dst)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error processing %s: %v\n", rel, err)
+				return 1
+			}
+			if didCompress {
+				compressed++
+			}
+			cache.Hashes[rel] = hash
+			processed++
+		}
+	}
+
+	for dir, name := range atlasGroups {
+		sprites, err := listPNGs(filepath.Join(appDir(rootDir), dir))
+		if err != nil || len(sprites) == 0 {
+			continue
+		}
+		outPNG := filepath.Join(outDir, "atlases", name+"_atlas.png")
+		outJSON := filepath.Join(outDir, "atlases", name+"_atlas.json")
+		// The atlas only needs repacking when the sprite set changed;
+		// its cache entry is the combined hash of every input.
+		groupHash, err := combinedHash(sprites)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		cacheKey := "atlas:" + name
+		if !*force && cache.Hashes[cacheKey] == groupHash && fileExists(outPNG) && fileExists(outJSON) {
+			skipped++
+			continue
+		}
+		if err := packAtlas(sprites, outPNG, outJSON); err != nil {
+			fmt.Fprintf(os.Stderr, "error packing atlas %s: %v\n", name, err)
+			return 1
+		}
+		cache.Hashes[cacheKey] = groupHash
+		fmt.Printf("packed atlas %s (%d frames)\n", name, len(sprites))
+	}
+
+	if err := cache.save(); err != nil {
+		fmt.Fprintln(os.Stderr, "error saving cache:", err)
+		return 1
+	}
+	fmt.Printf("assets: %d processed (%d compressed), %d up to date -> %s\n",
+		processed, compressed, skipped, filepath.Join("build", "assets"))
+	return 0
+}
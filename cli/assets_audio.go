@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// assets:audio converts source WAVs under assets/audio_src into the
+// compressed formats each platform ships (ogg for Android/web, m4a for iOS),
+// normalizing loudness along the way, and verifies that every sound
+// referenced from content files exists in the converted output set.
+
+var audioTargets = []struct {
+	ext  string
+	args []string
+}{
+	// EBU R128 loudness normalization keeps effects at a consistent level
+	// regardless of how the source was exported.
+	{".ogg", []string{"-c:a", "libvorbis", "-qscale:a", "5", "-af", "loudnorm"}},
+	{".m4a", []string{"-c:a", "aac", "-b:a", "128k", "-af", "loudnorm"}},
+}
+
+func audioSrcDir(rootDir string) string {
+	return filepath.Join(appDir(rootDir), "assets", "audio_src")
+}
+
+func audioOutDir(rootDir string) string {
+	return filepath.Join(appDir(rootDir), "assets", "audio")
+}
+
+// collectSoundRefs walks the content files for string values that look like
+// sound references (assets/audio/...). Content entries gain sound fields
+// over time; scanning values keeps this check from lagging the schema.
+func collectSoundRefs(rootDir string) ([]string, error) {
+	entries, err := os.ReadDir(contentDir(rootDir))
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		var raw any
+		if err := readJSONFile(filepath.Join(contentDir(rootDir), entry.Name()), &raw); err != nil {
+			return nil, err
+		}
+		walkStrings(raw, func(s string) {
+			if strings.HasPrefix(s, "assets/audio/") {
+				seen[s] = true
+			}
+		})
+	}
+	refs := make([]string, 0, len(seen))
+	for ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs, nil
+}
+
+func walkStrings(value any, visit func(string)) {
+	switch v := value.(type) {
+	case string:
+		visit(v)
+	case []any:
+		for _, item := range v {
+			walkStrings(item, visit)
+		}
+	case map[string]any:
+		for _, item := range v {
+			walkStrings(item, visit)
+		}
+	}
+}
+
+func runAssetsAudio(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("assets:audio", flag.ContinueOnError)
+	force := flags.Bool("force", false, "reconvert all sources, ignoring the cache")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	srcDir := audioSrcDir(rootDir)
+	outDir := audioOutDir(rootDir)
+
+	var wavs []string
+	if entries, err := os.ReadDir(srcDir); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".wav") {
+				wavs = append(wavs, filepath.Join(srcDir, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(wavs)
+
+	converted, skipped := 0, 0
+	if len(wavs) > 0 {
+		if _, err := exec.LookPath("ffmpeg"); err != nil {
+			fmt.Fprintln(os.Stderr, "error: ffmpeg is required to convert audio but was not found on PATH")
+			return 1
+		}
+		cache := loadAssetCache(rootDir)
+		for _, src := range wavs {
+			rel, _ := filepath.Rel(appDir(rootDir), src)
+			hash, err := hashFile(src)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				return 1
+			}
+			base := strings.TrimSuffix(filepath.Base(src), filepath.Ext(src))
+			if !*force && cache.Hashes[rel] == hash {
+				skipped++
+				continue
+			}
+			for _, target := range audioTargets {
+				dst := filepath.Join(outDir, base+target.ext)
+				if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+					fmt.Fprintln(os.Stderr, "error:", err)
+					return 1
+				}
+				cmdArgs := append([]string{"-y", "-i", src}, target.args...)
+				cmdArgs = append(cmdArgs, dst)
+				if out, err := exec.Command("ffmpeg", cmdArgs...).CombinedOutput(); err != nil {
+					fmt.Fprintf(os.Stderr, "error converting %s: %v\n%s", rel, err, out)
+					return 1
+				}
+			}
+			cache.Hashes[rel] = hash
+			converted++
+		}
+		if err := cache.save(); err != nil {
+			fmt.Fprintln(os.Stderr, "error saving cache:", err)
+			return 1
+		}
+	}
+
+	refs, err := collectSoundRefs(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	missing := 0
+	for _, ref := range refs {
+		// References omit the extension check: a ref is satisfied if any
+		// converted format exists for it.
+		base := strings.TrimSuffix(ref, filepath.Ext(ref))
+		found := false
+		for _, target := range audioTargets {
+			if _, err := os.Stat(filepath.Join(appDir(rootDir), base+target.ext)); err == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fmt.Fprintf(os.Stderr, "missing sound: %s (referenced from content)\n", ref)
+			missing++
+		}
+	}
+
+	fmt.Printf("audio: %d converted, %d up to date, %d referenced sound(s), %d missing\n",
+		converted, skipped, len(refs), missing)
+	if missing > 0 {
+		return 1
+	}
+	return 0
+}
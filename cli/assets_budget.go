@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// assets:budget measures the built asset artifacts against the budgets in
+// apps/lurelands/asset_budgets.json and fails (for CI) when any budget is
+// exceeded, with a top-offenders breakdown.
+
+type assetBudgets struct {
+	TotalBytes   int64            `json:"total_bytes"`
+	PerFileBytes int64            `json:"per_file_bytes"`
+	Atlases      map[string]int64 `json:"atlases"`
+}
+
+type sizedFile struct {
+	rel  string
+	size int64
+}
+
+func measureDir(dir string) ([]sizedFile, int64, error) {
+	var files []sizedFile
+	var total int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files = append(files, sizedFile{rel: rel, size: info.Size()})
+		total += info.Size()
+		return nil
+	})
+	sort.Slice(files, func(i, j int) bool { return files[i].size > files[j].size })
+	return files, total, err
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%dB", n)
+	}
+}
+
+func runAssetsBudget(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("assets:budget", flag.ContinueOnError)
+	topN := flags.Int("top", 10, "number of largest files to list")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	var budgets assetBudgets
+	if err := readJSONFile(filepath.Join(appDir(rootDir), "asset_budgets.json"), &budgets); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	builtDir := filepath.Join(rootDir, "build", "assets")
+	if _, err := os.Stat(builtDir); err != nil {
+		fmt.Fprintln(os.Stderr, "error: no built assets found; run `lurelands assets:build` first")
+		return 1
+	}
+
+	files, total, err := measureDir(builtDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	violations := 0
+	fmt.Printf("assets: %d file(s), %s total (budget %s)\n", len(files), formatBytes(total), formatBytes(budgets.TotalBytes))
+	if budgets.TotalBytes > 0 && total > budgets.TotalBytes {
+		fmt.Printf("  ! total size exceeds budget by %s\n", formatBytes(total-budgets.TotalBytes))
+		violations++
+	}
+
+	for _, file := range files {
+		if budgets.PerFileBytes > 0 && file.size > budgets.PerFileBytes {
+			fmt.Printf("  ! %s is %s (per-file budget %s)\n", file.rel, formatBytes(file.size), formatBytes(budgets.PerFileBytes))
+			violations++
+		}
+	}
+
+	for name, limit := range budgets.Atlases {
+		atlasPath := filepath.Join(builtDir, "atlases", name+"_atlas.png")
+		info, err := os.Stat(atlasPath)
+		if err != nil {
+			fmt.Printf("  ! atlas %q has a budget but no built output\n", name)
+			violations++
+			continue
+		}
+		if info.Size() > limit {
+			fmt.Printf("  ! atlas %s is %s (budget %s)\n", name, formatBytes(info.Size()), formatBytes(limit))
+			violations++
+		}
+	}
+
+	fmt.Println("\ntop offenders:")
+	for i, file := range files {
+		if i >= *topN {
+			break
+		}
+		fmt.Printf("  %8s  %s\n", formatBytes(file.size), file.rel)
+	}
+
+	if violations > 0 {
+		fmt.Printf("\nassets: %d budget violation(s)\n", violations)
+		return 1
+	}
+	fmt.Println("\nassets: within budget")
+	return 0
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// assets:spritesheets merges directories of per-frame animation PNGs (as
+// exported from the art tool, one directory per animation under
+// assets/frames/) into horizontal strip sheets plus frame metadata JSON for
+// the Flutter renderer — the same strip layout the character sheets use
+// (e.g. base_walk_strip8.png).
+
+func framesDir(rootDir string) string {
+	return filepath.Join(appDir(rootDir), "assets", "frames")
+}
+
+func spritesheetOutDir(rootDir string) string {
+	return filepath.Join(appDir(rootDir), "assets", "images", "generated")
+}
+
+type stripMeta struct {
+	Image       string `json:"image"`
+	FrameCount  int    `json:"frame_count"`
+	FrameWidth  int    `json:"frame_width"`
+	FrameHeight int    `json:"frame_height"`
+	FPS         int    `json:"fps"`
+}
+
+// buildStrip merges ordered frames into one horizontal strip. All frames in
+// an animation must share dimensions; mismatches are an export error.
+func buildStrip(framePaths []string, outPNG, outJSON string, fps int) error {
+	var frames []image.Image
+	for _, path := range framePaths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		img, err := png.Decode(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		frames = append(frames, img)
+	}
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames")
+	}
+	width := frames[0].Bounds().Dx()
+	height := frames[0].Bounds().Dy()
+	for i, frame := range frames {
+		if frame.Bounds().Dx() != width || frame.Bounds().Dy() != height {
+			return fmt.Errorf("%s is %dx%d, expected %dx%d like frame 0",
+				filepath.Base(framePaths[i]), frame.Bounds().Dx(), frame.Bounds().Dy(), width, height)
+		}
+	}
+
+	strip := image.NewRGBA(image.Rect(0, 0, width*len(frames), height))
+	for i, frame := range frames {
+		draw.Draw(strip, image.Rect(i*width, 0, (i+1)*width, height), frame, frame.Bounds().Min, draw.Src)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outPNG), 0o755); err != nil {
+		return err
+	}
+	out, err := os.Create(outPNG)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if err := png.Encode(out, strip); err != nil {
+		return err
+	}
+	return writeJSONFile(outJSON, stripMeta{
+		Image:       filepath.Base(outPNG),
+		FrameCount:  len(frames),
+		FrameWidth:  width,
+		FrameHeight: height,
+		FPS:         fps,
+	})
+}
+
+func runAssetsSpritesheets(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("assets:spritesheets", flag.ContinueOnError)
+	fps := flags.Int("fps", 8, "playback rate recorded in the frame metadata")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	srcDir := framesDir(rootDir)
+	animations, err := os.ReadDir(srcDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: no frame exports at %s\n", srcDir)
+		fmt.Fprintln(os.Stderr, "export per-frame PNGs into assets/frames/<animation>/ first")
+		return 1
+	}
+
+	built := 0
+	for _, animation := range animations {
+		if !animation.IsDir() {
+			continue
+		}
+		var framePaths []string
+		entries, err := os.ReadDir(filepath.Join(srcDir, animation.Name()))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		for _, entry := range entries {
+			if strings.EqualFold(filepath.Ext(entry.Name()), ".png") {
+				framePaths = append(framePaths, filepath.Join(srcDir, animation.Name(), entry.Name()))
+			}
+		}
+		if len(framePaths) == 0 {
+			continue
+		}
+		// Frames are ordered by file name; art exports number them 000, 001…
+		sort.Strings(framePaths)
+
+		name := fmt.Sprintf("%s_strip%d", animation.Name(), len(framePaths))
+		outPNG := filepath.Join(spritesheetOutDir(rootDir), name+".png")
+		outJSON := filepath.Join(spritesheetOutDir(rootDir), name+".json")
+		if err := buildStrip(framePaths, outPNG, outJSON, *fps); err != nil {
+			fmt.Fprintf(os.Stderr, "error building %s: %v\n", animation.Name(), err)
+			return 1
+		}
+		fmt.Printf("built %s (%d frames)\n", name+".png", len(framePaths))
+		built++
+	}
+
+	if built == 0 {
+		fmt.Println("spritesheets: no animations to build")
+		return 0
+	}
+	fmt.Printf("spritesheets: %d built -> %s\n", built, spritesheetOutDir(rootDir))
+	return 0
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// assets:unused cross-references every file under the app's asset
+// directories with references from Dart code, content files, and the Tiled
+// maps/tilesets, and lists the orphans bloating the bundle. Asset
+// directories are declared wholesale in pubspec.yaml, so "declared" doesn't
+// mean "used" — only an actual reference counts.
+
+var assetPathRef = regexp.MustCompile(`assets/[A-Za-z0-9_/.\- ]+\.[A-Za-z0-9]+`)
+var xmlSourceRef = regexp.MustCompile(`source="([^"]+)"`)
+
+// collectAssetReferences gathers every referenced asset path or file name
+// from Dart sources, content JSON, and Tiled files.
+func collectAssetReferences(rootDir string) (map[string]bool, error) {
+	refs := map[string]bool{}
+	addRef := func(s string) {
+		refs[strings.ToLower(filepath.Base(s))] = true
+	}
+
+	// Dart sources and content files reference assets by path.
+	scanDirs := []struct {
+		dir string
+		ext string
+	}{
+		{filepath.Join(appDir(rootDir), "lib"), ".dart"},
+		{contentDir(rootDir), ".json"},
+	}
+	for _, scan := range scanDirs {
+		err := filepath.WalkDir(scan.dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, scan.ext) {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for _, match := range assetPathRef.FindAllString(string(data), -1) {
+				addRef(match)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Tiled maps reference tilesets, and tilesets reference their images.
+	assetsDir := filepath.Join(appDir(rootDir), "assets")
+	err := filepath.WalkDir(assetsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".tmx" && ext != ".tsx" {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+		for scanner.Scan() {
+			for _, match := range xmlSourceRef.FindAllStringSubmatch(scanner.Text(), -1) {
+				addRef(match[1])
+			}
+		}
+		return scanner.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+func runAssetsUnused(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("assets:unused", flag.ContinueOnError)
+	doDelete := flags.Bool("delete", false, "delete the orphaned files")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	refs, err := collectAssetReferences(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	assetsDir := filepath.Join(appDir(rootDir), "assets")
+	var orphans []sizedFile
+	var totalOrphanBytes int64
+	err = filepath.WalkDir(assetsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		// Tiled project files are editor inputs, not runtime assets.
+		if ext == ".tmx" || ext == ".tsx" {
+			return nil
+		}
+		if refs[strings.ToLower(d.Name())] {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(appDir(rootDir), path)
+		orphans = append(orphans, sizedFile{rel: rel, size: info.Size()})
+		totalOrphanBytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	if len(orphans) == 0 {
+		fmt.Println("assets: no unused assets found")
+		return 0
+	}
+
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].size > orphans[j].size })
+	for _, orphan := range orphans {
+		fmt.Printf("  %8s  %s\n", formatBytes(orphan.size), orphan.rel)
+	}
+	fmt.Printf("\nassets: %d unused file(s), %s\n", len(orphans), formatBytes(totalOrphanBytes))
+
+	if *doDelete {
+		for _, orphan := range orphans {
+			if err := os.Remove(filepath.Join(appDir(rootDir), orphan.rel)); err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				return 1
+			}
+		}
+		fmt.Printf("deleted %d file(s)\n", len(orphans))
+	}
+	return 0
+}
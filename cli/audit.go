@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// audit merges security advisories from every ecosystem into one
+// severity-sorted report: `cargo audit` for the module, `bun audit` for
+// the bridge, and the pub.dev advisories API for the app (Dart has no
+// offline audit command). High and critical findings make the command
+// exit non-zero, which is what lets it gate releases in CI.
+
+type auditFinding struct {
+	Ecosystem string
+	Package   string
+	Version   string
+	Severity  string // critical > high > moderate > low > unknown
+	ID        string
+	Title     string
+}
+
+var severityRank = map[string]int{
+	"critical": 0, "high": 1, "moderate": 2, "medium": 2, "low": 3, "unknown": 4,
+}
+
+func normalizeSeverity(s string) string {
+	s = strings.ToLower(s)
+	if _, ok := severityRank[s]; !ok {
+		return "unknown"
+	}
+	if s == "medium" {
+		return "moderate"
+	}
+	return s
+}
+
+func auditCargo(ctx context.Context, rootDir string) ([]auditFinding, error) {
+	if _, ok := lookupTool(rootDir, "cargo-audit"); !ok {
+		if _, ok := lookupTool(rootDir, "cargo"); !ok {
+			return nil, errToolNotFound("cargo", "auditing module dependencies")
+		}
+	}
+	result, _ := runner.Run(ctx, runner.Spec{
+		Name: "cargo",
+		Args: []string{"audit", "--json"},
+		Dir:  moduleDir(rootDir),
+	})
+	// cargo audit exits non-zero when it finds vulnerabilities; the JSON
+	// is still the report, so the exit code is ignored here.
+	var report struct {
+		Vulnerabilities struct {
+			List []struct {
+				Advisory struct {
+					ID       string `json:"id"`
+					Title    string `json:"title"`
+					Severity string `json:"severity"`
+				} `json:"advisory"`
+				Package struct {
+					Name    string `json:"name"`
+					Version string `json:"version"`
+				} `json:"package"`
+			} `json:"list"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal([]byte(result.Output), &report); err != nil {
+		return nil, fmt.Errorf("cargo audit output not parseable (is cargo-audit installed?): %w", err)
+	}
+	findings := make([]auditFinding, 0, len(report.Vulnerabilities.List))
+	for _, vuln := range report.Vulnerabilities.List {
+		findings = append(findings, auditFinding{
+			Ecosystem: "cargo",
+			Package:   vuln.Package.Name,
+			Version:   vuln.Package.Version,
+			Severity:  normalizeSeverity(vuln.Advisory.Severity),
+			ID:        vuln.Advisory.ID,
+			Title:     vuln.Advisory.Title,
+		})
+	}
+	return findings, nil
+}
+
+func auditBun(ctx context.Context, rootDir string) ([]auditFinding, error) {
+	if _, ok := lookupTool(rootDir, "bun"); !ok {
+		return nil, errToolNotFound("bun", "auditing bridge dependencies")
+	}
+	result, _ := runner.Run(ctx, runner.Spec{
+		Name: "bun",
+		Args: []string{"audit", "--json"},
+		Dir:  bridgeDir(rootDir),
+	})
+	// bun emits the npm advisory format: a map of advisories keyed by id.
+	var report struct {
+		Advisories map[string]struct {
+			ModuleName         string `json:"module_name"`
+			Severity           string `json:"severity"`
+			Title              string `json:"title"`
+			VulnerableVersions string `json:"vulnerable_versions"`
+		} `json:"advisories"`
+	}
+	if err := json.Unmarshal([]byte(result.Output), &report); err != nil {
+		return nil, fmt.Errorf("bun audit output not parseable: %w", err)
+	}
+	var findings []auditFinding
+	for id, advisory := range report.Advisories {
+		findings = append(findings, auditFinding{
+			Ecosystem: "bun",
+			Package:   advisory.ModuleName,
+			Version:   advisory.VulnerableVersions,
+			Severity:  normalizeSeverity(advisory.Severity),
+			ID:        id,
+			Title:     advisory.Title,
+		})
+	}
+	return findings, nil
+}
+
+func auditPub(ctx context.Context, rootDir string) ([]auditFinding, error) {
+	packages, err := lockedPubPackages(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	client := http.Client{Timeout: 15 * time.Second}
+	var findings []auditFinding
+	for _, pkg := range packages {
+		url := "https://pub.dev/api/packages/" + pkg.Name + "/advisories"
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("pub.dev advisories: %w", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			continue // unknown package (path deps etc.)
+		}
+		var report struct {
+			Advisories []struct {
+				ID               string `json:"id"`
+				Summary          string `json:"summary"`
+				DatabaseSpecific struct {
+					Severity string `json:"severity"`
+				} `json:"database_specific"`
+			} `json:"advisories"`
+		}
+		if json.Unmarshal(body, &report) != nil {
+			continue
+		}
+		for _, advisory := range report.Advisories {
+			findings = append(findings, auditFinding{
+				Ecosystem: "pub",
+				Package:   pkg.Name,
+				Version:   pkg.Version,
+				Severity:  normalizeSeverity(advisory.DatabaseSpecific.Severity),
+				ID:        advisory.ID,
+				Title:     advisory.Summary,
+			})
+		}
+	}
+	return findings, nil
+}
+
+func runAudit(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("audit", flag.ContinueOnError)
+	failOn := flags.String("fail-on", "high", "lowest severity that fails the run (critical, high, moderate, low)")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	failRank, ok := severityRank[normalizeSeverity(*failOn)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "error: bad --fail-on %q\n", *failOn)
+		return exitUsage
+	}
+
+	var findings []auditFinding
+	auditors := []struct {
+		name string
+		run  func() ([]auditFinding, error)
+	}{
+		{"cargo", func() ([]auditFinding, error) { return auditCargo(ctx, rootDir) }},
+		{"bun", func() ([]auditFinding, error) { return auditBun(ctx, rootDir) }},
+		{"pub", func() ([]auditFinding, error) { return auditPub(ctx, rootDir) }},
+	}
+	for _, auditor := range auditors {
+		found, err := auditor.run()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %s audit: %v\n", auditor.name, err)
+			continue
+		}
+		findings = append(findings, found...)
+	}
+
+	if len(findings) == 0 {
+		fmt.Println("no known vulnerabilities across cargo, bun, and pub 🎉")
+		return 0
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		if severityRank[findings[i].Severity] != severityRank[findings[j].Severity] {
+			return severityRank[findings[i].Severity] < severityRank[findings[j].Severity]
+		}
+		return findings[i].Package < findings[j].Package
+	})
+
+	failing := 0
+	for _, finding := range findings {
+		fmt.Printf("%-9s %-6s %-30s %-14s %s (%s)\n",
+			finding.Severity, finding.Ecosystem, finding.Package, finding.Version,
+			shorten(finding.Title, 50), finding.ID)
+		if severityRank[finding.Severity] <= failRank {
+			failing++
+		}
+	}
+	fmt.Printf("\n%d finding(s), %d at or above %s\n", len(findings), failing, normalizeSeverity(*failOn))
+	if failing > 0 {
+		return 1
+	}
+	return 0
+}
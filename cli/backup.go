@@ -0,0 +1,273 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// db:backup archives every user table of an environment into one
+// .tar.gz under .lurelands/backups/<env>/, and `backup daemon` runs
+// those backups on a cron schedule, rotates old archives by retention,
+// and optionally uploads each archive to the remote cache bucket. The
+// last run per environment is recorded so `lurelands status` can answer
+// "when did prod last get backed up" without grepping logs. The cron
+// parser covers the subset we use: *, */n, plain numbers, and comma
+// lists across the usual five fields.
+
+func backupsDir(rootDir, envName string) string {
+	return filepath.Join(rootDir, ".lurelands", "backups", envName)
+}
+
+type backupStatus struct {
+	Env        string    `json:"env"`
+	Archive    string    `json:"archive"`
+	FinishedAt time.Time `json:"finished_at"`
+	SizeBytes  int64     `json:"size_bytes"`
+	Uploaded   bool      `json:"uploaded"`
+}
+
+func backupStatusPath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "backup_status.json")
+}
+
+func loadBackupStatuses(rootDir string) map[string]backupStatus {
+	statuses := map[string]backupStatus{}
+	data, err := os.ReadFile(backupStatusPath(rootDir))
+	if err == nil {
+		json.Unmarshal(data, &statuses)
+	}
+	return statuses
+}
+
+func recordBackupStatus(rootDir string, status backupStatus) {
+	statuses := loadBackupStatuses(rootDir)
+	statuses[status.Env] = status
+	data, _ := json.MarshalIndent(statuses, "", "  ")
+	os.WriteFile(backupStatusPath(rootDir), data, 0o644)
+}
+
+// backupEnvironment dumps all user tables into one tar.gz archive.
+func backupEnvironment(ctx context.Context, rootDir string, env environment, upload bool) (backupStatus, error) {
+	status := backupStatus{Env: env.Name}
+	tables, err := snapshotTables(env)
+	if err != nil {
+		return status, err
+	}
+	dir := backupsDir(rootDir, env.Name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return status, err
+	}
+	archive := filepath.Join(dir, time.Now().Format("20060102-150405")+".tar.gz")
+	f, err := os.Create(archive)
+	if err != nil {
+		return status, err
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, table := range tables {
+		out, err := exec.CommandContext(ctx, "spacetime", "sql", "--server", env.Server, env.Module,
+			"SELECT * FROM "+table, "--json").Output()
+		if err != nil {
+			f.Close()
+			os.Remove(archive)
+			return status, fmt.Errorf("dumping %s: %w", table, err)
+		}
+		header := &tar.Header{Name: table + ".json", Mode: 0o644, Size: int64(len(out)), ModTime: time.Now()}
+		if err := tw.WriteHeader(header); err != nil {
+			f.Close()
+			return status, err
+		}
+		if _, err := tw.Write(out); err != nil {
+			f.Close()
+			return status, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return status, err
+	}
+	if err := gz.Close(); err != nil {
+		return status, err
+	}
+	if err := f.Close(); err != nil {
+		return status, err
+	}
+
+	info, _ := os.Stat(archive)
+	status.Archive = archive
+	status.FinishedAt = time.Now()
+	if info != nil {
+		status.SizeBytes = info.Size()
+	}
+	if upload {
+		if base := remoteCacheBase(); base != "" {
+			key := "backups/" + env.Name + "/" + filepath.Base(archive)
+			if err := pushArtifact(ctx, base, key, archive); err != nil {
+				fmt.Fprintln(os.Stderr, "warning: backup upload:", err)
+			} else {
+				status.Uploaded = true
+			}
+		}
+	}
+	recordBackupStatus(rootDir, status)
+	return status, nil
+}
+
+// rotateBackups removes archives older than the retention window.
+func rotateBackups(rootDir, envName string, keep time.Duration) int {
+	entries, _ := filepath.Glob(filepath.Join(backupsDir(rootDir, envName), "*.tar.gz"))
+	cutoff := time.Now().Add(-keep)
+	removed := 0
+	for _, entry := range entries {
+		info, err := os.Stat(entry)
+		if err == nil && info.ModTime().Before(cutoff) {
+			if os.Remove(entry) == nil {
+				removed++
+			}
+		}
+	}
+	return removed
+}
+
+// cronFieldMatches reports whether value satisfies one cron field.
+func cronFieldMatches(field string, value int) bool {
+	for _, part := range strings.Split(field, ",") {
+		switch {
+		case part == "*":
+			return true
+		case strings.HasPrefix(part, "*/"):
+			if n, err := strconv.Atoi(part[2:]); err == nil && n > 0 && value%n == 0 {
+				return true
+			}
+		default:
+			if n, err := strconv.Atoi(part); err == nil && n == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cronMatches reports whether t satisfies a five-field cron expression
+// (minute hour day-of-month month day-of-week).
+func cronMatches(expr string, t time.Time) (bool, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return false, fmt.Errorf("cron expression needs 5 fields, got %q", expr)
+	}
+	values := []int{t.Minute(), t.Hour(), t.Day(), int(t.Month()), int(t.Weekday())}
+	for i, field := range fields {
+		if !cronFieldMatches(field, values[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func runDBBackup(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("db backup", flag.ContinueOnError)
+	envName := flags.String("env", "", "environment to back up")
+	upload := flags.Bool("upload", false, "upload the archive to the remote cache bucket")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	status, err := backupEnvironment(ctx, rootDir, env, *upload)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Printf("backed up %s to %s (%.1f MB)\n", env.Name, status.Archive, float64(status.SizeBytes)/(1<<20))
+	return 0
+}
+
+func runBackupDaemon(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("backup daemon", flag.ContinueOnError)
+	envList := flags.String("envs", "local", "comma-separated environments to back up")
+	schedule := flags.String("cron", "0 3 * * *", "cron expression for backup runs")
+	retention := flags.String("retention", "14d", "how long to keep archives")
+	upload := flags.Bool("upload", false, "upload archives to the remote cache bucket")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	keep, err := parseAgeLimit(*retention)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return exitUsage
+	}
+	if _, err := cronMatches(*schedule, time.Now()); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return exitUsage
+	}
+	var envs []environment
+	for _, name := range strings.Split(*envList, ",") {
+		env, err := resolveEnvironment(strings.TrimSpace(name))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return exitUsage
+		}
+		envs = append(envs, env)
+	}
+
+	fmt.Printf("backup daemon: %d env(s) on %q, retention %s — ctrl+c to stop\n", len(envs), *schedule, *retention)
+	// Tick on minute boundaries so the cron match sees each minute once.
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return exitCancelled
+		case now := <-ticker.C:
+			if match, _ := cronMatches(*schedule, now); !match {
+				continue
+			}
+			for _, env := range envs {
+				fmt.Printf("[%s] backing up %s…\n", now.Format("15:04"), env.Name)
+				if _, err := backupEnvironment(ctx, rootDir, env, *upload); err != nil {
+					fmt.Fprintf(os.Stderr, "warning: %s backup failed: %v\n", env.Name, err)
+					continue
+				}
+				if removed := rotateBackups(rootDir, env.Name, keep); removed > 0 {
+					fmt.Printf("  rotated %d old archive(s)\n", removed)
+				}
+			}
+		}
+	}
+}
+
+func runStatus(ctx context.Context, rootDir string, args []string) int {
+	statuses := loadBackupStatuses(rootDir)
+	fmt.Println("backups:")
+	if len(statuses) == 0 {
+		fmt.Println("  none recorded")
+	}
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		status := statuses[name]
+		uploaded := ""
+		if status.Uploaded {
+			uploaded = ", uploaded"
+		}
+		fmt.Printf("  %-8s %s (%.1f MB%s)\n", name,
+			status.FinishedAt.Format("2006-01-02 15:04"), float64(status.SizeBytes)/(1<<20), uploaded)
+	}
+	return 0
+}
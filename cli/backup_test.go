@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronMatches(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 8, 31, hour, minute, 0, 0, time.UTC) // a Monday
+	}
+	cases := []struct {
+		expr string
+		time time.Time
+		want bool
+	}{
+		{"0 3 * * *", at(3, 0), true},
+		{"0 3 * * *", at(3, 1), false},
+		{"*/15 * * * *", at(9, 45), true},
+		{"*/15 * * * *", at(9, 50), false},
+		{"0 3,15 * * *", at(15, 0), true},
+		{"0 3 * * 1", at(3, 0), true}, // Monday
+		{"0 3 * * 2", at(3, 0), false},
+	}
+	for _, c := range cases {
+		got, err := cronMatches(c.expr, c.time)
+		if err != nil {
+			t.Fatalf("%q: %v", c.expr, err)
+		}
+		if got != c.want {
+			t.Errorf("cronMatches(%q, %s) = %v, want %v", c.expr, c.time, got, c.want)
+		}
+	}
+}
+
+func TestCronRejectsBadExpression(t *testing.T) {
+	if _, err := cronMatches("0 3 * *", time.Now()); err == nil {
+		t.Error("expected an error for a 4-field expression")
+	}
+}
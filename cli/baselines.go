@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Named performance baselines for the loadtest/benchmark commands. A
+// baseline is a frozen copy of a run's headline numbers; later runs
+// compare against it and fail when p95 regresses past the threshold, so
+// CI can catch a slow reducer before players do. Baselines live under
+// .lurelands/baselines/ and are meant to be committed to CI artifact
+// storage, not git.
+
+type perfBaseline struct {
+	Name      string    `json:"name"`
+	SavedAt   time.Time `json:"saved_at"`
+	Profile   string    `json:"profile"`
+	Env       string    `json:"env"`
+	P50ms     float64   `json:"p50_ms"`
+	P95ms     float64   `json:"p95_ms"`
+	P99ms     float64   `json:"p99_ms"`
+	ErrorRate float64   `json:"avg_errors_per_minute"`
+}
+
+func baselinesDir(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "baselines")
+}
+
+func baselinePath(rootDir, name string) string {
+	return filepath.Join(baselinesDir(rootDir), name+".json")
+}
+
+func baselineFromRun(name string, result loadRunResult) perfBaseline {
+	p50, p95, p99 := latencyPercentiles(result.Samples)
+	var errSum float64
+	for _, s := range result.Samples {
+		errSum += s.ErrorsPerMin
+	}
+	avgErr := 0.0
+	if len(result.Samples) > 0 {
+		avgErr = errSum / float64(len(result.Samples))
+	}
+	return perfBaseline{
+		Name: name, SavedAt: time.Now(), Profile: result.Profile, Env: result.Env,
+		P50ms: p50, P95ms: p95, P99ms: p99, ErrorRate: avgErr,
+	}
+}
+
+func saveBaseline(rootDir string, baseline perfBaseline) error {
+	if err := os.MkdirAll(baselinesDir(rootDir), 0o755); err != nil {
+		return err
+	}
+	data, _ := json.MarshalIndent(baseline, "", "  ")
+	return os.WriteFile(baselinePath(rootDir, baseline.Name), data, 0o644)
+}
+
+func loadBaseline(rootDir, name string) (*perfBaseline, error) {
+	data, err := os.ReadFile(baselinePath(rootDir, name))
+	if err != nil {
+		return nil, fmt.Errorf("baseline %q not found (save one with --save-baseline)", name)
+	}
+	var baseline perfBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("parsing baseline %q: %w", name, err)
+	}
+	return &baseline, nil
+}
+
+// compareToBaseline prints the delta and reports whether the run regressed
+// beyond threshold (a fraction, e.g. 0.15 allows +15% on p95).
+func compareToBaseline(current perfBaseline, baseline perfBaseline, threshold float64) bool {
+	regressed := false
+	check := func(label string, now, then float64) {
+		delta := 0.0
+		if then > 0 {
+			delta = (now - then) / then
+		}
+		marker := ""
+		if then > 0 && delta > threshold {
+			marker = "  ← REGRESSION"
+			regressed = true
+		}
+		fmt.Printf("  %-12s %8.1f ms vs %8.1f ms (%+.1f%%)%s\n", label, now, then, delta*100, marker)
+	}
+	fmt.Printf("comparing against baseline %q (%s, saved %s)\n",
+		baseline.Name, baseline.Profile, baseline.SavedAt.Format("2006-01-02"))
+	check("p50", current.P50ms, baseline.P50ms)
+	check("p95", current.P95ms, baseline.P95ms)
+	check("p99", current.P99ms, baseline.P99ms)
+	if baseline.ErrorRate > 0 && current.ErrorRate > baseline.ErrorRate*(1+threshold) {
+		fmt.Printf("  %-12s %8.2f/min vs %8.2f/min  ← REGRESSION\n", "errors", current.ErrorRate, baseline.ErrorRate)
+		regressed = true
+	}
+	return regressed
+}
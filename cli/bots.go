@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// bots drives N scripted headless clients against the local stack so one
+// developer can exercise multiplayer sync without N devices. Each bot is
+// a bridge-side script (services/bridge/scripts/bot.ts) — the CLI only
+// supervises the processes: staggered starts, restart-on-crash, and a
+// periodic roll-up of who is still alive.
+
+var botBehaviors = []string{
+	"fish_loop", // walk to the nearest fishing spot and cast forever
+	"wander",    // random walk, no fishing — pure movement traffic
+	"shopper",   // cycle between fishing and buying/selling
+	"idle",      // connect and stand still, for connection-count testing
+}
+
+type botSupervisor struct {
+	mu      sync.Mutex
+	alive   int
+	crashes int
+}
+
+func (s *botSupervisor) run(rootDir string, env environment, id int, behavior string, done <-chan struct{}) {
+	name := fmt.Sprintf("bot-%03d", id)
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		cmd := exec.Command("bun", "run", "scripts/bot.ts",
+			"--name", name, "--behavior", behavior, "--server", env.Server, "--module", env.Module)
+		cmd.Dir = bridgeDir(rootDir)
+		if err := cmd.Start(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			return
+		}
+		s.mu.Lock()
+		s.alive++
+		s.mu.Unlock()
+
+		waitErr := make(chan error, 1)
+		go func() { waitErr <- cmd.Wait() }()
+
+		select {
+		case <-done:
+			cmd.Process.Kill()
+			<-waitErr
+			s.mu.Lock()
+			s.alive--
+			s.mu.Unlock()
+			return
+		case err := <-waitErr:
+			s.mu.Lock()
+			s.alive--
+			if err != nil {
+				s.crashes++
+			}
+			s.mu.Unlock()
+			if err == nil {
+				return // clean exit, don't respawn
+			}
+			time.Sleep(time.Second) // crashed: back off, then reconnect
+		}
+	}
+}
+
+func runBots(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 || args[0] != "run" {
+		fmt.Fprintln(os.Stderr, "usage: lurelands bots run [--count N] [--behavior <name>]")
+		return 1
+	}
+
+	flags := flag.NewFlagSet("bots run", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	count := flags.Int("count", 20, "number of bots")
+	behavior := flags.String("behavior", "fish_loop", "bot script behavior")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 1
+	}
+
+	if !contains(botBehaviors, *behavior) {
+		fmt.Fprintf(os.Stderr, "unknown behavior %q (available: %v)\n", *behavior, botBehaviors)
+		return 1
+	}
+	if _, err := exec.LookPath("bun"); err != nil {
+		return reportError(errToolNotFound("bun", "run bridge bot scripts"))
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if _, err := os.Stat(bridgeDir(rootDir)); err != nil {
+		return reportError(errWorkdirMissing(bridgeDir(rootDir)))
+	}
+
+	fmt.Printf("starting %d %s bot(s) against %s — ctrl+c to stop\n", *count, *behavior, env.Name)
+
+	supervisor := &botSupervisor{}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < *count; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			supervisor.run(rootDir, env, id, *behavior, done)
+		}(i)
+		time.Sleep(100 * time.Millisecond) // stagger connects
+	}
+
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			supervisor.mu.Lock()
+			fmt.Printf("bots: %d/%d alive, %d crash-restarts\n", supervisor.alive, *count, supervisor.crashes)
+			supervisor.mu.Unlock()
+		case <-ctx.Done():
+			fmt.Println("\nstopping bots…")
+			close(done)
+			wg.Wait()
+			supervisor.mu.Lock()
+			crashes := supervisor.crashes
+			supervisor.mu.Unlock()
+			fmt.Printf("done (%d crash-restarts over the session)\n", crashes)
+			return 0
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// bridge:deploy replaces the hand-written SSH ritual: build the bridge,
+// sync dist/ to the environment's host, restart the service, and watch
+// the remote log for a few seconds so a crash-loop is caught here and
+// not by players. Hosts and commands come from config, since every
+// environment runs the bridge a little differently:
+//
+//	[bridge.staging]
+//	host = "deploy@bridge-staging.lurelands.com"
+//	path = "/opt/lurelands-bridge"
+//	restart = "sudo systemctl restart lurelands-bridge"
+//	logs = "journalctl -u lurelands-bridge -f -n 20"
+
+// bridgeLogWatch is how long the remote log is tailed after restart.
+const bridgeLogWatch = 5 * time.Second
+
+type bridgeDeployTarget struct {
+	host    string
+	path    string
+	restart string
+	logs    string
+}
+
+func bridgeDeployTargetFor(rootDir, envName string) (bridgeDeployTarget, error) {
+	values := loadConfigSections(rootDir)["bridge."+envName]
+	target := bridgeDeployTarget{
+		host:    values["host"],
+		path:    values["path"],
+		restart: values["restart"],
+		logs:    values["logs"],
+	}
+	if target.host == "" || target.path == "" {
+		return target, fmt.Errorf("no bridge host configured for %s — add [bridge.%s] with host and path to .lurelands.toml", envName, envName)
+	}
+	if target.restart == "" {
+		target.restart = "sudo systemctl restart lurelands-bridge"
+	}
+	if target.logs == "" {
+		target.logs = "journalctl -u lurelands-bridge -f -n 20"
+	}
+	return target, nil
+}
+
+// syncBridgeDist pushes dist/ via rsync, falling back to scp where
+// rsync isn't installed. --delete is scoped to the remote dist/ so
+// host-side state next to it (node_modules, .env) is never touched.
+func syncBridgeDist(ctx context.Context, rootDir string, target bridgeDeployTarget) error {
+	if _, err := exec.LookPath("rsync"); err == nil {
+		result, err := runner.Run(ctx, runner.Spec{
+			Name: "rsync",
+			Args: []string{"-az", "--delete", "dist/",
+				target.host + ":" + target.path + "/dist/"},
+			Dir: bridgeDir(rootDir),
+		})
+		if err != nil {
+			return fmt.Errorf("rsync: %w\n%s", err, lastOutputLines(result.Output, 10))
+		}
+		return nil
+	}
+	result, err := runner.Run(ctx, runner.Spec{
+		Name: "scp",
+		Args: []string{"-r", "dist", target.host + ":" + target.path + "/"},
+		Dir:  bridgeDir(rootDir),
+	})
+	if err != nil {
+		return fmt.Errorf("scp: %w\n%s", err, lastOutputLines(result.Output, 10))
+	}
+	return nil
+}
+
+// watchRemoteLog tails the remote log briefly and reports whether the
+// restarted service looks healthy (no error-level lines).
+func watchRemoteLog(ctx context.Context, target bridgeDeployTarget) bool {
+	tailCtx, cancel := context.WithTimeout(ctx, bridgeLogWatch)
+	defer cancel()
+	clean := true
+	runner.RunStreaming(tailCtx, runner.Spec{
+		Name: "ssh", Args: []string{target.host, target.logs},
+	}, func(line string, _ bool) {
+		fmt.Println("  remote |", line)
+		if strings.Contains(strings.ToLower(line), "error") || strings.Contains(line, "panic") {
+			clean = false
+		}
+	})
+	return clean
+}
+
+func runBridgeDeploy(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("bridge deploy", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "staging"))
+	if err != nil {
+		return reportError(err)
+	}
+	target, err := bridgeDeployTargetFor(rootDir, env.Name)
+	if err != nil {
+		return reportError(err)
+	}
+	if _, ok := lookupTool(rootDir, "bun"); !ok {
+		return reportError(errToolNotFound("bun", "building the bridge"))
+	}
+
+	fmt.Printf("deploying bridge to %s (%s)…\n", env.Name, target.host)
+	if err := deployBridge(ctx, rootDir, target); err != nil {
+		return reportError(err)
+	}
+	fmt.Println("bridge deploy complete — log looks clean")
+	return 0
+}
+
+// deployBridge runs the build→sync→restart→log-watch sequence against
+// an already-resolved target, shared by bridge:deploy and deploy:all.
+func deployBridge(ctx context.Context, rootDir string, target bridgeDeployTarget) error {
+	result, err := runWithProgress(ctx, "bridge build", "bun", pinnedSpec(rootDir, runner.Spec{
+		Name: "bun", Args: []string{"run", "build"}, Dir: bridgeDir(rootDir),
+	}))
+	if err != nil {
+		return fmt.Errorf("bridge build: %w\n%s", err, summarizeFailure(result.Output, 10))
+	}
+	reportArtifactSizes(rootDir, "bridge dist")
+	if err := syncBridgeDist(ctx, rootDir, target); err != nil {
+		return err
+	}
+	fmt.Printf("  synced dist/ to %s:%s\n", target.host, target.path)
+
+	restart, err := runner.Run(ctx, runner.Spec{
+		Name: "ssh", Args: []string{target.host, target.restart},
+	})
+	if err != nil {
+		return fmt.Errorf("restart: %w\n%s", err, lastOutputLines(restart.Output, 10))
+	}
+	fmt.Printf("  restarted — watching the log for %s…\n", bridgeLogWatch)
+
+	if !watchRemoteLog(ctx, target) {
+		return fmt.Errorf("error lines in the remote log right after restart — check `ssh %s '%s'`", target.host, target.logs)
+	}
+	return nil
+}
+
+// backupRemoteDist keeps the host's current dist/ next to the new one
+// (dist.prev) so a coordinated rollback can restore it without needing
+// the previous build locally.
+func backupRemoteDist(ctx context.Context, target bridgeDeployTarget) error {
+	script := fmt.Sprintf("rm -rf %[1]s/dist.prev && { [ ! -d %[1]s/dist ] || cp -a %[1]s/dist %[1]s/dist.prev; }", target.path)
+	result, err := runner.Run(ctx, runner.Spec{Name: "ssh", Args: []string{target.host, script}})
+	if err != nil {
+		return fmt.Errorf("backing up remote dist: %w\n%s", err, lastOutputLines(result.Output, 10))
+	}
+	return nil
+}
+
+// restoreRemoteDist swaps dist.prev back into place and restarts.
+func restoreRemoteDist(ctx context.Context, target bridgeDeployTarget) error {
+	script := fmt.Sprintf("[ -d %[1]s/dist.prev ] && rm -rf %[1]s/dist && mv %[1]s/dist.prev %[1]s/dist", target.path)
+	result, err := runner.Run(ctx, runner.Spec{Name: "ssh", Args: []string{target.host, script}})
+	if err != nil {
+		return fmt.Errorf("restoring remote dist: %w\n%s", err, lastOutputLines(result.Output, 10))
+	}
+	if result, err = runner.Run(ctx, runner.Spec{Name: "ssh", Args: []string{target.host, target.restart}}); err != nil {
+		return fmt.Errorf("restarting previous bridge build: %w\n%s", err, lastOutputLines(result.Output, 10))
+	}
+	return nil
+}
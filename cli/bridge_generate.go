@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// bridge:generate regenerates the bridge's TypeScript bindings from the
+// module. Generation is slow and usually a no-op, so the module source
+// is hashed and generation is skipped when nothing schema-relevant
+// changed since the last run — this takes the common dev loop from
+// ~30s to instant. The same record file also tracks the app's Dart
+// bindings (see generate.go).
+
+func bindingRecordPath(rootDir string) string {
+	return filepath.Join(cacheDir(rootDir), "bindings.json")
+}
+
+// moduleSchemaHash hashes everything in the module that can affect the
+// generated bindings: Rust sources and the crate manifest. File names
+// are hashed too, so renames don't collide.
+func moduleSchemaHash(rootDir string) (string, error) {
+	dir := moduleDir(rootDir)
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == "target" || strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".rs" || info.Name() == "Cargo.toml" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	hash := sha256.New()
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		fmt.Fprintf(hash, "%s\x00%d\x00", filepath.ToSlash(rel), len(data))
+		hash.Write(data)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func loadBindingRecords(rootDir string) map[string]string {
+	records := map[string]string{}
+	data, err := os.ReadFile(bindingRecordPath(rootDir))
+	if err == nil {
+		json.Unmarshal(data, &records)
+	}
+	return records
+}
+
+func saveBindingRecord(rootDir, target, hash string) {
+	records := loadBindingRecords(rootDir)
+	records[target] = hash
+	os.MkdirAll(cacheDir(rootDir), 0o755)
+	data, _ := json.MarshalIndent(records, "", "  ")
+	os.WriteFile(bindingRecordPath(rootDir), append(data, '\n'), 0o644)
+}
+
+// ensureBridgeBindings regenerates the TS bindings unless the schema
+// hash matches the last generation record (force bypasses the check).
+// Output is captured, not streamed, so it can run concurrently with
+// other build steps; on failure the captured output is the error.
+func ensureBridgeBindings(ctx context.Context, rootDir string, force bool) error {
+	hash, err := moduleSchemaHash(rootDir)
+	if err != nil {
+		return fmt.Errorf("hashing module schema: %w", err)
+	}
+	if !force && loadBindingRecords(rootDir)["bridge"] == hash {
+		fmt.Println("bindings up to date (schema unchanged)")
+		return nil
+	}
+
+	result, err := runner.Run(ctx, pinnedSpec(rootDir, runner.Spec{
+		Name: "spacetime",
+		Args: []string{"generate", "--lang", "typescript",
+			"--out-dir", "src/module_bindings",
+			"--project-path", moduleDir(rootDir)},
+		Dir: bridgeDir(rootDir),
+	}))
+	if err != nil {
+		return fmt.Errorf("generating bindings: %w\n%s", err, result.Output)
+	}
+	if dryRun {
+		return nil // nothing generated; don't record the hash
+	}
+	saveBindingRecord(rootDir, "bridge", hash)
+	fmt.Println("bindings regenerated")
+	return nil
+}
+
+func runBridgeGenerate(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("bridge generate", flag.ContinueOnError)
+	force := flags.Bool("force", false, "regenerate even when the schema hash matches")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if err := ensureBridgeBindings(ctx, rootDir, *force); err != nil {
+		return reportError(err)
+	}
+	return 0
+}
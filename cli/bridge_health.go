@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// bridge:health probes the bridge's /health endpoint the same way the
+// deploy smoke check does, but reports what it saw — HTTP status,
+// round-trip latency, and the version the bridge says it is running —
+// instead of just pass/fail. The menu header reuses the probe for its
+// up/down indicator.
+
+const bridgeHealthTimeout = 5 * time.Second
+const bridgeWatchInterval = 3 * time.Second
+
+// bridgeHealthURL resolves the health endpoint for an environment:
+// the `health` key from its [env.*] config section if set, else the
+// built-in URL for the standard environments.
+func bridgeHealthURL(env environment) string {
+	if env.Health != "" {
+		return env.Health
+	}
+	return bridgeHealthURLs[env.Name]
+}
+
+type bridgeProbe struct {
+	status  string // e.g. "200 OK"
+	latency time.Duration
+	version string
+	err     error
+}
+
+func (p bridgeProbe) healthy() bool {
+	return p.err == nil
+}
+
+// probeBridgeHealth hits the health endpoint once. The body is parsed
+// loosely — any JSON with a "version" field fills it in, anything else
+// leaves it blank.
+func probeBridgeHealth(env environment) bridgeProbe {
+	url := bridgeHealthURL(env)
+	if url == "" {
+		return bridgeProbe{err: fmt.Errorf("no bridge health URL configured for %s (set health in [env.%s])", env.Name, env.Name)}
+	}
+	client := http.Client{Timeout: bridgeHealthTimeout}
+	start := time.Now()
+	resp, err := client.Get(url)
+	if err != nil {
+		return bridgeProbe{err: err}
+	}
+	defer resp.Body.Close()
+	probe := bridgeProbe{status: resp.Status, latency: time.Since(start).Round(time.Millisecond)}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	var payload struct {
+		Version string `json:"version"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		probe.version = payload.Version
+	}
+	if resp.StatusCode != http.StatusOK {
+		probe.err = fmt.Errorf("bridge returned %s", resp.Status)
+	}
+	return probe
+}
+
+// bridgeUpIndicator renders the green/red dot for TUI headers.
+func bridgeUpIndicator(up bool) string {
+	if up {
+		return tui.OK.Render("● bridge")
+	}
+	return tui.Warn.Render("● bridge")
+}
+
+func formatProbeLine(probe bridgeProbe) string {
+	if probe.err != nil && probe.status == "" {
+		return tui.Warn.Render("● down") + " · " + probe.err.Error()
+	}
+	dot := tui.OK.Render("●")
+	if probe.err != nil {
+		dot = tui.Warn.Render("●")
+	}
+	line := fmt.Sprintf("%s %s · %s", dot, probe.status, probe.latency)
+	if probe.version != "" {
+		line += " · v" + probe.version
+	}
+	return line
+}
+
+func runBridgeHealth(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("bridge health", flag.ContinueOnError)
+	envName := flags.String("env", "", "environment whose bridge to probe")
+	watch := flags.Bool("watch", false, "keep probing and refresh the line in place")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		return reportError(err)
+	}
+
+	if *watch {
+		if code := requireInteractive("bridge health --watch", "drop --watch for a single probe"); code >= 0 {
+			return code
+		}
+		fmt.Println(tui.Title.Render(fmt.Sprintf("bridge health — %s (%s)", env.Name, bridgeHealthURL(env))))
+		ticker := time.NewTicker(bridgeWatchInterval)
+		defer ticker.Stop()
+		for {
+			fmt.Printf("\r\x1b[2K%s · %s", formatProbeLine(probeBridgeHealth(env)), tui.Dim.Render(time.Now().Format("15:04:05")))
+			select {
+			case <-ctx.Done():
+				fmt.Println()
+				return exitCancelled
+			case <-ticker.C:
+			}
+		}
+	}
+
+	probe := probeBridgeHealth(env)
+	fmt.Printf("bridge health — %s (%s)\n", env.Name, bridgeHealthURL(env))
+	if probe.err != nil && probe.status == "" {
+		fmt.Fprintln(os.Stderr, "error:", probe.err)
+		return 1
+	}
+	fmt.Printf("  status   %s\n", probe.status)
+	fmt.Printf("  latency  %s\n", probe.latency)
+	version := probe.version
+	if version == "" {
+		version = "(not reported)"
+	}
+	fmt.Printf("  version  %s\n", version)
+	if probe.err != nil {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// bridge start runs the bridge in the foreground with streamed logs.
+// With --target it runs on a remote machine instead (see remote.go),
+// which is how we tail or bounce the staging bridge without a separate
+// ssh session — the spec is identical, only the host changes.
+func runBridgeStart(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("bridge start", flag.ContinueOnError)
+	envName := flags.String("env", "", "environment whose module the bridge connects to")
+	targetName := flags.String("target", "", "run on a remote target instead of locally")
+	killPort := flags.Bool("kill-port", false, "terminate whatever holds the bridge port first")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	spec := runner.Spec{
+		Name: "bun",
+		Args: []string{"run", "start"},
+		Dir:  bridgeDir(rootDir),
+		Env: append(append(os.Environ(), dotEnvPairs(bridgeDir(rootDir), env)...),
+			"LURELANDS_ENV="+env.Name, "LURELANDS_MODULE="+env.Module),
+	}
+	if *targetName == "" {
+		if _, ok := lookupTool(rootDir, "bun"); !ok {
+			return reportError(errToolNotFound("bun", "running the bridge"))
+		}
+		if !ensurePortFree(rootDir, defaultBridgePort, "bridge", *killPort) {
+			return 1
+		}
+		// Local runs inherit the full environment and honor toolchain
+		// pins; remote runs only inject our own variables — the remote
+		// machine has its own environment and tool versions.
+		spec = pinnedSpec(rootDir, spec)
+	} else {
+		spec.Env = []string{"LURELANDS_ENV=" + env.Name, "LURELANDS_MODULE=" + env.Module}
+	}
+	spec, err = expandSpecSecrets(ctx, rootDir, spec)
+	if err != nil {
+		return reportError(err)
+	}
+	spec, err = applyRemoteTarget(*targetName, rootDir, spec)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return exitUsage
+	}
+
+	where := "locally"
+	if *targetName != "" {
+		where = "on " + *targetName
+	}
+	fmt.Printf("starting bridge %s (env %s)…\n", where, env.Name)
+	result, err := runner.RunStreaming(ctx, spec, func(line string, isStderr bool) {
+		feedErrorLine(rootDir, "bridge", line)
+		if isStderr {
+			fmt.Fprintln(os.Stderr, line)
+		} else {
+			fmt.Println(line)
+		}
+	})
+	if err != nil && result.ExitCode == 0 {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return result.ExitCode
+}
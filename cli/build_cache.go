@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Input-hash caching for build steps, generalizing what bridge:generate
+// does for bindings: hash everything that can change the output —
+// source trees, lockfiles, toolchain pins — and skip the step when the
+// hash matches the last successful run and the outputs still exist.
+// Records live in .lurelands/cache/inputs.json; `cache clear` wipes
+// them (and the binding records) so every step rebuilds once.
+
+// stepInputs declares what a cached step depends on.
+type stepInputs struct {
+	dirs    []string // source trees, walked recursively
+	files   []string // lockfiles and manifests; missing ones hash as absent
+	tools   []string // [toolchain] pin keys; an unpinned tool hashes as "unpinned"
+	outputs []string // artifacts that must still exist for a cache hit
+}
+
+// inputDirSkip lists directory names that never feed a build.
+var inputDirSkip = map[string]bool{
+	"target": true, "node_modules": true, "build": true, ".dart_tool": true,
+}
+
+func inputRecordPath(rootDir string) string {
+	return filepath.Join(cacheDir(rootDir), "inputs.json")
+}
+
+func loadInputRecords(rootDir string) map[string]string {
+	records := map[string]string{}
+	data, err := os.ReadFile(inputRecordPath(rootDir))
+	if err == nil {
+		json.Unmarshal(data, &records)
+	}
+	return records
+}
+
+func saveInputRecord(rootDir, step, hash string) {
+	records := loadInputRecords(rootDir)
+	records[step] = hash
+	os.MkdirAll(cacheDir(rootDir), 0o755)
+	data, _ := json.MarshalIndent(records, "", "  ")
+	os.WriteFile(inputRecordPath(rootDir), append(data, '\n'), 0o644)
+}
+
+// inputHash digests a step's declared inputs. Toolchain pins come from
+// config rather than invoking the tools, so hashing stays instant; an
+// upgrade someone didn't pin won't bust the cache, which is the
+// trade-off for not shelling out per step.
+func inputHash(rootDir string, inputs stepInputs) (string, error) {
+	hash := sha256.New()
+
+	var files []string
+	for _, dir := range inputs.dirs {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if inputDirSkip[info.Name()] || strings.HasPrefix(info.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			files = append(files, path)
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	files = append(files, inputs.files...)
+	sort.Strings(files)
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(hash, "%s\x00absent\x00", filepath.ToSlash(path))
+			continue
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", filepath.ToSlash(path), len(data))
+		hash.Write(data)
+	}
+
+	pins := toolchainPins(rootDir)
+	for _, tool := range inputs.tools {
+		version := pins[tool]
+		if version == "" {
+			version = "unpinned"
+		}
+		fmt.Fprintf(hash, "tool:%s=%s\x00", tool, version)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// cachedStep runs run unless the step's input hash matches its last
+// successful run and every declared output still exists. Hashing
+// failures fall through to running — a cache must never be the reason
+// a build fails.
+func cachedStep(rootDir, name string, force bool, inputs stepInputs, run func() error) error {
+	hash, hashErr := inputHash(rootDir, inputs)
+	if hashErr == nil && !force && loadInputRecords(rootDir)[name] == hash {
+		missing := false
+		for _, output := range inputs.outputs {
+			if !fileExists(output) {
+				missing = true
+			}
+		}
+		if !missing {
+			fmt.Printf("  %s: cached, up to date\n", name)
+			return nil
+		}
+	}
+	if err := run(); err != nil {
+		return err
+	}
+	// A dry run produced no outputs; recording its hash would make the
+	// next real run skip a step that never happened.
+	if hashErr == nil && !dryRun {
+		saveInputRecord(rootDir, name, hash)
+	}
+	return nil
+}
+
+func runCacheClear(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("cache clear", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	cleared := 0
+	for _, path := range []string{inputRecordPath(rootDir), bindingRecordPath(rootDir)} {
+		if err := os.Remove(path); err == nil {
+			cleared++
+		} else if !os.IsNotExist(err) {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+	}
+	fmt.Printf("cleared %d record file(s) — every cached step rebuilds on its next run\n", cleared)
+	return 0
+}
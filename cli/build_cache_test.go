@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCachedStepSkipsWhenInputsUnchanged(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	os.MkdirAll(src, 0o755)
+	os.WriteFile(filepath.Join(src, "main.rs"), []byte("fn main() {}"), 0o644)
+	out := filepath.Join(root, "out.wasm")
+
+	runs := 0
+	build := func() error {
+		runs++
+		return os.WriteFile(out, []byte("wasm"), 0o644)
+	}
+	inputs := stepInputs{dirs: []string{src}, outputs: []string{out}}
+
+	for i := 0; i < 2; i++ {
+		if err := cachedStep(root, "step", false, inputs, build); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if runs != 1 {
+		t.Errorf("unchanged inputs ran the step %d times, want 1", runs)
+	}
+
+	// Touching a source file busts the cache; --force always does.
+	os.WriteFile(filepath.Join(src, "main.rs"), []byte("fn main() { run(); }"), 0o644)
+	cachedStep(root, "step", false, inputs, build)
+	if runs != 2 {
+		t.Errorf("changed inputs did not re-run the step (runs=%d)", runs)
+	}
+	cachedStep(root, "step", true, inputs, build)
+	if runs != 3 {
+		t.Errorf("force did not re-run the step (runs=%d)", runs)
+	}
+
+	// A missing output is a miss even with a matching hash.
+	os.Remove(out)
+	cachedStep(root, "step", false, inputs, build)
+	if runs != 4 {
+		t.Errorf("missing output did not re-run the step (runs=%d)", runs)
+	}
+}
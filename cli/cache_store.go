@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Content-addressed blob storage under .lurelands/cache/blobs/. Large
+// artifacts (wasm builds, bridge dists) are stored once per unique
+// content hash and surfaced elsewhere as hardlinks, so deploying the
+// same wasm to three environments costs one copy on disk. Hardlinking
+// falls back to a plain copy on filesystems that refuse it. `cache
+// prune` keeps the whole cache tree inside a size/age budget.
+
+func blobsDir(rootDir string) string {
+	return filepath.Join(cacheDir(rootDir), "blobs")
+}
+
+// storeAsBlob puts src's content into the blob store (no-op when the
+// blob already exists) and links it to dst.
+func storeAsBlob(rootDir, src, dst string) error {
+	hash, err := hashFile(src)
+	if err != nil {
+		return err
+	}
+	blob := filepath.Join(blobsDir(rootDir), hash)
+	if !fileExists(blob) {
+		if err := copyFile(src, blob); err != nil {
+			return err
+		}
+	}
+	return linkOrCopy(blob, dst)
+}
+
+func linkOrCopy(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	os.Remove(dst) // hardlinking over an existing file fails
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	return copyFile(src, dst)
+}
+
+// parseSizeLimit understands "5GB", "500MB", "1024" (bytes).
+func parseSizeLimit(s string) (int64, error) {
+	s = strings.TrimSpace(strings.ToUpper(s))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		factor int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(s, unit.suffix) {
+			multiplier = unit.factor
+			s = strings.TrimSuffix(s, unit.suffix)
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad size %q", s)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// parseAgeLimit understands "30d", "12h" — ParseDuration plus days.
+func parseAgeLimit(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.ParseFloat(strings.TrimSuffix(s, "d"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("bad age %q", s)
+		}
+		return time.Duration(days * 24 * float64(time.Hour)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+type cacheEntry struct {
+	path  string
+	size  int64
+	mtime time.Time
+}
+
+func listCacheEntries(rootDir string) ([]cacheEntry, int64) {
+	var entries []cacheEntry
+	var total int64
+	filepath.Walk(cacheDir(rootDir), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		entries = append(entries, cacheEntry{path: path, size: info.Size(), mtime: info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	return entries, total
+}
+
+func runCachePrune(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("cache prune", flag.ContinueOnError)
+	maxSize := flags.String("max-size", "5GB", "total cache size to keep")
+	maxAge := flags.String("max-age", "30d", "drop entries older than this")
+	dryRun := flags.Bool("dry-run", false, "report what would be removed")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	sizeLimit, err := parseSizeLimit(*maxSize)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return exitUsage
+	}
+	ageLimit, err := parseAgeLimit(*maxAge)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return exitUsage
+	}
+
+	entries, total := listCacheEntries(rootDir)
+	cutoff := time.Now().Add(-ageLimit)
+	// Oldest first: the age pass and the size pass then both walk in
+	// eviction order.
+	sort.Slice(entries, func(i, j int) bool { return entries[i].mtime.Before(entries[j].mtime) })
+
+	removed, freed := 0, int64(0)
+	for _, entry := range entries {
+		evict := entry.mtime.Before(cutoff) || total-freed > sizeLimit
+		if !evict {
+			break
+		}
+		if !*dryRun {
+			if err := os.Remove(entry.path); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+				continue
+			}
+		}
+		removed++
+		freed += entry.size
+	}
+
+	verb := "pruned"
+	if *dryRun {
+		verb = "would prune"
+	}
+	fmt.Printf("cache: %s %d file(s), %.1f MB (was %.1f MB)\n",
+		verb, removed, float64(freed)/(1<<20), float64(total)/(1<<20))
+	return 0
+}
+
+func runCacheStats(ctx context.Context, rootDir string, args []string) int {
+	entries, total := listCacheEntries(rootDir)
+	blobs := 0
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.path, blobsDir(rootDir)) {
+			blobs++
+		}
+	}
+	fmt.Printf("cache: %d file(s), %.1f MB total, %d blob(s)\n",
+		len(entries), float64(total)/(1<<20), blobs)
+	return 0
+}
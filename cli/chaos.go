@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// chaos injects controlled failures into a running local dev/e2e session
+// so reconnect and state-resync logic gets exercised on purpose instead
+// of on launch day. It finds the bridge and spacetime processes that are
+// already running, kills or stalls them on a randomized schedule, and
+// brings the bridge back up itself after each kill. Seeded, so a failure
+// sequence that broke the app can be replayed exactly.
+
+type chaosAction struct {
+	name string
+	run  func(rootDir string, rng *rand.Rand) error
+}
+
+// killBridge kills the bridge outright, waits a random downtime, and
+// restarts it — the window where clients must queue and reconnect.
+func killBridge(rootDir string, rng *rand.Rand) error {
+	pids := findPids("bun run start")
+	if len(pids) == 0 {
+		return fmt.Errorf("no bridge process found")
+	}
+	for _, pid := range pids {
+		killProcess(pid)
+	}
+	downtime := time.Duration(2+rng.Intn(8)) * time.Second
+	fmt.Printf("  bridge killed (pid %v), restarting in %s\n", pids, downtime)
+	time.Sleep(downtime)
+
+	restart := exec.Command("bun", "run", "start")
+	restart.Dir = bridgeDir(rootDir)
+	restart.Stdout, restart.Stderr = os.Stdout, os.Stderr
+	if err := restart.Start(); err != nil {
+		return fmt.Errorf("restarting bridge: %w", err)
+	}
+	go restart.Wait()
+	return nil
+}
+
+// stallDB freezes the spacetime instance for a few seconds with SIGSTOP,
+// which looks like a network partition to every connected client, then
+// resumes it. State is intact afterwards — only connections drop.
+func stallDB(rootDir string, rng *rand.Rand) error {
+	pids := findPids("spacetime start")
+	if len(pids) == 0 {
+		return fmt.Errorf("no spacetime process found")
+	}
+	if !canStallProcesses {
+		return fmt.Errorf("db_stall is not supported on this platform")
+	}
+	stall := time.Duration(3+rng.Intn(10)) * time.Second
+	for _, pid := range pids {
+		stallProcess(pid)
+	}
+	fmt.Printf("  spacetime stalled (pid %v) for %s\n", pids, stall)
+	time.Sleep(stall)
+	for _, pid := range pids {
+		resumeProcess(pid)
+	}
+	return nil
+}
+
+var chaosActions = []chaosAction{
+	{name: "bridge_kill", run: killBridge},
+	{name: "db_stall", run: stallDB},
+}
+
+func runChaos(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("chaos", flag.ContinueOnError)
+	interval := flags.Duration("interval", 30*time.Second, "mean time between injected failures")
+	duration := flags.Duration("duration", 10*time.Minute, "how long to run (0 = until ctrl+c)")
+	seed := flags.Int64("seed", time.Now().UnixNano(), "RNG seed, printed so runs can be replayed")
+	target := flags.String("target", "all", "what to break: bridge, db, or all")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	actions := chaosActions[:0:0]
+	for _, action := range chaosActions {
+		switch *target {
+		case "all":
+			actions = append(actions, action)
+		case "bridge":
+			if action.name == "bridge_kill" {
+				actions = append(actions, action)
+			}
+		case "db":
+			if action.name == "db_stall" {
+				actions = append(actions, action)
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "unknown target %q (expected bridge, db, or all)\n", *target)
+			return 1
+		}
+	}
+
+	rng := rand.New(rand.NewSource(*seed))
+	fmt.Printf("chaos: seed=%d interval≈%s target=%s — ctrl+c to stop\n", *seed, *interval, *target)
+
+	deadline := time.Time{}
+	if *duration > 0 {
+		deadline = time.Now().Add(*duration)
+	}
+
+	injected, failures := 0, 0
+	for {
+		// Jitter ±50% around the mean so failures don't land on a beat the
+		// app could accidentally be tuned to.
+		wait := time.Duration(float64(*interval) * (0.5 + rng.Float64()))
+		select {
+		case <-ctx.Done():
+			fmt.Printf("\nchaos stopped: %d injected, %d could not run\n", injected, failures)
+			return 0
+		case <-time.After(wait):
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			fmt.Printf("chaos finished: %d injected, %d could not run\n", injected, failures)
+			return 0
+		}
+
+		action := actions[rng.Intn(len(actions))]
+		fmt.Printf("[%s] inject %s\n", time.Now().Format("15:04:05"), action.name)
+		if err := action.run(rootDir, rng); err != nil {
+			fmt.Fprintf(os.Stderr, "  skipped: %v\n", err)
+			failures++
+			continue
+		}
+		injected++
+	}
+}
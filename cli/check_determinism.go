@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// check:determinism replays one recorded sequence of reducer calls into
+// two fresh copies of the module on the local instance and diffs every
+// table afterwards. Identical inputs must produce identical state; any
+// divergence means a reducer is reaching for wall-clock time, an
+// unseeded RNG, or iteration order it shouldn't depend on.
+//
+// Recordings are JSON lines of {"reducer": ..., "args": ...}, as written
+// by `lurelands record` sessions or handcrafted for regression cases.
+
+type reducerCall struct {
+	Reducer string          `json:"reducer"`
+	Args    json.RawMessage `json:"args"`
+}
+
+func loadRecording(path string) ([]reducerCall, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var calls []reducerCall
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var call reducerCall
+		if err := json.Unmarshal(line, &call); err != nil {
+			return nil, fmt.Errorf("bad recording line %q: %w", line, err)
+		}
+		calls = append(calls, call)
+	}
+	return calls, scanner.Err()
+}
+
+// publishFresh deletes and republishes the module under a scratch name so
+// each replay starts from genuinely empty state.
+func publishFresh(rootDir string, env environment, moduleName string) error {
+	exec.Command("spacetime", "delete", "--server", env.Server, moduleName).Run()
+	publish := exec.Command("spacetime", "publish", "--server", env.Server, moduleName)
+	publish.Dir = moduleDir(rootDir)
+	if out, err := publish.CombinedOutput(); err != nil {
+		return fmt.Errorf("publishing %s: %v\n%s", moduleName, err, out)
+	}
+	return nil
+}
+
+func replayCalls(env environment, moduleName string, calls []reducerCall) error {
+	for i, call := range calls {
+		if err := exec.Command("spacetime", "call", "--server", env.Server, moduleName,
+			call.Reducer, string(call.Args)).Run(); err != nil {
+			return fmt.Errorf("call %d (%s) failed: %w", i, call.Reducer, err)
+		}
+	}
+	return nil
+}
+
+func dumpAllTables(env environment, moduleName string) (map[string]string, error) {
+	scratch := environment{Name: env.Name, Server: env.Server, Module: moduleName}
+	tables, err := snapshotTables(scratch)
+	if err != nil {
+		return nil, err
+	}
+	dump := map[string]string{}
+	for _, table := range tables {
+		out, err := exec.Command("spacetime", "sql", "--server", env.Server, moduleName,
+			"SELECT * FROM "+table, "--json").Output()
+		if err != nil {
+			return nil, fmt.Errorf("dumping %s: %w", table, err)
+		}
+		dump[table] = string(out)
+	}
+	return dump, nil
+}
+
+func runCheckDeterminism(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("check:determinism", flag.ContinueOnError)
+	recording := flags.String("recording", "", "path to a reducer-call recording (jsonl)")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if *recording == "" {
+		fmt.Fprintln(os.Stderr, "check:determinism requires --recording")
+		return 1
+	}
+
+	calls, err := loadRecording(*recording)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if len(calls) == 0 {
+		fmt.Fprintln(os.Stderr, "error: recording is empty")
+		return 1
+	}
+
+	env := environments["local"]
+	copies := []string{env.Module + "-det-a", env.Module + "-det-b"}
+	dumps := make([]map[string]string, len(copies))
+
+	for i, moduleName := range copies {
+		fmt.Printf("replaying %d call(s) into %s…\n", len(calls), moduleName)
+		if err := publishFresh(rootDir, env, moduleName); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		if err := replayCalls(env, moduleName, calls); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		if dumps[i], err = dumpAllTables(env, moduleName); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		exec.Command("spacetime", "delete", "--server", env.Server, moduleName).Run()
+	}
+
+	diverged := 0
+	for table, a := range dumps[0] {
+		b, ok := dumps[1][table]
+		if !ok {
+			fmt.Printf("table %s exists in copy A only\n", table)
+			diverged++
+			continue
+		}
+		var rowsA, rowsB any
+		json.Unmarshal([]byte(a), &rowsA)
+		json.Unmarshal([]byte(b), &rowsB)
+		if !jsonEqual(rowsA, rowsB) {
+			fmt.Printf("table %s DIVERGED:\n  A: %s\n  B: %s\n", table, shorten(a, 120), shorten(b, 120))
+			diverged++
+		}
+	}
+	for table := range dumps[1] {
+		if _, ok := dumps[0][table]; !ok {
+			fmt.Printf("table %s exists in copy B only\n", table)
+			diverged++
+		}
+	}
+
+	if diverged > 0 {
+		fmt.Fprintf(os.Stderr, "determinism check FAILED: %d table(s) diverged\n", diverged)
+		return 1
+	}
+	fmt.Printf("determinism check passed (%d tables identical)\n", len(dumps[0]))
+	return 0
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// CI mode. `--ci` (or CI=true in the environment, which GitHub Actions
+// sets) turns the CLI into a plain pipe citizen: colors off, no
+// bubbletea — interactive commands fail fast with a pointer to their
+// non-interactive flags instead of writing control sequences into a
+// build log. `--json[=file]` additionally emits a one-object summary
+// of the run (command, duration, exit code) for later steps to parse.
+
+var ciMode bool
+
+// extractCIFlags pulls the global --ci and --json flags out of args,
+// wherever they sit, same contract as extractRootFlag.
+func extractCIFlags(args []string) (rest []string, ci bool, jsonOut string, jsonSet bool) {
+	rest = args[:0]
+	for _, arg := range args {
+		switch {
+		case arg == "--ci":
+			ci = true
+		case arg == "--json":
+			jsonSet = true
+		case strings.HasPrefix(arg, "--json="):
+			jsonSet = true
+			jsonOut = strings.TrimPrefix(arg, "--json=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	if os.Getenv("CI") == "true" {
+		ci = true
+	}
+	if ci {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	ciMode = ci
+	return rest, ci, jsonOut, jsonSet
+}
+
+// requireInteractive guards commands that would start a TUI.
+func requireInteractive(command, instead string) int {
+	if !ciMode {
+		return -1
+	}
+	fmt.Fprintf(os.Stderr, "error: %s is interactive and unavailable with --ci — %s\n", command, instead)
+	return 1
+}
+
+type ciSummary struct {
+	Command  string `json:"command"`
+	Duration int64  `json:"duration_ms"`
+	ExitCode int    `json:"exit_code"`
+}
+
+func writeCISummary(args []string, took time.Duration, code int, path string) {
+	data, _ := json.Marshal(ciSummary{
+		Command:  strings.Join(args, " "),
+		Duration: took.Milliseconds(),
+		ExitCode: code,
+	})
+	data = append(data, '\n')
+	if path == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "error: writing summary:", err)
+	}
+}
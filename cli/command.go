@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+)
+
+// The command tree. Every command is a node: groups (content, assets,
+// sim, …) hold children, leaves hold a run func. Dispatch accepts both
+// spaced paths (`lurelands content validate`) and the legacy colon names
+// (`lurelands content:validate`) so muscle memory and old scripts keep
+// working. Per-command flags stay with each run func's FlagSet, which
+// already rejects unknown flags; the tree adds nesting, per-node help,
+// and did-you-mean for typos.
+
+type command struct {
+	name     string
+	summary  string
+	run      func(ctx context.Context, rootDir string, args []string) int
+	children []*command
+}
+
+func (c *command) child(name string) *command {
+	for _, child := range c.children {
+		if child.name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// path renders help for this node: its own summary plus any children.
+func (c *command) printHelp(path string) {
+	if c.summary != "" {
+		fmt.Printf("%s - %s\n", path, c.summary)
+	}
+	if len(c.children) == 0 {
+		return
+	}
+	fmt.Printf("\nSubcommands of %s:\n", path)
+	for _, child := range c.children {
+		fmt.Printf("  %-18s %s\n", child.name, child.summary)
+	}
+}
+
+// dispatch walks the tree. Colon-joined names are split first, so
+// "content:maps:validate" and "content maps validate" hit the same leaf.
+func dispatch(ctx context.Context, root []*command, rootDir string, args []string) int {
+	if len(args) == 0 {
+		usage()
+		return 0
+	}
+
+	segments := strings.Split(args[0], ":")
+	rest := args[1:]
+
+	var node *command
+	for _, top := range root {
+		if top.name == segments[0] {
+			node = top
+			break
+		}
+	}
+	if node == nil {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", segments[0])
+		if suggestion := closestCommand(root, segments[0]); suggestion != "" {
+			fmt.Fprintf(os.Stderr, "did you mean %q?\n", suggestion)
+		}
+		fmt.Fprintln(os.Stderr)
+		usage()
+		return 1
+	}
+	path := node.name
+
+	// Remaining colon segments descend the tree.
+	for _, segment := range segments[1:] {
+		child := node.child(segment)
+		if child == nil {
+			fmt.Fprintf(os.Stderr, "unknown subcommand %q of %s\n", segment, path)
+			node.printHelp(path)
+			return 1
+		}
+		node = child
+		path += " " + node.name
+	}
+
+	// Then spaced args descend as far as they match children.
+	for len(rest) > 0 {
+		child := node.child(rest[0])
+		if child == nil {
+			break
+		}
+		node = child
+		path += " " + node.name
+		rest = rest[1:]
+	}
+
+	if len(rest) > 0 && (rest[0] == "--help" || rest[0] == "-h" || rest[0] == "help") {
+		node.printHelp(path)
+		return 0
+	}
+	if node.run == nil {
+		node.printHelp(path)
+		if len(rest) > 0 {
+			fmt.Fprintf(os.Stderr, "\nunknown subcommand %q of %s\n", rest[0], path)
+			return 1
+		}
+		return 0
+	}
+	slog.Debug("dispatching", "command", path, "args", rest)
+	return node.run(ctx, rootDir, rest)
+}
+
+// closestCommand suggests a top-level name within edit distance 2.
+func closestCommand(root []*command, input string) string {
+	best, bestDist := "", 3
+	for _, top := range root {
+		if d := editDistance(input, top.name); d < bestDist {
+			best, bestDist = top.name, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// sortedTopLevel returns the top-level commands alphabetically for help.
+func sortedTopLevel(root []*command) []*command {
+	sorted := append([]*command(nil), root...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].name < sorted[j].name })
+	return sorted
+}
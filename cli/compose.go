@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// compose gives non-developers (artists, designers) a one-command local
+// stack: a docker-compose file with the spacetime server and the bridge
+// in containers, optionally plus nginx serving the Flutter web build.
+// `generate` writes the file, `up`/`down` wrap docker compose so nobody
+// needs to remember -f paths. The file is generated, not hand-edited —
+// regenerate after changing it here.
+
+func composeFilePath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "compose", "docker-compose.yml")
+}
+
+func composeFileContents(rootDir string, withWeb bool) string {
+	contents := fmt.Sprintf(`# Generated by lurelands compose generate — do not edit by hand.
+services:
+  spacetime:
+    image: clockworklabs/spacetime:latest
+    command: start --listen-addr 0.0.0.0:3000
+    ports:
+      - "3000:3000"
+    volumes:
+      - spacetime-data:/var/lib/spacetime
+
+  bridge:
+    image: oven/bun:1
+    working_dir: /bridge
+    command: bun run start
+    environment:
+      SPACETIME_URI: ws://spacetime:3000
+      LURELANDS_MODULE: lurelands
+    volumes:
+      - %s:/bridge
+    ports:
+      - "8080:8080"
+    depends_on:
+      - spacetime
+`, bridgeDir(rootDir))
+
+	if withWeb {
+		contents += fmt.Sprintf(`
+  web:
+    image: nginx:alpine
+    volumes:
+      - %s:/usr/share/nginx/html:ro
+    ports:
+      - "8000:80"
+    depends_on:
+      - bridge
+`, filepath.Join(appDir(rootDir), "build", "web"))
+	}
+
+	contents += `
+volumes:
+  spacetime-data:
+`
+	return contents
+}
+
+func runComposeGenerate(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("compose generate", flag.ContinueOnError)
+	withWeb := flags.Bool("web", false, "include nginx serving the Flutter web build")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *withWeb && !fileExists(filepath.Join(appDir(rootDir), "build", "web", "index.html")) {
+		fmt.Fprintln(os.Stderr, "warning: no Flutter web build found — run `flutter build web` in the app first")
+	}
+	if err := writeComposeFile(rootDir, *withWeb); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Println("wrote", composeFilePath(rootDir))
+	return 0
+}
+
+func writeComposeFile(rootDir string, withWeb bool) error {
+	path := composeFilePath(rootDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(composeFileContents(rootDir, withWeb)), 0o644)
+}
+
+func runComposeCommand(ctx context.Context, rootDir string, composeArgs []string) int {
+	if _, ok := lookupTool(rootDir, "docker"); !ok {
+		return reportError(errToolNotFound("docker", "running the containerized stack"))
+	}
+	if !fileExists(composeFilePath(rootDir)) {
+		fmt.Fprintln(os.Stderr, "error: no compose file yet — run `lurelands compose generate` first")
+		return 1
+	}
+	args := append([]string{"compose", "-f", composeFilePath(rootDir)}, composeArgs...)
+	result, err := runner.RunStreaming(ctx, runner.Spec{Name: "docker", Args: args, Dir: rootDir},
+		func(line string, isStderr bool) {
+			if isStderr {
+				fmt.Fprintln(os.Stderr, line)
+			} else {
+				fmt.Println(line)
+			}
+		})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+	}
+	return result.ExitCode
+}
+
+func runComposeUp(ctx context.Context, rootDir string, args []string) int {
+	return runComposeCommand(ctx, rootDir, append([]string{"up", "--detach"}, args...))
+}
+
+func runComposeDown(ctx context.Context, rootDir string, args []string) int {
+	return runComposeCommand(ctx, rootDir, append([]string{"down"}, args...))
+}
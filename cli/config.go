@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// .lurelands.toml is the workspace's one config file: the root marker,
+// [toolchain] pins, [service.*] registrations, and [command.*] custom
+// commands all live there. parseConfigSections implements the flat
+// `[section]` / `key = "value"` subset those features share — it is not
+// a TOML parser and does not try to be; nesting, arrays, and multi-line
+// strings are out of scope on purpose.
+
+func configPath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands.toml")
+}
+
+// parseConfigSections returns section name → key → value. Keys outside
+// any section land under "".
+func parseConfigSections(data string) map[string]map[string]string {
+	sections := map[string]map[string]string{}
+	current := ""
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = strings.Trim(line, "[]")
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if comment := strings.Index(value, "#"); comment >= 0 {
+			value = value[:comment]
+		}
+		if sections[current] == nil {
+			sections[current] = map[string]string{}
+		}
+		sections[current][strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return sections
+}
+
+func loadConfigSections(rootDir string) map[string]map[string]string {
+	data, err := os.ReadFile(configPath(rootDir))
+	if err != nil {
+		return nil
+	}
+	return parseConfigSections(string(data))
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/workspace"
+)
+
+// Typed views of the content files under content/. These mirror the
+// client-side ItemDefinition catalog in apps/lurelands/lib/utils/constants.dart
+// and are the source of truth the server tables get seeded from.
+
+type SpriteRef struct {
+	Sheet  string `json:"sheet"`
+	Column int    `json:"column"`
+	Row    int    `json:"row"`
+}
+
+type FishDef struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	WaterType   string    `json:"water_type"`
+	Tier        int       `json:"tier"`
+	BasePrice   int       `json:"base_price"`
+	WeightMin   float64   `json:"weight_min"`
+	WeightMax   float64   `json:"weight_max"`
+	Sprite      SpriteRef `json:"sprite"`
+}
+
+type LureDef struct {
+	ID                  string    `json:"id"`
+	Name                string    `json:"name"`
+	Description         string    `json:"description"`
+	Price               int       `json:"price"`
+	WaterTypes          []string  `json:"water_types"`
+	BiteSpeedMultiplier float64   `json:"bite_speed_multiplier"`
+	TierMultipliers     []float64 `json:"tier_multipliers"`
+}
+
+type SpawnEntry struct {
+	FishID string  `json:"fish_id"`
+	Weight float64 `json:"weight"`
+}
+
+type BiomeDef struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	WaterType   string       `json:"water_type"`
+	SpawnTable  []SpawnEntry `json:"spawn_table"`
+}
+
+type ContentSet struct {
+	Fish   []FishDef
+	Lures  []LureDef
+	Biomes []BiomeDef
+}
+
+func contentDir(rootDir string) string {
+	return (&workspace.Workspace{Root: rootDir}).ContentDir()
+}
+
+// loadContent reads and decodes all content files without validating them.
+func loadContent(rootDir string) (*ContentSet, error) {
+	var set ContentSet
+
+	var fishFile struct {
+		Fish []FishDef `json:"fish"`
+	}
+	if err := readJSONFile(filepath.Join(contentDir(rootDir), "fish.json"), &fishFile); err != nil {
+		return nil, err
+	}
+	set.Fish = fishFile.Fish
+
+	var lureFile struct {
+		Lures []LureDef `json:"lures"`
+	}
+	if err := readJSONFile(filepath.Join(contentDir(rootDir), "lures.json"), &lureFile); err != nil {
+		return nil, err
+	}
+	set.Lures = lureFile.Lures
+
+	var biomeFile struct {
+		Biomes []BiomeDef `json:"biomes"`
+	}
+	if err := readJSONFile(filepath.Join(contentDir(rootDir), "biomes.json"), &biomeFile); err != nil {
+		return nil, err
+	}
+	set.Biomes = biomeFile.Biomes
+
+	return &set, nil
+}
+
+func readJSONFile(path string, out any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// lineOfID returns the 1-based line number of the entry declaring the given
+// id in a content file, for violation reports. Returns 0 when not found.
+func lineOfID(path, id string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	needle := []byte(fmt.Sprintf("%q", id))
+	idx := bytes.Index(data, needle)
+	if idx < 0 {
+		return 0
+	}
+	return bytes.Count(data[:idx], []byte{'\n'}) + 1
+}
+
+// validateContent runs schema validation plus the cross-file checks the
+// schemas can't express (unique IDs, spawn-table references, sprite files).
+func validateContent(rootDir string) []string {
+	var violations []string
+	dir := contentDir(rootDir)
+
+	files := []struct {
+		name   string
+		schema string
+	}{
+		{"fish.json", "fish.schema.json"},
+		{"lures.json", "lures.schema.json"},
+		{"biomes.json", "biomes.schema.json"},
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %v", f.name, err))
+			continue
+		}
+		var raw any
+		if err := json.Unmarshal(data, &raw); err != nil {
+			violations = append(violations, fmt.Sprintf("%s: invalid JSON: %v", f.name, err))
+			continue
+		}
+		schema, err := loadSchema(filepath.Join(dir, "schemas", f.schema))
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %v", f.schema, err))
+			continue
+		}
+		var schemaViolations []string
+		validateSchema(schema, raw, f.name, &schemaViolations)
+		violations = append(violations, schemaViolations...)
+	}
+
+	set, err := loadContent(rootDir)
+	if err != nil {
+		// Decoding failures were already reported above via the schema pass.
+		return violations
+	}
+
+	report := func(file, id, format string, args ...any) {
+		loc := file
+		if line := lineOfID(filepath.Join(dir, file), id); line > 0 {
+			loc = fmt.Sprintf("%s:%d", file, line)
+		}
+		violations = append(violations, fmt.Sprintf("%s: %s: %s", loc, id, fmt.Sprintf(format, args...)))
+	}
+
+	fishIDs := map[string]bool{}
+	for _, fish := range set.Fish {
+		if fishIDs[fish.ID] {
+			report("fish.json", fish.ID, "duplicate fish id")
+		}
+		fishIDs[fish.ID] = true
+		if fish.WeightMax < fish.WeightMin {
+			report("fish.json", fish.ID, "weight_max (%v) is below weight_min (%v)", fish.WeightMax, fish.WeightMin)
+		}
+		sheet := filepath.Join(rootDir, "apps", "lurelands", fish.Sprite.Sheet)
+		if _, err := os.Stat(sheet); err != nil {
+			report("fish.json", fish.ID, "sprite sheet %s does not exist", fish.Sprite.Sheet)
+		}
+	}
+
+	lureIDs := map[string]bool{}
+	for _, lure := range set.Lures {
+		if lureIDs[lure.ID] {
+			report("lures.json", lure.ID, "duplicate lure id")
+		}
+		lureIDs[lure.ID] = true
+	}
+
+	biomeIDs := map[string]bool{}
+	for _, biome := range set.Biomes {
+		if biomeIDs[biome.ID] {
+			report("biomes.json", biome.ID, "duplicate biome id")
+		}
+		biomeIDs[biome.ID] = true
+		total := 0.0
+		for _, entry := range biome.SpawnTable {
+			if !fishIDs[entry.FishID] {
+				report("biomes.json", biome.ID, "spawn table references unknown fish %q", entry.FishID)
+			}
+			total += entry.Weight
+		}
+		if total <= 0 {
+			report("biomes.json", biome.ID, "spawn table weights sum to %v; nothing can spawn", total)
+		}
+	}
+
+	return violations
+}
+
+// contentStateHash hashes every content JSON file as one unit. The
+// rules cross-reference files (spawn tables name fish, biomes name
+// lures), so validation can only be skipped when *nothing* changed —
+// per-file skipping would miss broken references.
+func contentStateHash(rootDir string) (string, error) {
+	var files []string
+	err := filepath.Walk(contentDir(rootDir), func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && filepath.Ext(path) == ".json" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+	return combinedHash(files)
+}
+
+func validatedContentHashPath(rootDir string) string {
+	return filepath.Join(cacheDir(rootDir), "content_validated.hash")
+}
+
+func runContentValidate(ctx context.Context, rootDir string) int {
+	hash, hashErr := contentStateHash(rootDir)
+	if hashErr == nil {
+		if last, err := os.ReadFile(validatedContentHashPath(rootDir)); err == nil && string(last) == hash {
+			fmt.Println("content: unchanged since last clean validation")
+			return 0
+		}
+	}
+
+	violations := validateContent(rootDir)
+	if len(violations) == 0 {
+		if hashErr == nil {
+			os.MkdirAll(cacheDir(rootDir), 0o755)
+			os.WriteFile(validatedContentHashPath(rootDir), []byte(hash), 0o644)
+		}
+		fmt.Println("content: all files valid")
+		return 0
+	}
+	for _, v := range violations {
+		fmt.Fprintln(os.Stderr, v)
+	}
+	fmt.Fprintf(os.Stderr, "\ncontent: %d violation(s)\n", len(violations))
+	return 1
+}
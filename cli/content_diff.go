@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// content:diff compares the local content files against the rows in the
+// deployed module's content tables, showing exactly what content:push
+// would change for the target environment.
+
+// contentTables maps each content kind to the module table that stores it.
+// The module seeds these tables from the same JSON shape as the files.
+var contentTables = []string{"fish", "lures", "biomes"}
+
+// fetchDeployedContent queries the module's content tables over
+// `spacetime sql` and returns kind -> id -> entry.
+func fetchDeployedContent(env environment) (map[string]map[string]map[string]any, error) {
+	if _, err := exec.LookPath("spacetime"); err != nil {
+		return nil, errToolNotFound("spacetime", "query deployed tables")
+	}
+	deployed := map[string]map[string]map[string]any{}
+	for _, table := range contentTables {
+		query := fmt.Sprintf("SELECT * FROM %s", table)
+		out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module, query, "--json").Output()
+		if err != nil {
+			if exitErr := (*exec.ExitError)(nil); errors.As(err, &exitErr) &&
+				strings.Contains(string(exitErr.Stderr), "not logged in") {
+				return nil, errAuthRequired(env.Name)
+			}
+			return nil, fmt.Errorf("querying %s on %s: %w", table, env.Name, err)
+		}
+		var rows []map[string]any
+		if err := json.Unmarshal(out, &rows); err != nil {
+			return nil, fmt.Errorf("parsing %s rows: %w", table, err)
+		}
+		byID := map[string]map[string]any{}
+		for _, row := range rows {
+			if id, ok := row["id"].(string); ok {
+				byID[id] = row
+			}
+		}
+		deployed[table] = byID
+	}
+	return deployed, nil
+}
+
+// localContentEntries renders the local content files into the same
+// kind -> id -> entry shape the deployed tables use, by round-tripping the
+// typed definitions through JSON.
+func localContentEntries(set *ContentSet) map[string]map[string]map[string]any {
+	entries := map[string]map[string]map[string]any{}
+	put := func(kind, id string, value any) {
+		data, _ := json.Marshal(value)
+		var entry map[string]any
+		json.Unmarshal(data, &entry)
+		if entries[kind] == nil {
+			entries[kind] = map[string]map[string]any{}
+		}
+		entries[kind][id] = entry
+	}
+	for _, fish := range set.Fish {
+		put("fish", fish.ID, fish)
+	}
+	for _, lure := range set.Lures {
+		put("lures", lure.ID, lure)
+	}
+	for _, biome := range set.Biomes {
+		put("biomes", biome.ID, biome)
+	}
+	return entries
+}
+
+type contentChange struct {
+	Kind   string
+	ID     string
+	Action string // "add", "remove", "update"
+	Fields []string
+}
+
+// diffContentEntries computes per-entry changes from deployed to local.
+func diffContentEntries(local, deployed map[string]map[string]map[string]any) []contentChange {
+	var changes []contentChange
+	for _, kind := range contentTables {
+		localByID := local[kind]
+		deployedByID := deployed[kind]
+
+		ids := map[string]bool{}
+		for id := range localByID {
+			ids[id] = true
+		}
+		for id := range deployedByID {
+			ids[id] = true
+		}
+		sortedIDs := make([]string, 0, len(ids))
+		for id := range ids {
+			sortedIDs = append(sortedIDs, id)
+		}
+		sort.Strings(sortedIDs)
+
+		for _, id := range sortedIDs {
+			localEntry, inLocal := localByID[id]
+			deployedEntry, inDeployed := deployedByID[id]
+			switch {
+			case inLocal && !inDeployed:
+				changes = append(changes, contentChange{Kind: kind, ID: id, Action: "add"})
+			case !inLocal && inDeployed:
+				changes = append(changes, contentChange{Kind: kind, ID: id, Action: "remove"})
+			default:
+				var fields []string
+				for key, localValue := range localEntry {
+					if !jsonEqual(localValue, deployedEntry[key]) {
+						fields = append(fields, key)
+					}
+				}
+				for key := range deployedEntry {
+					if _, ok := localEntry[key]; !ok {
+						fields = append(fields, key)
+					}
+				}
+				if len(fields) > 0 {
+					sort.Strings(fields)
+					changes = append(changes, contentChange{Kind: kind, ID: id, Action: "update", Fields: fields})
+				}
+			}
+		}
+	}
+	return changes
+}
+
+func jsonEqual(a, b any) bool {
+	aj, _ := json.Marshal(a)
+	bj, _ := json.Marshal(b)
+	return string(aj) == string(bj)
+}
+
+func runContentDiff(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("content:diff", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment (local, staging, prod)")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	set, err := loadContent(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	deployed, err := fetchDeployedContent(env)
+	if err != nil {
+		return reportError(err)
+	}
+
+	changes := diffContentEntries(localContentEntries(set), deployed)
+	if len(changes) == 0 {
+		fmt.Printf("content: %s is up to date with local files\n", env.Name)
+		return 0
+	}
+	for _, change := range changes {
+		switch change.Action {
+		case "add":
+			fmt.Printf("+ %s %s\n", change.Kind, change.ID)
+		case "remove":
+			fmt.Printf("- %s %s\n", change.Kind, change.ID)
+		case "update":
+			fmt.Printf("~ %s %s (%v)\n", change.Kind, change.ID, change.Fields)
+		}
+	}
+	fmt.Printf("\ncontent: %d change(s) would be pushed to %s\n", len(changes), env.Name)
+	return 0
+}
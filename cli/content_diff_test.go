@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestDiffContentEntries(t *testing.T) {
+	local := map[string]map[string]map[string]any{
+		"fish": {
+			"fish_new":     {"id": "fish_new", "base_price": 10.0},
+			"fish_changed": {"id": "fish_changed", "base_price": 25.0},
+			"fish_same":    {"id": "fish_same", "base_price": 5.0},
+		},
+	}
+	deployed := map[string]map[string]map[string]any{
+		"fish": {
+			"fish_changed": {"id": "fish_changed", "base_price": 20.0},
+			"fish_same":    {"id": "fish_same", "base_price": 5.0},
+			"fish_removed": {"id": "fish_removed", "base_price": 1.0},
+		},
+	}
+
+	changes := diffContentEntries(local, deployed)
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %d: %v", len(changes), changes)
+	}
+
+	byID := map[string]contentChange{}
+	for _, change := range changes {
+		byID[change.ID] = change
+	}
+	if byID["fish_new"].Action != "add" {
+		t.Errorf("fish_new: expected add, got %q", byID["fish_new"].Action)
+	}
+	if byID["fish_removed"].Action != "remove" {
+		t.Errorf("fish_removed: expected remove, got %q", byID["fish_removed"].Action)
+	}
+	changed := byID["fish_changed"]
+	if changed.Action != "update" || len(changed.Fields) != 1 || changed.Fields[0] != "base_price" {
+		t.Errorf("fish_changed: expected update of base_price, got %+v", changed)
+	}
+}
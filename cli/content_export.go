@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// content:export renders the content data as human-readable tables for
+// design reviews and the community wiki. Entries are sorted by id so
+// re-exports diff cleanly.
+
+func sortedFish(set *ContentSet) []FishDef {
+	fish := append([]FishDef(nil), set.Fish...)
+	sort.Slice(fish, func(i, j int) bool { return fish[i].ID < fish[j].ID })
+	return fish
+}
+
+func sortedLures(set *ContentSet) []LureDef {
+	lures := append([]LureDef(nil), set.Lures...)
+	sort.Slice(lures, func(i, j int) bool { return lures[i].ID < lures[j].ID })
+	return lures
+}
+
+func sortedBiomes(set *ContentSet) []BiomeDef {
+	biomes := append([]BiomeDef(nil), set.Biomes...)
+	sort.Slice(biomes, func(i, j int) bool { return biomes[i].ID < biomes[j].ID })
+	return biomes
+}
+
+func exportMarkdown(set *ContentSet) string {
+	var b strings.Builder
+	b.WriteString("# Lurelands Content\n\n")
+
+	b.WriteString("## Fish\n\n")
+	b.WriteString("| ID | Name | Water | Tier | Base price | Weight (kg) | Description |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, fish := range sortedFish(set) {
+		fmt.Fprintf(&b, "| %s | %s | %s | %d | %d | %.1f–%.1f | %s |\n",
+			fish.ID, fish.Name, fish.WaterType, fish.Tier, fish.BasePrice, fish.WeightMin, fish.WeightMax, fish.Description)
+	}
+
+	b.WriteString("\n## Lures\n\n")
+	b.WriteString("| ID | Name | Price | Waters | Bite speed | Tier multipliers | Description |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, lure := range sortedLures(set) {
+		fmt.Fprintf(&b, "| %s | %s | %d | %s | %.2f | %s | %s |\n",
+			lure.ID, lure.Name, lure.Price, strings.Join(lure.WaterTypes, ", "),
+			lure.BiteSpeedMultiplier, joinFloats(lure.TierMultipliers), lure.Description)
+	}
+
+	b.WriteString("\n## Biomes\n\n")
+	for _, biome := range sortedBiomes(set) {
+		fmt.Fprintf(&b, "### %s (%s)\n\n%s\n\n", biome.Name, biome.ID, biome.Description)
+		b.WriteString("| Fish | Weight |\n| --- | --- |\n")
+		for _, entry := range biome.SpawnTable {
+			fmt.Fprintf(&b, "| %s | %.0f |\n", entry.FishID, entry.Weight)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func exportCSV(set *ContentSet, out *os.File) error {
+	w := csv.NewWriter(out)
+	defer w.Flush()
+
+	if err := w.Write([]string{"kind", "id", "name", "water_type", "tier", "price", "extra"}); err != nil {
+		return err
+	}
+	for _, fish := range sortedFish(set) {
+		if err := w.Write([]string{
+			"fish", fish.ID, fish.Name, fish.WaterType,
+			strconv.Itoa(fish.Tier), strconv.Itoa(fish.BasePrice),
+			fmt.Sprintf("weight=%.1f-%.1f", fish.WeightMin, fish.WeightMax),
+		}); err != nil {
+			return err
+		}
+	}
+	for _, lure := range sortedLures(set) {
+		if err := w.Write([]string{
+			"lure", lure.ID, lure.Name, strings.Join(lure.WaterTypes, "|"),
+			"", strconv.Itoa(lure.Price),
+			fmt.Sprintf("tiers=%s", joinFloats(lure.TierMultipliers)),
+		}); err != nil {
+			return err
+		}
+	}
+	for _, biome := range sortedBiomes(set) {
+		for _, entry := range biome.SpawnTable {
+			if err := w.Write([]string{
+				"spawn", biome.ID, biome.Name, biome.WaterType,
+				"", "", fmt.Sprintf("%s=%.0f", entry.FishID, entry.Weight),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func joinFloats(values []float64) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return strings.Join(parts, "/")
+}
+
+func runContentExport(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("content:export", flag.ContinueOnError)
+	format := flags.String("format", "md", "output format: md or csv")
+	out := flags.String("out", "", "write to a file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	set, err := loadContent(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	dest := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		defer f.Close()
+		dest = f
+	}
+
+	switch *format {
+	case "md":
+		fmt.Fprint(dest, exportMarkdown(set))
+	case "csv":
+		if err := exportCSV(set, dest); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format %q (expected md or csv)\n", *format)
+		return 1
+	}
+	if *out != "" {
+		fmt.Println("exported to", *out)
+	}
+	return 0
+}
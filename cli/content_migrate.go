@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Content files carry a schema version (content/version.json). When the
+// schema changes, add a contentMigration here: migrations run in order
+// against the raw decoded JSON of each file and the version is bumped once
+// they all succeed. content:migrate applies pending migrations;
+// content:migrate --status lists them.
+
+type contentMigration struct {
+	Version     int
+	Description string
+	// Apply mutates the raw file contents in place. Keys are file names
+	// (fish.json, lures.json, biomes.json); values are the decoded JSON.
+	Apply func(files map[string]map[string]any) error
+}
+
+// Ordered, append-only. Never edit a shipped migration — add a new one.
+var contentMigrations = []contentMigration{
+	{
+		Version:     1,
+		Description: "initial schema (fish, lures, biomes)",
+		Apply:       func(files map[string]map[string]any) error { return nil },
+	},
+}
+
+type contentVersionFile struct {
+	SchemaVersion int `json:"schema_version"`
+}
+
+func loadContentSchemaVersion(rootDir string) int {
+	var vf contentVersionFile
+	readJSONFile(filepath.Join(contentDir(rootDir), "version.json"), &vf)
+	return vf.SchemaVersion
+}
+
+func saveContentSchemaVersion(rootDir string, version int) error {
+	return writeJSONFile(filepath.Join(contentDir(rootDir), "version.json"), contentVersionFile{SchemaVersion: version})
+}
+
+var migratableFiles = []string{"fish.json", "lures.json", "biomes.json"}
+
+func runContentMigrate(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("content:migrate", flag.ContinueOnError)
+	status := flags.Bool("status", false, "list migrations and their applied state")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	current := loadContentSchemaVersion(rootDir)
+
+	if *status {
+		fmt.Printf("content schema version: %d\n\n", current)
+		for _, migration := range contentMigrations {
+			marker := " "
+			if migration.Version <= current {
+				marker = "x"
+			}
+			fmt.Printf("  [%s] %03d %s\n", marker, migration.Version, migration.Description)
+		}
+		return 0
+	}
+
+	var pending []contentMigration
+	for _, migration := range contentMigrations {
+		if migration.Version > current {
+			pending = append(pending, migration)
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Printf("content: schema version %d is up to date\n", current)
+		return 0
+	}
+
+	// Load all files once, run every pending migration over them, then
+	// write everything back — a failed migration leaves the files on disk
+	// untouched.
+	files := map[string]map[string]any{}
+	for _, name := range migratableFiles {
+		var raw map[string]any
+		if err := readJSONFile(filepath.Join(contentDir(rootDir), name), &raw); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		files[name] = raw
+	}
+
+	for _, migration := range pending {
+		if err := migration.Apply(files); err != nil {
+			fmt.Fprintf(os.Stderr, "error: migration %03d failed: %v\n", migration.Version, err)
+			return 1
+		}
+		fmt.Printf("applied %03d %s\n", migration.Version, migration.Description)
+	}
+
+	// Round-trip through the typed definitions so migrated files keep the
+	// same field order the content editor writes.
+	for _, name := range migratableFiles {
+		if err := writeJSONFile(filepath.Join(contentDir(rootDir), name), files[name]); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+	}
+	if set, err := loadContent(rootDir); err == nil {
+		if err := writeContent(rootDir, set); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+	}
+	newVersion := pending[len(pending)-1].Version
+	if err := saveContentSchemaVersion(rootDir, newVersion); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	if violations := validateContent(rootDir); len(violations) > 0 {
+		fmt.Fprintf(os.Stderr, "warning: content is invalid after migration (%d violation(s)); run content:validate\n", len(violations))
+	}
+	fmt.Printf("content: migrated to schema version %d\n", newVersion)
+	return 0
+}
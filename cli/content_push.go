@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// content:push uploads the local content files to the target environment's
+// module. The whole set goes through the import_content reducer in one call
+// so the module can swap tables atomically and record the content version;
+// nothing is pushed while validation fails.
+
+// contentVersion is a stable hash of the full content payload, recorded in
+// the module's content_version table with each import.
+func contentVersion(set *ContentSet) string {
+	payload, _ := json.Marshal(set)
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:8])
+}
+
+type contentPayload struct {
+	Version string     `json:"version"`
+	Fish    []FishDef  `json:"fish"`
+	Lures   []LureDef  `json:"lures"`
+	Biomes  []BiomeDef `json:"biomes"`
+}
+
+func runContentPush(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("content:push", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment (local, staging, prod)")
+	skipDiff := flags.Bool("no-diff", false, "skip the pre-push diff against deployed tables")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	release, err := acquireResourceLock(ctx, rootDir, "module")
+	if err != nil {
+		return reportError(err)
+	}
+	defer release()
+
+	if violations := validateContent(rootDir); len(violations) > 0 {
+		for _, v := range violations {
+			fmt.Fprintln(os.Stderr, v)
+		}
+		fmt.Fprintf(os.Stderr, "\ncontent: refusing to push with %d validation violation(s)\n", len(violations))
+		return 1
+	}
+
+	set, err := loadContent(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	if !*skipDiff {
+		deployed, err := fetchDeployedContent(env)
+		if err != nil {
+			return reportError(err)
+		}
+		changes := diffContentEntries(localContentEntries(set), deployed)
+		if len(changes) == 0 {
+			fmt.Printf("content: %s already matches local files, nothing to push\n", env.Name)
+			return 0
+		}
+		fmt.Printf("pushing %d change(s) to %s:\n", len(changes), env.Name)
+		for _, change := range changes {
+			fmt.Printf("  %s %s %s\n", change.Action, change.Kind, change.ID)
+		}
+	}
+
+	version := contentVersion(set)
+	payload, err := json.Marshal(contentPayload{
+		Version: version,
+		Fish:    set.Fish,
+		Lures:   set.Lures,
+		Biomes:  set.Biomes,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	cmd := exec.Command("spacetime", "call", "--server", env.Server, env.Module, "import_content", string(payload))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: import_content failed on %s: %v\n", env.Name, err)
+		return 1
+	}
+	fmt.Printf("content: pushed version %s to %s\n", version, env.Name)
+	return 0
+}
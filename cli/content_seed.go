@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// content:seed-dev generates a small but complete synthetic content set —
+// one fish per tier per water type, a test lure per water type, and one
+// biome per water type — and imports it into the local module so a fresh
+// database is immediately playable. Use --out to write the files somewhere
+// for inspection instead of pushing.
+
+var seedWaterTypes = []string{"pond", "river", "ocean", "night"}
+
+func generateSeedContent() *ContentSet {
+	set := &ContentSet{}
+	basePrices := [4]int{10, 25, 60, 150}
+	for _, water := range seedWaterTypes {
+		for tier := 1; tier <= 4; tier++ {
+			set.Fish = append(set.Fish, FishDef{
+				ID:          fmt.Sprintf("dev_fish_%s_%d", water, tier),
+				Name:        fmt.Sprintf("Dev %s T%d", water, tier),
+				Description: fmt.Sprintf("Synthetic tier %d %s fish for local development.", tier, water),
+				WaterType:   water,
+				Tier:        tier,
+				BasePrice:   basePrices[tier-1],
+				WeightMin:   float64(tier),
+				WeightMax:   float64(tier) * 4,
+				Sprite:      SpriteRef{Sheet: "assets/images/fish/fish_spritesheet.png", Column: tier - 1, Row: 0},
+			})
+		}
+		set.Lures = append(set.Lures, LureDef{
+			ID:                  "dev_lure_" + water,
+			Name:                "Dev Lure (" + water + ")",
+			Description:         "Synthetic test lure for " + water + " water.",
+			Price:               10,
+			WaterTypes:          []string{water},
+			BiteSpeedMultiplier: 1.5,
+			TierMultipliers:     []float64{1, 1, 1, 1},
+		})
+		biome := BiomeDef{
+			ID:          "dev_biome_" + water,
+			Name:        "Dev " + water,
+			Description: "Synthetic " + water + " biome for local development.",
+			WaterType:   water,
+		}
+		// Generous rare-fish weights: dev sessions should see every tier
+		// without grinding.
+		weights := [4]float64{40, 30, 20, 10}
+		for tier := 1; tier <= 4; tier++ {
+			biome.SpawnTable = append(biome.SpawnTable, SpawnEntry{
+				FishID: fmt.Sprintf("dev_fish_%s_%d", water, tier),
+				Weight: weights[tier-1],
+			})
+		}
+		set.Biomes = append(set.Biomes, biome)
+	}
+	return set
+}
+
+func runContentSeedDev(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("content:seed-dev", flag.ContinueOnError)
+	outDir := flags.String("out", "", "write the seed files to a directory instead of importing")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	set := generateSeedContent()
+
+	if *outDir != "" {
+		if err := os.MkdirAll(*outDir, 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		files := map[string]any{
+			"fish.json": struct {
+				Fish []FishDef `json:"fish"`
+			}{set.Fish},
+			"lures.json": struct {
+				Lures []LureDef `json:"lures"`
+			}{set.Lures},
+			"biomes.json": struct {
+				Biomes []BiomeDef `json:"biomes"`
+			}{set.Biomes},
+		}
+		for name, value := range files {
+			if err := writeJSONFile(filepath.Join(*outDir, name), value); err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				return 1
+			}
+		}
+		fmt.Printf("seed content written to %s (%d fish, %d lures, %d biomes)\n",
+			*outDir, len(set.Fish), len(set.Lures), len(set.Biomes))
+		return 0
+	}
+
+	env := environments["local"]
+	payload, err := json.Marshal(contentPayload{
+		Version: "seed-dev-" + contentVersion(set),
+		Fish:    set.Fish,
+		Lures:   set.Lures,
+		Biomes:  set.Biomes,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	cmd := exec.Command("spacetime", "call", "--server", env.Server, env.Module, "import_content", string(payload))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: seeding local module failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("seeded local module with %d fish, %d lures, %d biomes\n",
+		len(set.Fish), len(set.Lures), len(set.Biomes))
+	return 0
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The content editor gives designers a guided form over content/*.json so
+// rarity and weight tuning doesn't involve hand-editing JSON.
+
+var (
+	contentTitleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#00CED1")).Bold(true)
+	contentErrStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B"))
+	contentHelpStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+type contentItem struct {
+	kind  string // "fish" or "lure"
+	index int
+	title string
+	desc  string
+}
+
+func (i contentItem) Title() string       { return i.title }
+func (i contentItem) Description() string { return i.desc }
+func (i contentItem) FilterValue() string { return i.title }
+
+type formField struct {
+	label string
+	input textinput.Model
+}
+
+type contentModel struct {
+	rootDir string
+	set     *ContentSet
+
+	mode    string // "list" or "form"
+	list    list.Model
+	editing contentItem
+	fields  []formField
+	focused int
+	errMsg  string
+	status  string
+}
+
+func newContentModel(rootDir string, set *ContentSet) contentModel {
+	items := make([]list.Item, 0, len(set.Fish)+len(set.Lures))
+	for i, fish := range set.Fish {
+		items = append(items, contentItem{
+			kind:  "fish",
+			index: i,
+			title: fmt.Sprintf("%s (tier %d %s)", fish.Name, fish.Tier, fish.WaterType),
+			desc:  fmt.Sprintf("%s — %dc, %.1f-%.1fkg", fish.ID, fish.BasePrice, fish.WeightMin, fish.WeightMax),
+		})
+	}
+	for i, lure := range set.Lures {
+		items = append(items, contentItem{
+			kind:  "lure",
+			index: i,
+			title: fmt.Sprintf("%s (lure)", lure.Name),
+			desc:  fmt.Sprintf("%s — %dc, waters: %s", lure.ID, lure.Price, strings.Join(lure.WaterTypes, ",")),
+		})
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "Content Editor"
+	l.SetShowStatusBar(false)
+
+	return contentModel{rootDir: rootDir, set: set, mode: "list", list: l}
+}
+
+func (m contentModel) Init() tea.Cmd { return nil }
+
+func (m contentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, msg.Height-2)
+		return m, nil
+	case tea.KeyMsg:
+		if m.mode == "form" {
+			return m.updateForm(msg)
+		}
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "enter":
+			if item, ok := m.list.SelectedItem().(contentItem); ok {
+				m.openForm(item)
+			}
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m *contentModel) openForm(item contentItem) {
+	m.mode = "form"
+	m.editing = item
+	m.errMsg = ""
+	m.status = ""
+	m.focused = 0
+
+	newField := func(label, value string) formField {
+		input := textinput.New()
+		input.SetValue(value)
+		input.CharLimit = 64
+		return formField{label: label, input: input}
+	}
+
+	switch item.kind {
+	case "fish":
+		fish := m.set.Fish[item.index]
+		m.fields = []formField{
+			newField("Name", fish.Name),
+			newField("Water type (pond/river/ocean/night)", fish.WaterType),
+			newField("Tier (1-4)", strconv.Itoa(fish.Tier)),
+			newField("Base price", strconv.Itoa(fish.BasePrice)),
+			newField("Weight min (kg)", formatFloat(fish.WeightMin)),
+			newField("Weight max (kg)", formatFloat(fish.WeightMax)),
+		}
+	case "lure":
+		lure := m.set.Lures[item.index]
+		multipliers := make([]string, len(lure.TierMultipliers))
+		for i, mult := range lure.TierMultipliers {
+			multipliers[i] = formatFloat(mult)
+		}
+		m.fields = []formField{
+			newField("Name", lure.Name),
+			newField("Price", strconv.Itoa(lure.Price)),
+			newField("Water types (comma separated)", strings.Join(lure.WaterTypes, ",")),
+			newField("Bite speed multiplier", formatFloat(lure.BiteSpeedMultiplier)),
+			newField("Tier multipliers (t1,t2,t3,t4)", strings.Join(multipliers, ",")),
+		}
+	}
+	m.fields[0].input.Focus()
+}
+
+func (m contentModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = "list"
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	case "tab", "down":
+		m.moveFocus(1)
+		return m, nil
+	case "shift+tab", "up":
+		m.moveFocus(-1)
+		return m, nil
+	case "enter":
+		if m.focused < len(m.fields)-1 {
+			m.moveFocus(1)
+			return m, nil
+		}
+		if err := m.save(); err != nil {
+			m.errMsg = err.Error()
+			return m, nil
+		}
+		m.mode = "list"
+		m.status = "saved " + m.editing.title
+		m.refreshList()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.fields[m.focused].input, cmd = m.fields[m.focused].input.Update(msg)
+	return m, cmd
+}
+
+func (m *contentModel) moveFocus(delta int) {
+	m.fields[m.focused].input.Blur()
+	m.focused = (m.focused + delta + len(m.fields)) % len(m.fields)
+	m.fields[m.focused].input.Focus()
+}
+
+func (m *contentModel) refreshList() {
+	refreshed := newContentModel(m.rootDir, m.set)
+	m.list.SetItems(refreshed.list.Items())
+}
+
+// save parses the form back into the definition, runs full content
+// validation, and only writes the file when the result is clean.
+func (m *contentModel) save() error {
+	values := make([]string, len(m.fields))
+	for i, field := range m.fields {
+		values[i] = strings.TrimSpace(field.input.Value())
+	}
+
+	switch m.editing.kind {
+	case "fish":
+		fish := &m.set.Fish[m.editing.index]
+		previous := *fish
+		fish.Name = values[0]
+		fish.WaterType = values[1]
+		var err error
+		if fish.Tier, err = strconv.Atoi(values[2]); err != nil {
+			return fmt.Errorf("tier: %v", err)
+		}
+		if fish.BasePrice, err = strconv.Atoi(values[3]); err != nil {
+			return fmt.Errorf("base price: %v", err)
+		}
+		if fish.WeightMin, err = strconv.ParseFloat(values[4], 64); err != nil {
+			return fmt.Errorf("weight min: %v", err)
+		}
+		if fish.WeightMax, err = strconv.ParseFloat(values[5], 64); err != nil {
+			return fmt.Errorf("weight max: %v", err)
+		}
+		if err := m.writeAndValidate(); err != nil {
+			*fish = previous
+			m.writeAndValidate() // restore the file to its previous state
+			return err
+		}
+	case "lure":
+		lure := &m.set.Lures[m.editing.index]
+		previous := *lure
+		lure.Name = values[0]
+		var err error
+		if lure.Price, err = strconv.Atoi(values[1]); err != nil {
+			return fmt.Errorf("price: %v", err)
+		}
+		lure.WaterTypes = splitCommaList(values[2])
+		if lure.BiteSpeedMultiplier, err = strconv.ParseFloat(values[3], 64); err != nil {
+			return fmt.Errorf("bite speed multiplier: %v", err)
+		}
+		multipliers := splitCommaList(values[4])
+		lure.TierMultipliers = make([]float64, 0, len(multipliers))
+		for _, raw := range multipliers {
+			mult, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return fmt.Errorf("tier multipliers: %v", err)
+			}
+			lure.TierMultipliers = append(lure.TierMultipliers, mult)
+		}
+		if err := m.writeAndValidate(); err != nil {
+			*lure = previous
+			m.writeAndValidate()
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *contentModel) writeAndValidate() error {
+	if err := writeContent(m.rootDir, m.set); err != nil {
+		return err
+	}
+	if violations := validateContent(m.rootDir); len(violations) > 0 {
+		return fmt.Errorf("%s (+%d more)", violations[0], len(violations)-1)
+	}
+	return nil
+}
+
+func (m contentModel) View() string {
+	if m.mode == "list" {
+		view := m.list.View()
+		if m.status != "" {
+			view += "\n" + contentHelpStyle.Render(m.status)
+		}
+		return view
+	}
+
+	var b strings.Builder
+	b.WriteString(contentTitleStyle.Render("Edit "+m.editing.title) + "\n\n")
+	for i, field := range m.fields {
+		cursor := "  "
+		if i == m.focused {
+			cursor = "> "
+		}
+		b.WriteString(fmt.Sprintf("%s%s: %s\n", cursor, field.label, field.input.View()))
+	}
+	if m.errMsg != "" {
+		b.WriteString("\n" + contentErrStyle.Render(m.errMsg) + "\n")
+	}
+	b.WriteString("\n" + contentHelpStyle.Render("enter: next/save • tab: move • esc: back"))
+	return b.String()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func splitCommaList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
+
+// writeContent writes the fish and lure files back with stable formatting.
+func writeContent(rootDir string, set *ContentSet) error {
+	fishFile := struct {
+		Fish []FishDef `json:"fish"`
+	}{Fish: set.Fish}
+	if err := writeJSONFile(filepath.Join(contentDir(rootDir), "fish.json"), fishFile); err != nil {
+		return err
+	}
+	lureFile := struct {
+		Lures []LureDef `json:"lures"`
+	}{Lures: set.Lures}
+	if err := writeJSONFile(filepath.Join(contentDir(rootDir), "lures.json"), lureFile); err != nil {
+		return err
+	}
+	biomeFile := struct {
+		Biomes []BiomeDef `json:"biomes"`
+	}{Biomes: set.Biomes}
+	return writeJSONFile(filepath.Join(contentDir(rootDir), "biomes.json"), biomeFile)
+}
+
+func writeJSONFile(path string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func runContentEditor(ctx context.Context, rootDir string) int {
+	set, err := loadContent(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	program := tea.NewProgram(newContentModel(rootDir, set), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
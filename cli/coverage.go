@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// coverage runs every service's test suite with coverage enabled, parses
+// the lcov each toolchain emits, and merges them into one per-service
+// summary. Minimums come from coverage_minimums.json at the repo root
+// (service name -> percent); services without an entry are reported but
+// not enforced.
+
+type coverageResult struct {
+	Service    string
+	LinesFound int
+	LinesHit   int
+	Skipped    bool
+	SkipReason string
+}
+
+func (r coverageResult) percent() float64 {
+	if r.LinesFound == 0 {
+		return 0
+	}
+	return float64(r.LinesHit) / float64(r.LinesFound) * 100
+}
+
+// parseLcov sums LF (lines found) and LH (lines hit) records.
+func parseLcov(path string) (found, hit int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "LF:"); ok {
+			if n, err := strconv.Atoi(value); err == nil {
+				found += n
+			}
+		} else if value, ok := strings.CutPrefix(line, "LH:"); ok {
+			if n, err := strconv.Atoi(value); err == nil {
+				hit += n
+			}
+		}
+	}
+	return found, hit, scanner.Err()
+}
+
+func loadCoverageMinimums(rootDir string) map[string]float64 {
+	minimums := map[string]float64{}
+	data, err := os.ReadFile(filepath.Join(rootDir, "coverage_minimums.json"))
+	if err == nil {
+		json.Unmarshal(data, &minimums)
+	}
+	return minimums
+}
+
+type coverageCollector struct {
+	service string
+	tool    string
+	dir     func(string) string
+	collect func(dir string) (string, error) // runs tests, returns lcov path
+}
+
+var coverageCollectors = []coverageCollector{
+	{
+		service: "flutter",
+		tool:    "flutter",
+		dir:     appDir,
+		collect: func(dir string) (string, error) {
+			cmd := exec.Command("flutter", "test", "--coverage")
+			cmd.Dir = dir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				return "", fmt.Errorf("flutter test --coverage: %v\n%s", err, out)
+			}
+			return filepath.Join(dir, "coverage", "lcov.info"), nil
+		},
+	},
+	{
+		service: "bridge",
+		tool:    "bun",
+		dir:     bridgeDir,
+		collect: func(dir string) (string, error) {
+			cmd := exec.Command("bun", "test", "--coverage", "--coverage-reporter=lcov")
+			cmd.Dir = dir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				return "", fmt.Errorf("bun test --coverage: %v\n%s", err, out)
+			}
+			return filepath.Join(dir, "coverage", "lcov.info"), nil
+		},
+	},
+	{
+		service: "module",
+		tool:    "cargo",
+		dir:     moduleDir,
+		collect: func(dir string) (string, error) {
+			// cargo llvm-cov is preferred; tarpaulin --out Lcov writes the
+			// same format if that's what the machine has.
+			cmd := exec.Command("cargo", "llvm-cov", "--lcov", "--output-path", "coverage.lcov")
+			cmd.Dir = dir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				tarpaulin := exec.Command("cargo", "tarpaulin", "--out", "Lcov")
+				tarpaulin.Dir = dir
+				if out2, err2 := tarpaulin.CombinedOutput(); err2 != nil {
+					return "", fmt.Errorf("cargo llvm-cov: %v\n%s\ncargo tarpaulin: %v\n%s", err, out, err2, out2)
+				}
+				return filepath.Join(dir, "lcov.info"), nil
+			}
+			return filepath.Join(dir, "coverage.lcov"), nil
+		},
+	},
+}
+
+func runCoverage(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	only := flags.String("service", "", "limit to one service (flutter, bridge, module)")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	minimums := loadCoverageMinimums(rootDir)
+	var results []coverageResult
+	for _, collector := range coverageCollectors {
+		if *only != "" && collector.service != *only {
+			continue
+		}
+		dir := collector.dir(rootDir)
+		if _, err := os.Stat(dir); err != nil {
+			results = append(results, coverageResult{Service: collector.service, Skipped: true, SkipReason: "directory missing"})
+			continue
+		}
+		if _, ok := lookupTool(rootDir, collector.tool); !ok {
+			results = append(results, coverageResult{Service: collector.service, Skipped: true, SkipReason: collector.tool + " not installed"})
+			continue
+		}
+		fmt.Printf("collecting %s coverage…\n", collector.service)
+		lcovPath, err := collector.collect(dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		found, hit, err := parseLcov(lcovPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: reading %s: %v\n", lcovPath, err)
+			return 1
+		}
+		results = append(results, coverageResult{Service: collector.service, LinesFound: found, LinesHit: hit})
+	}
+
+	fmt.Println()
+	totalFound, totalHit := 0, 0
+	failed := false
+	for _, result := range results {
+		if result.Skipped {
+			fmt.Printf("  %-8s skipped (%s)\n", result.Service, result.SkipReason)
+			continue
+		}
+		line := fmt.Sprintf("  %-8s %6.1f%%  (%d/%d lines)", result.Service, result.percent(), result.LinesHit, result.LinesFound)
+		if min, ok := minimums[result.Service]; ok {
+			if result.percent() < min {
+				line += fmt.Sprintf("  ← below %.0f%% minimum", min)
+				failed = true
+			} else {
+				line += fmt.Sprintf("  (min %.0f%%)", min)
+			}
+		}
+		fmt.Println(line)
+		totalFound += result.LinesFound
+		totalHit += result.LinesHit
+	}
+	if totalFound > 0 {
+		fmt.Printf("  %-8s %6.1f%%  (%d/%d lines)\n", "overall", float64(totalHit)/float64(totalFound)*100, totalHit, totalFound)
+	}
+	if failed {
+		fmt.Fprintln(os.Stderr, "error: coverage below configured minimums")
+		return 1
+	}
+	return 0
+}
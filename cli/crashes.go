@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// crashes is the triage view over Sentry: recent crash groups for the
+// app and the bridge in one list, sorted by how many players each one
+// actually hits. Each group shows which release (and therefore which
+// deployed module version — releases are tagged at deploy time) first
+// and last saw it, which answers "did the last deploy cause this"
+// without leaving the terminal. Needs SENTRY_AUTH_TOKEN.
+
+const sentryOrg = "lurelands"
+
+var sentryProjects = []string{"lurelands-app", "lurelands-bridge"}
+
+type crashGroup struct {
+	Title        string `json:"title"`
+	Culprit      string `json:"culprit"`
+	Count        string `json:"count"` // Sentry returns these as strings
+	UserCount    int    `json:"userCount"`
+	Permalink    string `json:"permalink"`
+	FirstSeen    string `json:"firstSeen"`
+	LastSeen     string `json:"lastSeen"`
+	Project      string `json:"-"`
+	FirstRelease struct {
+		Version string `json:"version"`
+	} `json:"firstRelease"`
+}
+
+func fetchCrashGroups(ctx context.Context, project string) ([]crashGroup, error) {
+	token := os.Getenv("SENTRY_AUTH_TOKEN")
+	if token == "" {
+		return nil, errAuthRequired("Sentry (set SENTRY_AUTH_TOKEN)")
+	}
+	url := fmt.Sprintf(
+		"https://sentry.io/api/0/projects/%s/%s/issues/?query=is:unresolved&statsPeriod=14d&sort=user",
+		sentryOrg, project)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	client := http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, errAuthRequired("Sentry (check SENTRY_AUTH_TOKEN scopes)")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sentry %s: %s", project, resp.Status)
+	}
+	var groups []crashGroup
+	if err := json.Unmarshal(body, &groups); err != nil {
+		return nil, fmt.Errorf("parsing sentry response: %w", err)
+	}
+	for i := range groups {
+		groups[i].Project = project
+	}
+	return groups, nil
+}
+
+type crashesModel struct {
+	groups  []crashGroup
+	cursor  int
+	loadErr error
+	loaded  bool
+}
+
+type crashesLoaded struct {
+	groups []crashGroup
+	err    error
+}
+
+func (m crashesModel) Init() tea.Cmd { return m.reload() }
+
+func (m crashesModel) reload() tea.Cmd {
+	return func() tea.Msg {
+		var all []crashGroup
+		ctx := context.Background()
+		for _, project := range sentryProjects {
+			groups, err := fetchCrashGroups(ctx, project)
+			if err != nil {
+				return crashesLoaded{err: err}
+			}
+			all = append(all, groups...)
+		}
+		sort.Slice(all, func(i, j int) bool { return all[i].UserCount > all[j].UserCount })
+		return crashesLoaded{groups: all}
+	}
+}
+
+func (m crashesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case crashesLoaded:
+		m.loaded = true
+		m.loadErr = msg.err
+		if msg.err == nil {
+			m.groups = msg.groups
+			if m.cursor >= len(m.groups) && m.cursor > 0 {
+				m.cursor = len(m.groups) - 1
+			}
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.groups)-1 {
+				m.cursor++
+			}
+		case "r":
+			return m, m.reload()
+		}
+	}
+	return m, nil
+}
+
+func (m crashesModel) View() string {
+	s := liveTitleStyle.Render("crash triage — last 14 days") + "\n\n"
+	switch {
+	case m.loadErr != nil:
+		return s + liveWarnStyle.Render("error: "+m.loadErr.Error()) + "\n\n" + liveDimStyle.Render("r retry · q quit")
+	case !m.loaded:
+		return s + "loading…\n"
+	case len(m.groups) == 0:
+		return s + "no unresolved crash groups 🎉\n"
+	}
+
+	for i, group := range m.groups {
+		project := "app"
+		if group.Project == "lurelands-bridge" {
+			project = "bridge"
+		}
+		events, _ := strconv.Atoi(group.Count)
+		line := fmt.Sprintf("%s %5d player(s) %6d event(s)  %s",
+			tui.Accent.Render(fmt.Sprintf("%-6s", project)), group.UserCount, events, shorten(group.Title, 60))
+		if i == m.cursor {
+			line = tui.Selected.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		s += line + "\n"
+	}
+
+	group := m.groups[m.cursor]
+	s += "\n" + liveDimStyle.Render("at ") + shorten(group.Culprit, 70) + "\n"
+	if group.FirstRelease.Version != "" {
+		s += liveDimStyle.Render("since release ") + group.FirstRelease.Version + "\n"
+	}
+	s += liveDimStyle.Render("last seen ") + group.LastSeen + "  " + liveDimStyle.Render(group.Permalink) + "\n"
+	return s + "\n" + liveDimStyle.Render("↑/↓ select · r refresh · q quit")
+}
+
+func runCrashes(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("crashes", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if os.Getenv("SENTRY_AUTH_TOKEN") == "" {
+		return reportError(errAuthRequired("Sentry (set SENTRY_AUTH_TOKEN)"))
+	}
+	if code := requireInteractive("crashes", "query Sentry directly"); code >= 0 {
+		return code
+	}
+	if _, err := tea.NewProgram(crashesModel{}, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
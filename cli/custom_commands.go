@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Custom commands. A [command.<name>] section in .lurelands.toml adds a
+// project task to the CLI without recompiling it:
+//
+//	[command.seed-staging]
+//	summary = "seed staging with the demo tournament"
+//	run = "bun run scripts/seed.ts --env staging"
+//	dir = "services/bridge"
+//	category = "content"   # optional: nest under an existing group
+//	quiet = "true"         # capture output, show it only on failure
+//
+// The built-in tree stays the default; a custom command with the same
+// name as a built-in (within its category) overrides it, which is how a
+// team swaps a stock task for their own variant. run strings may use
+// {{secret:…}} references and honor toolchain pins.
+
+type customCommand struct {
+	name     string
+	category string
+	summary  string
+	run      string
+	dir      string
+	quiet    bool
+}
+
+func loadCustomCommands(rootDir string) []customCommand {
+	var commands []customCommand
+	for section, values := range loadConfigSections(rootDir) {
+		name, ok := strings.CutPrefix(section, "command.")
+		if !ok || values["run"] == "" {
+			continue
+		}
+		commands = append(commands, customCommand{
+			name:     name,
+			category: values["category"],
+			summary:  values["summary"],
+			run:      values["run"],
+			dir:      values["dir"],
+			quiet:    values["quiet"] == "true",
+		})
+	}
+	return commands
+}
+
+func (c customCommand) runFunc() func(context.Context, string, []string) int {
+	return func(ctx context.Context, rootDir string, args []string) int {
+		fields := strings.Fields(c.run)
+		spec := runner.Spec{
+			Name: fields[0],
+			Args: append(fields[1:], args...),
+			Dir:  filepath.Join(rootDir, filepath.FromSlash(c.dir)),
+		}
+		spec, err := expandSpecSecrets(ctx, rootDir, spec)
+		if err != nil {
+			return reportError(err)
+		}
+		spec = pinnedSpec(rootDir, spec)
+
+		if c.quiet {
+			result, err := runner.Run(ctx, spec)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %s failed:\n%s", c.name, result.Output)
+				return result.ExitCode
+			}
+			return 0
+		}
+		result, err := runner.RunStreaming(ctx, spec, func(line string, isStderr bool) {
+			if isStderr {
+				fmt.Fprintln(os.Stderr, line)
+			} else {
+				fmt.Println(line)
+			}
+		})
+		if err != nil && result.ExitCode == 0 {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		return result.ExitCode
+	}
+}
+
+// mergeCustomCommands grafts config-defined commands onto the built-in
+// tree: same-name commands override, new names extend. Called once per
+// invocation after the workspace root is known.
+func mergeCustomCommands(rootDir string) {
+	for _, custom := range loadCustomCommands(rootDir) {
+		summary := custom.summary
+		if summary == "" {
+			summary = custom.run + " (from .lurelands.toml)"
+		}
+		node := &command{name: custom.name, summary: summary, run: custom.runFunc()}
+
+		siblings := &commandTree
+		if custom.category != "" {
+			parent := findCommand(commandTree, custom.category)
+			if parent == nil {
+				fmt.Fprintf(os.Stderr, "warning: [command.%s] category %q does not exist — placing at top level\n",
+					custom.name, custom.category)
+			} else {
+				siblings = &parent.children
+			}
+		}
+		replaced := false
+		for i, existing := range *siblings {
+			if existing.name == custom.name {
+				(*siblings)[i] = node
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			*siblings = append(*siblings, node)
+		}
+	}
+}
+
+func findCommand(tree []*command, name string) *command {
+	for _, node := range tree {
+		if node.name == name {
+			return node
+		}
+	}
+	return nil
+}
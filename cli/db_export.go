@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// db:export dumps tables for local debugging; with --anonymize the
+// dump is safe to share. Identity-like columns are replaced by stable
+// pseudonyms (the same player maps to the same pseudonym everywhere in
+// one export, so cross-table joins still reproduce the bug), display
+// names get generated stand-ins, and free-text columns are blanked
+// outright — chat content is never needed to reproduce a sync bug.
+
+// anonColumnKinds classifies columns by name suffix/keyword; anything
+// matching is rewritten when --anonymize is on.
+var (
+	anonIdentityColumns = []string{"identity", "player_id", "owner", "sender", "reporter_id", "target_id"}
+	anonNameColumns     = []string{"username", "display_name", "name"}
+	anonTextColumns     = []string{"message", "chat", "text", "bio"}
+)
+
+func columnMatches(column string, kinds []string) bool {
+	lower := strings.ToLower(column)
+	for _, kind := range kinds {
+		if lower == kind || strings.HasSuffix(lower, "_"+kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// pseudonym maps a real value to a stable stand-in: the same input
+// yields the same output within and across tables.
+func pseudonym(value string) string {
+	sum := sha256.Sum256([]byte("lurelands-anon:" + value))
+	return "player-" + hex.EncodeToString(sum[:4])
+}
+
+func anonymizeRow(row map[string]any) {
+	for column, value := range row {
+		str, isString := value.(string)
+		switch {
+		case columnMatches(column, anonIdentityColumns):
+			if isString {
+				row[column] = pseudonym(str)
+			}
+		case columnMatches(column, anonNameColumns):
+			if isString {
+				row[column] = "Angler " + strings.TrimPrefix(pseudonym(str), "player-")[:4]
+			}
+		case columnMatches(column, anonTextColumns):
+			if isString {
+				row[column] = ""
+			}
+		}
+	}
+}
+
+func runDBExport(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("db export", flag.ContinueOnError)
+	envName := flags.String("env", "", "environment to export from")
+	tableList := flags.String("tables", "", "comma-separated tables (default: all user tables)")
+	anonymize := flags.Bool("anonymize", false, "replace player identities, names, and chat text")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if env.Name == "prod" && !*anonymize {
+		fmt.Fprintln(os.Stderr, "error: prod exports require --anonymize")
+		return exitUsage
+	}
+
+	var tables []string
+	if *tableList != "" {
+		for _, table := range strings.Split(*tableList, ",") {
+			tables = append(tables, strings.TrimSpace(table))
+		}
+	} else {
+		tables, err = snapshotTables(env)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+	}
+
+	dir := filepath.Join(rootDir, ".lurelands", "exports", time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	for _, table := range tables {
+		out, err := exec.CommandContext(ctx, "spacetime", "sql", "--server", env.Server, env.Module,
+			"SELECT * FROM "+table, "--json").Output()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: dumping %s: %v\n", table, err)
+			return 1
+		}
+		if *anonymize {
+			var rows []map[string]any
+			if err := json.Unmarshal(out, &rows); err != nil {
+				fmt.Fprintf(os.Stderr, "error: parsing %s rows: %v\n", table, err)
+				return 1
+			}
+			for _, row := range rows {
+				anonymizeRow(row)
+			}
+			if out, err = json.MarshalIndent(rows, "", "  "); err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				return 1
+			}
+		}
+		if err := os.WriteFile(filepath.Join(dir, table+".json"), out, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Printf("  %s (%d bytes)\n", table, len(out))
+	}
+
+	label := ""
+	if *anonymize {
+		label = " (anonymized)"
+	}
+	fmt.Printf("exported %d table(s) from %s%s to %s\n", len(tables), env.Name, label, dir)
+	return 0
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestAnonymizeRowStablePseudonyms(t *testing.T) {
+	a := map[string]any{"player_id": "abc123", "username": "ReelDeal", "message": "hi", "coins": float64(7)}
+	b := map[string]any{"owner": "abc123"}
+	anonymizeRow(a)
+	anonymizeRow(b)
+	if a["player_id"] == "abc123" {
+		t.Error("identity was not rewritten")
+	}
+	if a["player_id"] != b["owner"] {
+		t.Errorf("same identity mapped to different pseudonyms: %v vs %v", a["player_id"], b["owner"])
+	}
+	if a["message"] != "" {
+		t.Errorf("chat text survived: %v", a["message"])
+	}
+	if a["coins"] != float64(7) {
+		t.Errorf("non-sensitive column was touched: %v", a["coins"])
+	}
+}
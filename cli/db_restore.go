@@ -0,0 +1,142 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// db:restore is the other half of db:backup — it takes one of the
+// timestamped .tar.gz archives and replays every table into an
+// instance through the import_snapshot reducer. With no archive
+// argument it restores the newest archive recorded for the target
+// environment, which makes "snapshot maincloud, try the risky schema
+// change, put it back" a two-command loop. Restoring to prod goes
+// through the usual typed confirmation (see safety.go).
+
+type restoreTable struct {
+	name string
+	rows json.RawMessage
+}
+
+// readBackupArchive loads all tables from a backup tar.gz into memory;
+// archives are per-table JSON and a full one is a few MB, not a few GB.
+func readBackupArchive(path string) ([]restoreTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a backup archive: %w", path, err)
+	}
+	defer gz.Close()
+
+	var tables []restoreTable
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasSuffix(header.Name, ".json") {
+			continue
+		}
+		rows, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, restoreTable{
+			name: strings.TrimSuffix(filepath.Base(header.Name), ".json"),
+			rows: rows,
+		})
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].name < tables[j].name })
+	return tables, nil
+}
+
+// latestBackupArchive returns the newest archive for an environment;
+// the timestamped names sort chronologically.
+func latestBackupArchive(rootDir, envName string) (string, error) {
+	archives, _ := filepath.Glob(filepath.Join(backupsDir(rootDir, envName), "*.tar.gz"))
+	if len(archives) == 0 {
+		return "", fmt.Errorf("no backups recorded for %s — `lurelands db backup --env %s` first", envName, envName)
+	}
+	sort.Strings(archives)
+	return archives[len(archives)-1], nil
+}
+
+func rowCount(rows json.RawMessage) int {
+	var parsed []json.RawMessage
+	if json.Unmarshal(rows, &parsed) != nil {
+		return 0
+	}
+	return len(parsed)
+}
+
+func runDBRestore(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("db restore", flag.ContinueOnError)
+	envName := flags.String("env", "", "environment to restore into")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	archive := flags.Arg(0)
+	if archive == "" {
+		if archive, err = latestBackupArchive(rootDir, env.Name); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+	}
+	tables, err := readBackupArchive(archive)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if len(tables) == 0 {
+		fmt.Fprintf(os.Stderr, "error: %s contains no tables\n", archive)
+		return 1
+	}
+
+	if env.Name == "local" {
+		// Same lock the other local-state rewriters take.
+		release, err := acquireResourceLock(ctx, rootDir, "localdb")
+		if err != nil {
+			return reportError(err)
+		}
+		defer release()
+	}
+
+	fmt.Printf("restoring %s into %s (%d tables)…\n", filepath.Base(archive), env.Name, len(tables))
+	for i, table := range tables {
+		fmt.Printf("  [%d/%d] %s (%d rows)… ", i+1, len(tables), table.name, rowCount(table.rows))
+		payload, _ := json.Marshal(map[string]any{"table": table.name, "rows": table.rows})
+		if out, err := exec.CommandContext(ctx, "spacetime", "call", "--server", env.Server, env.Module,
+			"import_snapshot", string(payload)).CombinedOutput(); err != nil {
+			fmt.Println("FAIL")
+			fmt.Fprintf(os.Stderr, "error: restoring %s: %v\n%s", table.name, err, out)
+			return 1
+		}
+		fmt.Println("ok")
+	}
+	fmt.Printf("restored %d table(s) into %s\n", len(tables), env.Name)
+	return 0
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// db:seed loads fixture files from fixtures/ into the local module, so
+// "mid-game account with a full tacklebox" is one command instead of
+// twenty minutes of hand-played setup. Two formats: <name>.json maps
+// table names to row arrays and goes through the import_snapshot
+// reducer (the same clear-and-fill path snapshots use), <name>.sql is
+// statements fed to `spacetime sql` one by one. With no argument a
+// picker lists what fixtures/ offers. Local only, like db:snapshot —
+// seeding staging is how test fish end up on the leaderboard.
+
+func fixturesDir(rootDir string) string {
+	return filepath.Join(rootDir, "fixtures")
+}
+
+type fixture struct {
+	name string
+	path string
+}
+
+func listFixtures(rootDir string) []fixture {
+	entries, err := os.ReadDir(fixturesDir(rootDir))
+	if err != nil {
+		return nil
+	}
+	var fixtures []fixture
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".json" && ext != ".sql") {
+			continue
+		}
+		fixtures = append(fixtures, fixture{
+			name: strings.TrimSuffix(entry.Name(), ext),
+			path: filepath.Join(fixturesDir(rootDir), entry.Name()),
+		})
+	}
+	sort.Slice(fixtures, func(i, j int) bool { return fixtures[i].name < fixtures[j].name })
+	return fixtures
+}
+
+// loadJSONFixture pushes each table through import_snapshot; --reset is
+// implicit in that reducer (clear then fill), so reset only matters for
+// tables the fixture doesn't mention.
+func loadJSONFixture(env environment, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var tables map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tables); err != nil {
+		return fmt.Errorf("parsing %s (want {\"table\": [rows…]}): %w", path, err)
+	}
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, table := range names {
+		payload, _ := json.Marshal(map[string]any{"table": table, "rows": tables[table]})
+		if err := exec.Command("spacetime", "call", "--server", env.Server, env.Module,
+			"import_snapshot", string(payload)).Run(); err != nil {
+			return fmt.Errorf("loading table %s: %w", table, err)
+		}
+		fmt.Printf("  loaded %s\n", table)
+	}
+	return nil
+}
+
+func loadSQLFixture(env environment, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	for _, statement := range strings.Split(string(data), ";") {
+		statement = strings.TrimSpace(statement)
+		if statement == "" || strings.HasPrefix(statement, "--") {
+			continue
+		}
+		if out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module,
+			statement).CombinedOutput(); err != nil {
+			return fmt.Errorf("statement %q: %v\n%s", shorten(statement, 60), err, out)
+		}
+	}
+	return nil
+}
+
+// resetUserTables clears every non-system table before the fixture
+// loads, via the same empty-rows import the snapshot restore uses.
+func resetUserTables(env environment) error {
+	tables, err := snapshotTables(env)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		payload, _ := json.Marshal(map[string]any{"table": table, "rows": []any{}})
+		if err := exec.Command("spacetime", "call", "--server", env.Server, env.Module,
+			"import_snapshot", string(payload)).Run(); err != nil {
+			return fmt.Errorf("clearing %s: %w", table, err)
+		}
+	}
+	fmt.Printf("  cleared %d table(s)\n", len(tables))
+	return nil
+}
+
+type fixturePickerModel struct {
+	fixtures []fixture
+	cursor   int
+	chosen   string
+}
+
+func (m fixturePickerModel) Init() tea.Cmd { return nil }
+
+func (m fixturePickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.fixtures)-1 {
+				m.cursor++
+			}
+		case "enter":
+			m.chosen = m.fixtures[m.cursor].name
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m fixturePickerModel) View() string {
+	s := liveTitleStyle.Render("pick a fixture") + "\n\n"
+	for i, fix := range m.fixtures {
+		line := fmt.Sprintf("%-24s %s", fix.name, tui.Dim.Render(filepath.Base(fix.path)))
+		if i == m.cursor {
+			line = tui.Selected.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		s += line + "\n"
+	}
+	return s + "\n" + liveDimStyle.Render("↑/↓ select · enter load · esc cancel")
+}
+
+func pickFixture(fixtures []fixture) (string, error) {
+	if ciMode {
+		return "", fmt.Errorf("the fixture picker is unavailable with --ci — `lurelands db seed <name>`")
+	}
+	final, err := tea.NewProgram(fixturePickerModel{fixtures: fixtures}, tea.WithAltScreen()).Run()
+	if err != nil {
+		return "", err
+	}
+	return final.(fixturePickerModel).chosen, nil
+}
+
+func runDBSeed(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("db seed", flag.ContinueOnError)
+	reset := flags.Bool("reset", false, "clear all user tables before loading")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	fixtures := listFixtures(rootDir)
+	if len(fixtures) == 0 {
+		fmt.Fprintf(os.Stderr, "no fixtures found — drop .json or .sql files in %s\n", fixturesDir(rootDir))
+		return 1
+	}
+
+	name := flags.Arg(0)
+	if name == "" {
+		var err error
+		if name, err = pickFixture(fixtures); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		if name == "" {
+			return exitCancelled
+		}
+	}
+	var chosen *fixture
+	for i := range fixtures {
+		if fixtures[i].name == name {
+			chosen = &fixtures[i]
+		}
+	}
+	if chosen == nil {
+		names := make([]string, len(fixtures))
+		for i, fix := range fixtures {
+			names[i] = fix.name
+		}
+		fmt.Fprintf(os.Stderr, "no fixture %q (have: %s)\n", name, strings.Join(names, ", "))
+		return 1
+	}
+
+	env := environments["local"]
+	if !localSpacetimeUp(rootDir) {
+		fmt.Fprintln(os.Stderr, "error: no local spacetime server is listening — `lurelands db start` first")
+		return 1
+	}
+
+	// Same lock as snapshot save/load: both rewrite local DB state.
+	release, err := acquireResourceLock(ctx, rootDir, "localdb")
+	if err != nil {
+		return reportError(err)
+	}
+	defer release()
+
+	fmt.Printf("seeding local module from %s…\n", filepath.Base(chosen.path))
+	if *reset {
+		if err := resetUserTables(env); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+	}
+	if filepath.Ext(chosen.path) == ".sql" {
+		err = loadSQLFixture(env, chosen.path)
+	} else {
+		err = loadJSONFixture(env, chosen.path)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Printf("seeded fixture %q\n", chosen.name)
+	return 0
+}
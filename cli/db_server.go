@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// Local SpacetimeDB server management. `db start` launches `spacetime
+// start` detached with its output in .lurelands/spacetime.log and
+// records pid, port, and data dir in .lurelands/spacetime.json; `db
+// stop` and `db status` work from that record. The status check is a
+// TCP dial, not a pid probe — a recorded pid can be stale after a
+// crash, but a listening port can't lie. The menu header shows the
+// same indicator so a dead local server is visible before a deploy
+// fails against it.
+
+const defaultSpacetimePort = 3000
+
+type spacetimeServerInfo struct {
+	PID       int       `json:"pid"`
+	Port      int       `json:"port"`
+	DataDir   string    `json:"data_dir"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+func spacetimeServerInfoPath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "spacetime.json")
+}
+
+func loadSpacetimeServerInfo(rootDir string) *spacetimeServerInfo {
+	data, err := os.ReadFile(spacetimeServerInfoPath(rootDir))
+	if err != nil {
+		return nil
+	}
+	info := &spacetimeServerInfo{}
+	if json.Unmarshal(data, info) != nil {
+		return nil
+	}
+	return info
+}
+
+// localSpacetimeUp reports whether something is listening on the local
+// server port (recorded, or the default when nothing was recorded).
+func localSpacetimeUp(rootDir string) bool {
+	port := defaultSpacetimePort
+	if info := loadSpacetimeServerInfo(rootDir); info != nil {
+		port = info.Port
+	}
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), 300*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+func runDBStart(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("db start", flag.ContinueOnError)
+	port := flags.Int("port", defaultSpacetimePort, "port for the local server")
+	dataDir := flags.String("data-dir", "", "server data dir (default: .lurelands/spacetime-data)")
+	killPort := flags.Bool("kill-port", false, "terminate whatever holds the port first")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if _, ok := lookupTool(rootDir, "spacetime"); !ok {
+		return reportError(errToolNotFound("spacetime", "starting the local server"))
+	}
+	if localSpacetimeUp(rootDir) {
+		fmt.Println("local spacetime server is already up")
+		return 0
+	}
+	if !ensurePortFree(rootDir, *port, "spacetime", *killPort) {
+		return 1
+	}
+	if *dataDir == "" {
+		*dataDir = filepath.Join(rootDir, ".lurelands", "spacetime-data")
+	}
+	os.MkdirAll(*dataDir, 0o755)
+
+	logPath := filepath.Join(rootDir, ".lurelands", "spacetime.log")
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	defer logFile.Close()
+
+	// Deliberately plain exec.Command, not the runner: this child must
+	// outlive the CLI, so it gets no context and no process-group kill.
+	cmd := exec.Command("spacetime", "start",
+		"--listen-addr", fmt.Sprintf("127.0.0.1:%d", *port), "--data-dir", *dataDir)
+	cmd.Stdout, cmd.Stderr = logFile, logFile
+	if err := cmd.Start(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	info := spacetimeServerInfo{PID: cmd.Process.Pid, Port: *port, DataDir: *dataDir, StartedAt: time.Now()}
+	data, _ := json.MarshalIndent(info, "", "  ")
+	os.WriteFile(spacetimeServerInfoPath(rootDir), append(data, '\n'), 0o644)
+	cmd.Process.Release()
+
+	// Give it a moment to bind so an immediate failure is reported here
+	// rather than discovered by the next deploy.
+	for i := 0; i < 20; i++ {
+		if localSpacetimeUp(rootDir) {
+			fmt.Printf("local spacetime server up (pid %d, port %d, logs %s)\n", info.PID, info.Port, logPath)
+			return 0
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	fmt.Fprintf(os.Stderr, "error: server did not come up — check %s\n", logPath)
+	return 1
+}
+
+func runDBStop(ctx context.Context, rootDir string, args []string) int {
+	info := loadSpacetimeServerInfo(rootDir)
+	if info == nil {
+		fmt.Println("no recorded local server (started outside the CLI?)")
+		return 1
+	}
+	process, err := os.FindProcess(info.PID)
+	if err == nil {
+		err = process.Kill()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: stopping pid %d: %v\n", info.PID, err)
+		return 1
+	}
+	os.Remove(spacetimeServerInfoPath(rootDir))
+	fmt.Printf("stopped local spacetime server (pid %d)\n", info.PID)
+	return 0
+}
+
+func runDBStatus(ctx context.Context, rootDir string, args []string) int {
+	info := loadSpacetimeServerInfo(rootDir)
+	up := localSpacetimeUp(rootDir)
+	switch {
+	case info == nil && up:
+		fmt.Println("a local server is listening, but it was not started by the CLI")
+	case info == nil:
+		fmt.Println("local spacetime server: down (`lurelands db start` to launch one)")
+		return 1
+	case up:
+		fmt.Printf("local spacetime server: up — pid %d, port %d, data %s, since %s\n",
+			info.PID, info.Port, info.DataDir, relativeAge(info.StartedAt))
+	default:
+		fmt.Printf("local spacetime server: DOWN (recorded pid %d is not listening on %d)\n", info.PID, info.Port)
+		return 1
+	}
+	return 0
+}
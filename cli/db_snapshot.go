@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// db:snapshot freezes and restores the local instance's game state so QA
+// can jump straight to "mid-tournament with 4 players" instead of
+// re-seeding and hand-playing. Snapshots are per-table JSON dumps under
+// .lurelands/snapshots/<name>/ and restores go through the module's
+// import_snapshot reducer, which clears and refills each table in one
+// transaction. Local only — restoring over staging or prod state is never
+// what anyone wants from this command.
+
+func snapshotsDir(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "snapshots")
+}
+
+type snapshotMeta struct {
+	Name    string    `json:"name"`
+	SavedAt time.Time `json:"saved_at"`
+	Tables  []string  `json:"tables"`
+}
+
+// snapshotTables asks the instance which user tables exist; system tables
+// (st_*) are the instance's own business.
+func snapshotTables(env environment) ([]string, error) {
+	out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module,
+		"SELECT table_name FROM st_table", "--json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+	var rows []struct {
+		TableName string `json:"table_name"`
+	}
+	if err := json.Unmarshal(out, &rows); err != nil {
+		return nil, fmt.Errorf("parsing table list: %w", err)
+	}
+	var tables []string
+	for _, row := range rows {
+		if !strings.HasPrefix(row.TableName, "st_") {
+			tables = append(tables, row.TableName)
+		}
+	}
+	sort.Strings(tables)
+	return tables, nil
+}
+
+func saveSnapshot(rootDir string, env environment, name string) error {
+	tables, err := snapshotTables(env)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(snapshotsDir(rootDir), name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module,
+			"SELECT * FROM "+table, "--json").Output()
+		if err != nil {
+			return fmt.Errorf("dumping %s: %w", table, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, table+".json"), out, 0o644); err != nil {
+			return err
+		}
+	}
+	meta := snapshotMeta{Name: name, SavedAt: time.Now(), Tables: tables}
+	data, _ := json.MarshalIndent(meta, "", "  ")
+	return os.WriteFile(filepath.Join(dir, "snapshot.json"), data, 0o644)
+}
+
+func loadSnapshot(rootDir string, env environment, name string) error {
+	dir := filepath.Join(snapshotsDir(rootDir), name)
+	data, err := os.ReadFile(filepath.Join(dir, "snapshot.json"))
+	if err != nil {
+		return fmt.Errorf("snapshot %q not found (saved ones: %s)", name, strings.Join(listSnapshots(rootDir), ", "))
+	}
+	var meta snapshotMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return fmt.Errorf("parsing snapshot meta: %w", err)
+	}
+	for _, table := range meta.Tables {
+		rows, err := os.ReadFile(filepath.Join(dir, table+".json"))
+		if err != nil {
+			return err
+		}
+		payload, _ := json.Marshal(map[string]any{"table": table, "rows": json.RawMessage(rows)})
+		if err := exec.Command("spacetime", "call", "--server", env.Server, env.Module,
+			"import_snapshot", string(payload)).Run(); err != nil {
+			return fmt.Errorf("restoring %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+func listSnapshots(rootDir string) []string {
+	entries, err := os.ReadDir(snapshotsDir(rootDir))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+func runDBSnapshot(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("db:snapshot", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	rest := flags.Args()
+
+	env := environments["local"]
+
+	if len(rest) == 1 && rest[0] == "list" {
+		names := listSnapshots(rootDir)
+		if len(names) == 0 {
+			fmt.Println("no snapshots saved")
+			return 0
+		}
+		for _, name := range names {
+			fmt.Println(" ", name)
+		}
+		return 0
+	}
+	if len(rest) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands db:snapshot <save|load> <name>  (or: db:snapshot list)")
+		return 1
+	}
+	sub, name := rest[0], rest[1]
+
+	// save and load both rewrite local DB state; don't interleave with
+	// another invocation doing the same.
+	release, err := acquireResourceLock(ctx, rootDir, "localdb")
+	if err != nil {
+		return reportError(err)
+	}
+	defer release()
+
+	switch sub {
+	case "save":
+		if err := saveSnapshot(rootDir, env, name); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Printf("saved snapshot %q\n", name)
+		return 0
+	case "load":
+		if err := loadSnapshot(rootDir, env, name); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Printf("restored snapshot %q\n", name)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown db:snapshot subcommand %q\n", sub)
+		return 1
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Internal logging rides on log/slog. By default everything below
+// Error is discarded; `-v` turns on Info-level logs to stderr (the
+// decisions that explain what the CLI is doing: resolved workspace
+// root, exact exec invocations, injected environment, per-command
+// timing), `-vv` or `--debug` turns on everything, and `--debug=path`
+// appends to a file instead so a TUI session isn't wrecked by
+// interleaved log lines. `--quiet` goes the other way: stdout is
+// swallowed and only errors plus a one-line final status remain.
+// Commands log via slog.Info/slog.Debug directly — no logger threading.
+
+// quiet is set by --quiet; main() swaps stdout out around dispatch.
+var quiet bool
+
+// setupDebugLogging scans args for the verbosity flags, configures the
+// default slog logger accordingly, and returns args with the flags
+// removed plus a cleanup func (closes the log file, if any).
+func setupDebugLogging(args []string) ([]string, func(), error) {
+	cleanup := func() {}
+	kept := args[:0:0]
+	level := slog.LevelError + 1 // above everything: discard
+	logPath := ""
+	toFile := false
+	for _, arg := range args {
+		switch {
+		case arg == "-v":
+			if level > slog.LevelInfo {
+				level = slog.LevelInfo
+			}
+		case arg == "-vv", arg == "--debug":
+			level = slog.LevelDebug
+		case strings.HasPrefix(arg, "--debug="):
+			level = slog.LevelDebug
+			logPath = strings.TrimPrefix(arg, "--debug=")
+			toFile = true
+		case arg == "--quiet", arg == "-q":
+			quiet = true
+		default:
+			kept = append(kept, arg)
+		}
+	}
+
+	var out io.Writer = io.Discard
+	if level <= slog.LevelInfo {
+		out = os.Stderr
+		if toFile {
+			f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return args, cleanup, fmt.Errorf("opening debug log: %w", err)
+			}
+			out = f
+			cleanup = func() { f.Close() }
+		}
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{Level: level})))
+	return kept, cleanup, nil
+}
+
+// silenceStdout redirects os.Stdout into a drained pipe for --quiet
+// and returns a restore func. fmt reads the os.Stdout variable on
+// every call, so everything the command prints (and everything child
+// processes inherit) lands in the pipe; stderr is left alone so errors
+// still surface. Interactive commands see a non-tty stdout and refuse
+// with their usual guidance, which is the right answer under --quiet.
+func silenceStdout() func() *os.File {
+	real := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		return func() *os.File { return real }
+	}
+	os.Stdout = w
+	done := make(chan struct{})
+	go func() {
+		io.Copy(io.Discard, r)
+		close(done)
+	}()
+	return func() *os.File {
+		os.Stdout = real
+		w.Close()
+		<-done
+		r.Close()
+		return real
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// deploy publishes the module to an environment and immediately runs the
+// smoke suite against it: the ping reducer answers, the bridge health
+// endpoint is OK, and a bot can connect and cast once. A deploy that
+// fails smoke is not a deploy — by default we alert loudly; with
+// --rollback we republish the last build that passed smoke, which deploy
+// keeps a copy of per environment.
+
+var bridgeHealthURLs = map[string]string{
+	"local":   "http://localhost:8080/health",
+	"staging": "https://bridge-staging.lurelands.com/health",
+	"prod":    "https://bridge.lurelands.com/health",
+}
+
+func deploysDir(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "deploys")
+}
+
+func lastGoodPath(rootDir string, env environment) string {
+	return filepath.Join(deploysDir(rootDir), env.Name+"-last-good.wasm")
+}
+
+// builtModulePath is where `spacetime publish` leaves the compiled module.
+func builtModulePath(rootDir string) string {
+	return filepath.Join(moduleDir(rootDir), "target", "wasm32-unknown-unknown", "release", "spacetime_module.wasm")
+}
+
+func smokePing(env environment) error {
+	if err := exec.Command("spacetime", "call", "--server", env.Server, env.Module, "ping").Run(); err != nil {
+		return fmt.Errorf("ping reducer did not answer: %w", err)
+	}
+	return nil
+}
+
+func smokeBridgeHealth(env environment) error {
+	url := bridgeHealthURL(env)
+	if url == "" {
+		return fmt.Errorf("no bridge health URL configured for %s", env.Name)
+	}
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("bridge health: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bridge health returned %s", resp.Status)
+	}
+	return nil
+}
+
+func smokeBotCast(rootDir string, env environment) error {
+	bot := exec.Command("bun", "run", "scripts/bot.ts",
+		"--name", "smoke-bot", "--behavior", "fish_loop", "--once",
+		"--server", env.Server, "--module", env.Module)
+	bot.Dir = bridgeDir(rootDir)
+	if out, err := bot.CombinedOutput(); err != nil {
+		return fmt.Errorf("smoke bot could not connect and cast: %v\n%s", err, out)
+	}
+	return nil
+}
+
+func runSmokeSuite(rootDir string, env environment) error {
+	checks := []struct {
+		name string
+		run  func() error
+	}{
+		{"ping reducer", func() error { return smokePing(env) }},
+		{"bridge health", func() error { return smokeBridgeHealth(env) }},
+		{"bot connect + cast", func() error { return smokeBotCast(rootDir, env) }},
+	}
+	for _, check := range checks {
+		fmt.Printf("  smoke: %s… ", check.name)
+		if err := check.run(); err != nil {
+			fmt.Println("FAIL")
+			return err
+		}
+		fmt.Println("ok")
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0o644)
+}
+
+// buildModuleWasm produces the release wasm, consulting the input-hash
+// cache first (skip the build entirely when nothing changed), then the
+// shared remote cache when one is configured: the artifact key is the
+// module schema hash, so any checkout (or CI) that built the same
+// sources can supply it. Successful local builds are pushed back
+// best-effort.
+func buildModuleWasm(ctx context.Context, rootDir string, rebuild bool) error {
+	// The walk picks up Cargo.lock and Cargo.toml along with the sources.
+	return cachedStep(rootDir, "module-wasm", rebuild, stepInputs{
+		dirs:    []string{moduleDir(rootDir)},
+		tools:   []string{"rust", "spacetime"},
+		outputs: []string{builtModulePath(rootDir)},
+	}, func() error { return buildModuleWasmUncached(ctx, rootDir) })
+}
+
+func buildModuleWasmUncached(ctx context.Context, rootDir string) error {
+	base := remoteCacheBase()
+	key := ""
+	if base != "" {
+		hash, err := moduleSchemaHash(rootDir)
+		if err == nil {
+			key = "module-wasm-" + hash
+			built := builtModulePath(rootDir)
+			os.MkdirAll(filepath.Dir(built), 0o755)
+			if hit, err := pullArtifact(ctx, base, key, built); err != nil {
+				fmt.Fprintln(os.Stderr, "warning: remote cache pull:", err)
+			} else if hit {
+				fmt.Println("  wasm pulled from remote cache")
+				return nil
+			}
+		}
+	}
+
+	result, err := runWithProgress(ctx, "module build", "cargo", pinnedSpec(rootDir, runner.Spec{
+		Name: "cargo",
+		Args: []string{"build", "--target", "wasm32-unknown-unknown", "--release"},
+		Dir:  moduleDir(rootDir),
+	}))
+	if err != nil {
+		return fmt.Errorf("module build: %w\n%s", err, summarizeFailure(result.Output, 15))
+	}
+	if key != "" && fileExists(builtModulePath(rootDir)) {
+		if err := pushArtifact(ctx, base, key, builtModulePath(rootDir)); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: remote cache push:", err)
+		}
+	}
+	return nil
+}
+
+// prepareDeployArtifacts runs the pre-publish steps whose inputs are
+// independent concurrently: the wasm build and the TS binding
+// generation both read only module sources, so there is no reason to
+// wait for one before the other. Dart generation joins this list when
+// it exists. Output is captured per step to keep the interleaving sane.
+func prepareDeployArtifacts(ctx context.Context, rootDir string, rebuild bool) error {
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"wasm build", func() error { return buildModuleWasm(ctx, rootDir, rebuild) }},
+		{"ts bindings", func() error { return ensureBridgeBindings(ctx, rootDir, rebuild) }},
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(steps))
+	for i, step := range steps {
+		wg.Add(1)
+		go func(i int, name string, run func() error) {
+			defer wg.Done()
+			start := time.Now()
+			errs[i] = run()
+			if errs[i] == nil {
+				fmt.Printf("  %s done (%s)\n", name, time.Since(start).Round(time.Millisecond))
+			}
+		}(i, step.name, step.run)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func publishModule(ctx context.Context, rootDir string, env environment, binPath string) error {
+	args := []string{"publish", "--server", env.Server, env.Module}
+	if binPath != "" {
+		args = append(args, "--bin-path", binPath)
+	}
+	result, err := runWithRetry(ctx, rootDir, "publish", "publish "+env.Name, "spacetime", runner.Spec{
+		Name: "spacetime", Args: args, Dir: moduleDir(rootDir),
+	})
+	if err != nil {
+		return fmt.Errorf("%w\n%s", err, summarizeFailure(result.Output, 10))
+	}
+	return nil
+}
+
+func runDeploy(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("deploy", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	rollback := flags.Bool("rollback", false, "republish the last smoke-passing build if smoke fails")
+	skipSmoke := flags.Bool("skip-smoke", false, "skip the post-deploy smoke suite (emergencies only)")
+	force := flags.Bool("force", false, "publish even when the schema diff shows destructive changes")
+	rebuild := flags.Bool("rebuild", false, "build and generate even when input hashes match")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "staging"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	if env.Server == "local" && !localSpacetimeUp(rootDir) {
+		fmt.Fprintln(os.Stderr, "error: no local spacetime server is listening — `lurelands db start` first")
+		return 1
+	}
+
+	release, err := acquireResourceLock(ctx, rootDir, "module")
+	if err != nil {
+		return reportError(err)
+	}
+	defer release()
+
+	printServiceChanges(rootDir)
+	fmt.Printf("deploying module to %s (%s/%s)…\n", env.Name, env.Server, env.Module)
+	if !schemaPreflight(ctx, rootDir, env, *force) {
+		return 1
+	}
+	if err := prepareDeployArtifacts(ctx, rootDir, *rebuild); err != nil {
+		fmt.Fprintln(os.Stderr, "error: pre-publish build failed:", err)
+		return 1
+	}
+	// Publish the wasm we just built rather than letting publish rebuild.
+	binPath := ""
+	if built := builtModulePath(rootDir); fileExists(built) {
+		binPath = built
+	}
+	if err := publishModule(ctx, rootDir, env, binPath); err != nil {
+		fmt.Fprintln(os.Stderr, "error: publish failed:", err)
+		return 1
+	}
+
+	if dryRun {
+		// Nothing was published, so there is nothing to smoke-test (and
+		// the ping reducer would poke the live module for real).
+		fmt.Println("dry run — smoke suite and last-good bookkeeping skipped")
+		return 0
+	}
+	if *skipSmoke {
+		fmt.Println("deployed (smoke suite skipped)")
+		return 0
+	}
+
+	if err := runSmokeSuite(rootDir, env); err != nil {
+		fmt.Fprintln(os.Stderr, "error: post-deploy smoke failed:", err)
+		if !*rollback {
+			fmt.Fprintf(os.Stderr, "ALERT: %s is running an unverified deploy — fix forward or rerun with --rollback\n", env.Name)
+			return 1
+		}
+		lastGood := lastGoodPath(rootDir, env)
+		if _, statErr := os.Stat(lastGood); statErr != nil {
+			fmt.Fprintf(os.Stderr, "ALERT: no last-good build recorded for %s, cannot roll back\n", env.Name)
+			return 1
+		}
+		fmt.Printf("rolling back %s to last smoke-passing build…\n", env.Name)
+		if err := publishModule(ctx, rootDir, env, lastGood); err != nil {
+			fmt.Fprintln(os.Stderr, "error: rollback publish failed:", err)
+			return 1
+		}
+		if err := runSmokeSuite(rootDir, env); err != nil {
+			fmt.Fprintln(os.Stderr, "ALERT: rollback deployed but smoke still failing:", err)
+			return 1
+		}
+		fmt.Println("rolled back; smoke passing on previous build")
+		return 1 // the deploy itself still failed
+	}
+
+	// Smoke passed — this build becomes the rollback target. Stored via
+	// the blob store so identical builds across envs share one copy.
+	if built := builtModulePath(rootDir); fileExists(built) {
+		if err := storeAsBlob(rootDir, built, lastGoodPath(rootDir, env)); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: could not record last-good build:", err)
+		}
+	}
+	writeDeployMeta(rootDir, env) // ground truth for `lurelands drift`
+	fmt.Printf("deployed to %s; smoke suite passed\n", env.Name)
+	return 0
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
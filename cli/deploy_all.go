@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// deploy:all ships the spacetime module and the bridge as one
+// coordinated operation. Version skew between the two has taken prod
+// down before, so the sequence is deliberate: regenerate bindings and
+// build everything first, refuse to continue when the bridge bindings
+// do not match the module schema, publish the module, then deploy the
+// bridge — and if the bridge half (or the final smoke suite) fails,
+// put both sides back: republish the last smoke-passing module build
+// and restore the host's previous dist/.
+
+func runDeployAll(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("deploy all", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	force := flags.Bool("force", false, "publish even when the schema diff shows destructive changes")
+	rebuild := flags.Bool("rebuild", false, "build and generate even when input hashes match")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "staging"))
+	if err != nil {
+		return reportError(err)
+	}
+	// Fail fast on missing config and tools before touching anything.
+	target, err := bridgeDeployTargetFor(rootDir, env.Name)
+	if err != nil {
+		return reportError(err)
+	}
+	for _, tool := range []string{"spacetime", "bun", "ssh"} {
+		if _, ok := lookupTool(rootDir, tool); !ok {
+			return reportError(errToolNotFound(tool, "deploying the module and bridge together"))
+		}
+	}
+
+	release, err := acquireResourceLock(ctx, rootDir, "module")
+	if err != nil {
+		return reportError(err)
+	}
+	defer release()
+
+	fmt.Printf("deploying module + bridge to %s…\n", env.Name)
+	if !schemaPreflight(ctx, rootDir, env, *force) {
+		return 1
+	}
+	if err := prepareDeployArtifacts(ctx, rootDir, *rebuild); err != nil {
+		fmt.Fprintln(os.Stderr, "error: pre-publish build failed:", err)
+		return 1
+	}
+
+	// The whole point of the coordinated path: never ship a bridge built
+	// against a different schema than the module being published.
+	hash, err := moduleSchemaHash(rootDir)
+	if err != nil {
+		return reportError(err)
+	}
+	if loadBindingRecords(rootDir)["bridge"] != hash {
+		fmt.Fprintln(os.Stderr, "error: bridge bindings do not match the module schema — run `lurelands bridge generate` and rebuild")
+		return 1
+	}
+
+	lastGood := lastGoodPath(rootDir, env)
+	canRollBackModule := fileExists(lastGood)
+
+	binPath := ""
+	if built := builtModulePath(rootDir); fileExists(built) {
+		binPath = built
+	}
+	if err := publishModule(ctx, rootDir, env, binPath); err != nil {
+		fmt.Fprintln(os.Stderr, "error: publish failed:", err)
+		return 1
+	}
+	fmt.Println("  module published")
+
+	if err := backupRemoteDist(ctx, target); err != nil {
+		// Worth flagging but not aborting: the deploy can still succeed,
+		// only the bridge half of a rollback would need doing by hand.
+		fmt.Fprintln(os.Stderr, "warning:", err)
+	}
+	if err := deployBridge(ctx, rootDir, target); err != nil {
+		fmt.Fprintln(os.Stderr, "error: bridge deploy failed:", err)
+		return rollBackPair(ctx, rootDir, env, target, canRollBackModule, lastGood)
+	}
+
+	if err := runSmokeSuite(rootDir, env); err != nil {
+		fmt.Fprintln(os.Stderr, "error: post-deploy smoke failed:", err)
+		return rollBackPair(ctx, rootDir, env, target, canRollBackModule, lastGood)
+	}
+
+	if built := builtModulePath(rootDir); fileExists(built) {
+		if err := storeAsBlob(rootDir, built, lastGood); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: could not record last-good build:", err)
+		}
+	}
+	writeDeployMeta(rootDir, env)
+	fmt.Printf("deployed module + bridge to %s; smoke suite passed\n", env.Name)
+	return 0
+}
+
+// rollBackPair republishes the previous module build and restores the
+// previous bridge dist, so the two sides stay version-matched even on
+// the way back down.
+func rollBackPair(ctx context.Context, rootDir string, env environment, target bridgeDeployTarget, canRollBackModule bool, lastGood string) int {
+	fmt.Printf("rolling %s back to the previous module + bridge pair…\n", env.Name)
+	if !canRollBackModule {
+		fmt.Fprintf(os.Stderr, "ALERT: no last-good module build recorded for %s — roll the module back by hand\n", env.Name)
+	} else if err := publishModule(ctx, rootDir, env, lastGood); err != nil {
+		fmt.Fprintln(os.Stderr, "ALERT: module rollback publish failed:", err)
+	} else {
+		fmt.Println("  previous module republished")
+	}
+	if err := restoreRemoteDist(ctx, target); err != nil {
+		fmt.Fprintln(os.Stderr, "ALERT: bridge rollback failed:", err)
+	} else {
+		fmt.Println("  previous bridge build restored and restarted")
+	}
+	return 1
+}
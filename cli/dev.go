@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// dev replaces the three-terminal workflow: the local spacetime server,
+// the bridge in dev mode, and `flutter run` all start together, their
+// output multiplexed with colored per-process prefixes. The whole group
+// lives and dies as one — when any process exits (or on ctrl+c) the
+// shared context is cancelled and the runner's process-group handling
+// takes the rest down with it. Services registered in .lurelands.toml
+// with a dev task join the lineup automatically.
+
+// devProcess is one member of the dev lineup. spec.Env holds only the
+// extra variables beyond the inherited environment; the launch paths
+// (multiplexed or tmux panes) merge or forward them as needed.
+type devProcess struct {
+	name string
+	spec runner.Spec
+}
+
+var devPrefixColors = []string{"39", "214", "78", "213", "208", "147"}
+
+func devProcesses(rootDir string, withApp bool, device string, env environment) []devProcess {
+	processes := []devProcess{
+		{name: "spacetime", spec: runner.Spec{
+			Name: "spacetime", Args: []string{"start"},
+		}},
+		{name: "bridge", spec: runner.Spec{
+			Name: "bun", Args: []string{"run", "dev"},
+			Dir: bridgeDir(rootDir),
+			Env: append(dotEnvPairs(bridgeDir(rootDir), env),
+				"LURELANDS_ENV="+env.Name, "LURELANDS_MODULE="+env.Module),
+		}},
+	}
+	for _, service := range registeredServices(rootDir) {
+		if service.Dev == "" || service.Name == "bridge" {
+			continue
+		}
+		fields := strings.Fields(service.Dev)
+		processes = append(processes, devProcess{name: service.Name, spec: runner.Spec{
+			Name: fields[0], Args: fields[1:], Dir: serviceDir(rootDir, service.Name),
+		}})
+	}
+	if withApp {
+		args := []string{"run"}
+		if device != "" {
+			args = append(args, "-d", device)
+		}
+		processes = append(processes, devProcess{name: "app", spec: runner.Spec{
+			Name: "flutter", Args: args, Dir: appDir(rootDir),
+			Env: dotEnvPairs(appDir(rootDir), env),
+		}})
+	}
+	return processes
+}
+
+func runDev(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("dev", flag.ContinueOnError)
+	noApp := flags.Bool("no-app", false, "skip flutter run (stack only)")
+	device := flags.String("device", "", "device id for flutter run")
+	envName := flags.String("env", "", "environment for the bridge")
+	killPort := flags.Bool("kill-port", false, "terminate whatever holds the stack's ports first")
+	panes := flags.Bool("panes", false, "launch each service in its own tmux pane")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	if !ensurePortFree(rootDir, defaultSpacetimePort, "spacetime", *killPort) ||
+		!ensurePortFree(rootDir, defaultBridgePort, "bridge", *killPort) {
+		return 1
+	}
+
+	processes := devProcesses(rootDir, !*noApp, *device, env)
+	for _, process := range processes {
+		if _, ok := lookupTool(rootDir, process.spec.Name); !ok {
+			return reportError(errToolNotFound(process.spec.Name, "running "+process.name+" in dev mode"))
+		}
+	}
+
+	if *panes {
+		// pinnedSpec applies per pane so toolchain pins hold there too.
+		for i := range processes {
+			processes[i].spec = pinnedSpec(rootDir, processes[i].spec)
+		}
+		return runDevPanes(rootDir, processes, loadConfigSections(rootDir)["dev"]["layout"])
+	}
+
+	// One cancel for the group: first exit (or ctrl+c via parent ctx)
+	// brings everything down.
+	groupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	width := 0
+	for _, process := range processes {
+		if len(process.name) > width {
+			width = len(process.name)
+		}
+	}
+
+	var outMu sync.Mutex
+	var wg sync.WaitGroup
+	exitCodes := make([]int, len(processes))
+	fmt.Printf("dev: starting %d processes — ctrl+c stops everything\n", len(processes))
+	for i, process := range processes {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(devPrefixColors[i%len(devPrefixColors)]))
+		prefix := style.Render(fmt.Sprintf("%-*s |", width, process.name))
+		wg.Add(1)
+		go func(i int, process devProcess) {
+			defer wg.Done()
+			// The runner appends spec.Env to the parent environment itself.
+			spec := pinnedSpec(rootDir, process.spec)
+			result, _ := runner.RunStreaming(groupCtx, spec, func(line string, _ bool) {
+				feedErrorLine(rootDir, process.name, line)
+				outMu.Lock()
+				fmt.Println(prefix, line)
+				outMu.Unlock()
+			})
+			exitCodes[i] = result.ExitCode
+			outMu.Lock()
+			fmt.Printf("%s exited (%d) — stopping the rest\n", prefix, result.ExitCode)
+			outMu.Unlock()
+			cancel()
+		}(i, process)
+	}
+	wg.Wait()
+
+	// ctrl+c is a clean stop; a process dying on its own is not.
+	if ctx.Err() != nil {
+		return exitCancelled
+	}
+	for _, code := range exitCodes {
+		if code != 0 {
+			return code
+		}
+	}
+	return 0
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Device discovery. `flutter devices` takes several seconds, which is
+// far too slow to sit on a picker's critical path, so results are
+// cached with a short TTL and refreshed in the background: callers get
+// the cached list instantly plus a channel that delivers the fresh list
+// when the probe finishes. The `devices` command is the human view of
+// the same cache.
+
+const deviceCacheTTL = 60 * time.Second
+
+type device struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Platform string `json:"targetPlatform"`
+	Emulator bool   `json:"emulator"`
+}
+
+type deviceCache struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Devices   []device  `json:"devices"`
+}
+
+func deviceCachePath(rootDir string) string {
+	return filepath.Join(cacheDir(rootDir), "devices.json")
+}
+
+func loadDeviceCache(rootDir string) *deviceCache {
+	cache := &deviceCache{}
+	data, err := os.ReadFile(deviceCachePath(rootDir))
+	if err == nil {
+		json.Unmarshal(data, cache)
+	}
+	return cache
+}
+
+func saveDeviceCache(rootDir string, devices []device) {
+	os.MkdirAll(cacheDir(rootDir), 0o755)
+	data, _ := json.MarshalIndent(deviceCache{FetchedAt: time.Now(), Devices: devices}, "", "  ")
+	os.WriteFile(deviceCachePath(rootDir), append(data, '\n'), 0o644)
+}
+
+// fetchDevices asks flutter, synchronously.
+func fetchDevices(ctx context.Context, rootDir string) ([]device, error) {
+	result, err := runner.Run(ctx, runner.Spec{
+		Name: "flutter",
+		Args: []string{"devices", "--machine"},
+		Dir:  appDir(rootDir),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("flutter devices: %w", err)
+	}
+	var devices []device
+	if err := json.Unmarshal([]byte(result.Output), &devices); err != nil {
+		return nil, fmt.Errorf("parsing flutter devices output: %w", err)
+	}
+	saveDeviceCache(rootDir, devices)
+	return devices, nil
+}
+
+// listDevices returns the cached device list (possibly stale, possibly
+// empty) plus a channel that delivers the refreshed list when the
+// background probe completes — nil if the cache was fresh and no probe
+// was needed. TUIs show the cached list immediately with a refreshing
+// indicator until the channel fires.
+func listDevices(ctx context.Context, rootDir string) ([]device, <-chan []device) {
+	cache := loadDeviceCache(rootDir)
+	if time.Since(cache.FetchedAt) < deviceCacheTTL {
+		return cache.Devices, nil
+	}
+	fresh := make(chan []device, 1)
+	go func() {
+		devices, err := fetchDevices(ctx, rootDir)
+		if err != nil {
+			devices = cache.Devices // keep showing the stale list
+		}
+		fresh <- devices
+	}()
+	return cache.Devices, fresh
+}
+
+func runDevices(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("devices", flag.ContinueOnError)
+	refresh := flags.Bool("refresh", false, "ignore the cache and probe now")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	var devices []device
+	if *refresh {
+		fetched, err := fetchDevices(ctx, rootDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		devices = fetched
+	} else {
+		cached, fresh := listDevices(ctx, rootDir)
+		devices = cached
+		if fresh != nil {
+			if len(cached) > 0 {
+				fmt.Printf("(cached, refreshing…)\n")
+			}
+			devices = <-fresh
+		}
+	}
+
+	if len(devices) == 0 {
+		fmt.Println("no devices found")
+		return 0
+	}
+	for _, d := range devices {
+		kind := "device"
+		if d.Emulator {
+			kind = "emulator"
+		}
+		fmt.Printf("  %-28s %-24s %s\n", d.Name, d.ID, kind+" ("+d.Platform+")")
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// The docker commands are the zero-toolchain path: contributors without
+// bun or spacetime installed get the whole local stack in containers.
+// `docker build` bakes the bridge into a real image (compose mounts the
+// source instead, which needs bun's deps on the host), and `docker up`
+// generates the compose file on first use so the happy path is exactly
+// one command. The menu header shows how many stack containers are up.
+
+const bridgeImageTag = "lurelands-bridge:dev"
+
+func bridgeDockerfilePath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "compose", "bridge.Dockerfile")
+}
+
+const bridgeDockerfile = `# Generated by lurelands docker build — do not edit by hand.
+FROM oven/bun:1
+WORKDIR /bridge
+COPY package.json ./
+RUN bun install --production
+COPY . .
+RUN bun run build
+CMD ["bun", "run", "start"]
+`
+
+func ensureBridgeDockerfile(rootDir string) error {
+	path := bridgeDockerfilePath(rootDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(bridgeDockerfile), 0o644)
+}
+
+func runDockerBuild(ctx context.Context, rootDir string, args []string) int {
+	if _, ok := lookupTool(rootDir, "docker"); !ok {
+		return reportError(errToolNotFound("docker", "building the bridge image"))
+	}
+	if err := ensureBridgeDockerfile(rootDir); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	result, err := runWithProgress(ctx, "bridge image", "docker", runner.Spec{
+		Name: "docker",
+		Args: []string{"build", "-f", bridgeDockerfilePath(rootDir), "-t", bridgeImageTag, bridgeDir(rootDir)},
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: docker build: %v\n%s", err, summarizeFailure(result.Output, 15))
+		return 1
+	}
+	fmt.Println("built", bridgeImageTag)
+	return 0
+}
+
+func runDockerUp(ctx context.Context, rootDir string, args []string) int {
+	if !fileExists(composeFilePath(rootDir)) {
+		if err := writeComposeFile(rootDir, false); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Println("generated", composeFilePath(rootDir))
+	}
+	if code := runComposeUp(ctx, rootDir, args); code != 0 {
+		return code
+	}
+	if running, ok := dockerStackStatus(rootDir); ok {
+		fmt.Printf("stack up — %d container(s) running (bridge on :8080, spacetime on :3000)\n", running)
+	}
+	return 0
+}
+
+func runDockerDown(ctx context.Context, rootDir string, args []string) int {
+	return runComposeDown(ctx, rootDir, args)
+}
+
+// dockerStackStatus counts running containers in the generated stack.
+// Anything that stops it answering (no docker, no compose file, daemon
+// down) just means no status.
+func dockerStackStatus(rootDir string) (int, bool) {
+	if !fileExists(composeFilePath(rootDir)) {
+		return 0, false
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		return 0, false
+	}
+	out, err := exec.Command("docker", "compose", "-f", composeFilePath(rootDir),
+		"ps", "--services", "--status", "running").Output()
+	if err != nil {
+		return 0, false
+	}
+	services := strings.Fields(strings.TrimSpace(string(out)))
+	return len(services), true
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// docs serve renders what designers and client devs keep asking for —
+// current content definitions, the content schemas, and the bridge's
+// route surface — as a small local website with client-side search.
+// Everything is rendered fresh per request from the working tree, so
+// the site is always as current as the checkout; there is no build
+// step and nothing to deploy.
+
+// bridgeRoutePattern matches the Bun/Express-style route registrations
+// in the bridge source: app.get("/health", …), router.post('/cast', …).
+var bridgeRoutePattern = regexp.MustCompile(`\.(get|post|put|delete|ws)\(\s*["']([^"']+)["']`)
+
+type bridgeRoute struct {
+	Method string
+	Path   string
+	File   string
+}
+
+func scanBridgeRoutes(rootDir string) []bridgeRoute {
+	var routes []bridgeRoute
+	filepath.Walk(filepath.Join(bridgeDir(rootDir), "src"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".ts") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(rootDir, path)
+		for _, match := range bridgeRoutePattern.FindAllStringSubmatch(string(data), -1) {
+			routes = append(routes, bridgeRoute{
+				Method: strings.ToUpper(match[1]),
+				Path:   match[2],
+				File:   rel,
+			})
+		}
+		return nil
+	})
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Path < routes[j].Path })
+	return routes
+}
+
+const docsPageShell = `<!doctype html>
+<html><head><meta charset="utf-8"><title>lurelands docs</title>
+<style>
+  body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; padding: 0 1rem; }
+  input { width: 100%%; padding: .5rem; font-size: 1rem; margin-bottom: 1.5rem; }
+  table { border-collapse: collapse; width: 100%%; margin-bottom: 2rem; }
+  th, td { text-align: left; padding: .25rem .6rem; border-bottom: 1px solid #ddd; }
+  h2 { margin-top: 2.5rem; }
+  .hidden { display: none; }
+  code { background: #f4f4f4; padding: 0 .25rem; }
+</style></head><body>
+<h1>lurelands docs</h1>
+<input id="search" placeholder="search everything…" autofocus>
+%s
+<script>
+  const input = document.getElementById('search');
+  input.addEventListener('input', () => {
+    const q = input.value.toLowerCase();
+    document.querySelectorAll('tbody tr').forEach(row => {
+      row.classList.toggle('hidden', q && !row.textContent.toLowerCase().includes(q));
+    });
+  });
+</script>
+</body></html>`
+
+func docsTable(title string, headers []string, rows [][]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<h2>%s</h2>\n<table><thead><tr>", html.EscapeString(title))
+	for _, h := range headers {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(h))
+	}
+	b.WriteString("</tr></thead><tbody>\n")
+	for _, row := range rows {
+		b.WriteString("<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(&b, "<td>%s</td>", html.EscapeString(cell))
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody></table>\n")
+	return b.String()
+}
+
+func renderDocsPage(rootDir string) (string, error) {
+	set, err := loadContent(rootDir)
+	if err != nil {
+		return "", err
+	}
+
+	var sections []string
+
+	fishRows := make([][]string, 0, len(set.Fish))
+	for _, fish := range sortedFish(set) {
+		fishRows = append(fishRows, []string{fish.ID, fish.Name, fish.WaterType,
+			fmt.Sprintf("%d", fish.Tier),
+			fmt.Sprintf("%.1f–%.1f", fish.WeightMin, fish.WeightMax),
+			fmt.Sprintf("%d", fish.BasePrice)})
+	}
+	sections = append(sections, docsTable("Fish", []string{"id", "name", "water", "tier", "weight", "price"}, fishRows))
+
+	lureRows := make([][]string, 0, len(set.Lures))
+	for _, lure := range sortedLures(set) {
+		lureRows = append(lureRows, []string{lure.ID, lure.Name,
+			strings.Join(lure.WaterTypes, ", "),
+			fmt.Sprintf("%.2f", lure.BiteSpeedMultiplier),
+			fmt.Sprintf("%d", lure.Price)})
+	}
+	sections = append(sections, docsTable("Lures", []string{"id", "name", "water", "bite speed", "price"}, lureRows))
+
+	biomeRows := make([][]string, 0, len(set.Biomes))
+	for _, biome := range sortedBiomes(set) {
+		biomeRows = append(biomeRows, []string{biome.ID, biome.Name, biome.WaterType,
+			fmt.Sprintf("%d spawn entries", len(biome.SpawnTable))})
+	}
+	sections = append(sections, docsTable("Biomes", []string{"id", "name", "water", "spawns"}, biomeRows))
+
+	schemaRows := [][]string{}
+	schemas, _ := filepath.Glob(filepath.Join(contentDir(rootDir), "schemas", "*.json"))
+	for _, schema := range schemas {
+		rel, _ := filepath.Rel(rootDir, schema)
+		schemaRows = append(schemaRows, []string{filepath.Base(schema), rel})
+	}
+	sections = append(sections, docsTable("Content schemas", []string{"schema", "path"}, schemaRows))
+
+	routeRows := [][]string{}
+	for _, route := range scanBridgeRoutes(rootDir) {
+		routeRows = append(routeRows, []string{route.Method, route.Path, route.File})
+	}
+	sections = append(sections, docsTable("Bridge routes", []string{"method", "path", "defined in"}, routeRows))
+
+	return fmt.Sprintf(docsPageShell, strings.Join(sections, "\n")), nil
+}
+
+func runDocsServe(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("docs serve", flag.ContinueOnError)
+	port := flags.Int("port", 7788, "port to listen on (localhost only)")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		page, err := renderDocsPage(rootDir)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, page)
+	})
+
+	httpServer := &http.Server{Addr: fmt.Sprintf("127.0.0.1:%d", *port), Handler: mux}
+	go func() {
+		<-ctx.Done()
+		httpServer.Close()
+	}()
+
+	fmt.Printf("docs on http://%s — ctrl+c to stop\n", httpServer.Addr)
+	if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// doctor is the first command we tell new contributors to run: it
+// probes every tool the stack needs, compares versions against the
+// [toolchain] pins in .lurelands.toml when present, and checks that the
+// monorepo directories the CLI assumes actually exist. Each failure
+// comes with a remediation hint so "command not found" surprises happen
+// here, with instructions, instead of halfway through a build.
+
+type doctorCheck struct {
+	name string
+	ok   bool
+	note string // version found, or what is wrong
+	hint string // how to fix it; empty when ok
+}
+
+// doctorTools lists what we probe and how to install it. flutter
+// provides dart, rust provides cargo; we probe the user-facing names.
+var doctorTools = []struct {
+	name string
+	pin  string // [toolchain] key holding its minimum version
+	hint string
+}{
+	{name: "flutter", pin: "flutter", hint: "https://docs.flutter.dev/get-started/install (or `mise install flutter`)"},
+	{name: "dart", pin: "flutter", hint: "dart ships with flutter — fix the flutter install first"},
+	{name: "spacetime", pin: "spacetime", hint: "curl -sSf https://install.spacetimedb.com | sh"},
+	{name: "bun", pin: "bun", hint: "curl -fsSL https://bun.sh/install | bash"},
+	{name: "cargo", pin: "rust", hint: "https://rustup.rs (the module builds with cargo)"},
+	{name: "git", hint: "install git via your package manager"},
+}
+
+var versionPattern = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// toolVersion runs `name --version` and extracts the first x.y[.z] it
+// prints. flutter writes its banner to stderr, so both streams count.
+func toolVersion(ctx context.Context, rootDir, name string) string {
+	path, ok := lookupTool(rootDir, name)
+	if !ok {
+		return ""
+	}
+	result, _ := runner.Run(ctx, runner.Spec{Name: path, Args: []string{"--version"}})
+	return versionPattern.FindString(result.Output)
+}
+
+// versionAtLeast compares dotted versions numerically, part by part.
+// Missing parts count as zero, so "3.19" satisfies a "3.19.0" minimum.
+func versionAtLeast(found, minimum string) bool {
+	f := strings.Split(found, ".")
+	m := strings.Split(minimum, ".")
+	for i := 0; i < len(f) || i < len(m); i++ {
+		fv, mv := 0, 0
+		if i < len(f) {
+			fv, _ = strconv.Atoi(f[i])
+		}
+		if i < len(m) {
+			mv, _ = strconv.Atoi(m[i])
+		}
+		if fv != mv {
+			return fv > mv
+		}
+	}
+	return true
+}
+
+// doctorDirs are the layout assumptions baked into the rest of the CLI.
+var doctorDirs = []struct {
+	label string
+	dir   func(string) string
+}{
+	{label: "apps/lurelands (flutter app)", dir: appDir},
+	{label: "services/bridge", dir: bridgeDir},
+	{label: "services/spacetime-server", dir: moduleDir},
+	{label: "content/", dir: contentDir},
+}
+
+func doctorChecks(ctx context.Context, rootDir string) []doctorCheck {
+	pins := toolchainPins(rootDir)
+	var checks []doctorCheck
+
+	for _, tool := range doctorTools {
+		check := doctorCheck{name: tool.name, hint: tool.hint}
+		if _, ok := lookupTool(rootDir, tool.name); !ok {
+			check.note = "not found on PATH"
+			checks = append(checks, check)
+			continue
+		}
+		version := toolVersion(ctx, rootDir, tool.name)
+		minimum := pins[tool.pin]
+		switch {
+		case version == "":
+			check.ok = true
+			check.note = "installed (version not reported)"
+			check.hint = ""
+		case minimum != "" && !versionAtLeast(version, minimum):
+			check.note = fmt.Sprintf("%s installed, %s pinned in .lurelands.toml", version, minimum)
+			check.hint = "run `lurelands toolchain sync` to install the pinned versions"
+		default:
+			check.ok = true
+			check.note = version
+			check.hint = ""
+		}
+		checks = append(checks, check)
+	}
+
+	for _, entry := range doctorDirs {
+		check := doctorCheck{name: entry.label, ok: true, note: "present"}
+		if !fileExists(entry.dir(rootDir)) {
+			check.ok = false
+			check.note = "missing"
+			check.hint = "is " + rootDir + " really the monorepo root? (see `lurelands workspace`)"
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func runDoctor(ctx context.Context, rootDir string, args []string) int {
+	checks := doctorChecks(ctx, rootDir)
+	failed := 0
+	for _, check := range checks {
+		mark := "ok"
+		if !check.ok {
+			mark = " !"
+			failed++
+		}
+		fmt.Printf("  %s  %-34s %s\n", mark, check.name, check.note)
+		if check.hint != "" {
+			fmt.Printf("       → %s\n", check.hint)
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("doctor: %d of %d checks failed\n", failed, len(checks))
+		return 1
+	}
+	fmt.Printf("doctor: all %d checks passed\n", len(checks))
+	return 0
+}
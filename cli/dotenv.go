@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Per-service .env files. The bridge and the Flutter app each keep a
+// .env next to their sources, with optional .env.<environment> overlays
+// on top; commands that spawn those services load the merged set and
+// inject it, so nobody exports a dozen variables by hand before
+// `lurelands dev`. `env list/set/unset` edit the files from the CLI,
+// and values under secret-looking keys are masked in listings and
+// registered with the runner's redaction list.
+
+// dotEnvServices maps the --service names to their directories. Only
+// services we spawn ourselves are here; registered custom services keep
+// managing their own environment.
+func dotEnvServices(rootDir string) map[string]string {
+	return map[string]string{
+		"bridge": bridgeDir(rootDir),
+		"app":    appDir(rootDir),
+	}
+}
+
+var secretKeyPattern = regexp.MustCompile(`(?i)(secret|token|password|passwd|credential|api_?key|private)`)
+
+// parseDotEnv implements the usual subset: KEY=VALUE lines, # comments,
+// an optional `export ` prefix, and single or double quotes around the
+// value. Multi-line values are out of scope, like in the config parser.
+func parseDotEnv(data string) map[string]string {
+	vars := map[string]string{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "export "))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	return vars
+}
+
+func dotEnvFile(dir, envName string) string {
+	if envName == "" {
+		return filepath.Join(dir, ".env")
+	}
+	return filepath.Join(dir, ".env."+envName)
+}
+
+// loadDotEnv merges a service's .env with its .env.<environment>
+// overlay; the overlay wins key by key.
+func loadDotEnv(dir, envName string) map[string]string {
+	vars := map[string]string{}
+	for _, path := range []string{dotEnvFile(dir, ""), dotEnvFile(dir, envName)} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for key, value := range parseDotEnv(string(data)) {
+			vars[key] = value
+		}
+	}
+	return vars
+}
+
+// dotEnvPairs renders the merged set as KEY=VALUE pairs for a spec's
+// Env, sorted for stable output, with secret-looking values redacted
+// from any captured logs.
+func dotEnvPairs(dir string, env environment) []string {
+	vars := loadDotEnv(dir, env.Name)
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if secretKeyPattern.MatchString(key) && vars[key] != "" {
+			runner.Redact(vars[key])
+		}
+		pairs = append(pairs, key+"="+vars[key])
+	}
+	return pairs
+}
+
+func maskDotEnvValue(key, value string) string {
+	if value != "" && secretKeyPattern.MatchString(key) {
+		return "•••••"
+	}
+	return value
+}
+
+// setDotEnvKey rewrites path with key set, editing the existing line in
+// place so comments and ordering survive; new keys append.
+func setDotEnvKey(path, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(data) == 0 {
+		lines = nil
+	}
+	replaced := false
+	for i, line := range lines {
+		existing, _, ok := strings.Cut(strings.TrimPrefix(strings.TrimSpace(line), "export "), "=")
+		if ok && strings.TrimSpace(existing) == key {
+			lines[i] = key + "=" + value
+			replaced = true
+		}
+	}
+	if !replaced {
+		lines = append(lines, key+"="+value)
+	}
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600)
+}
+
+func unsetDotEnvKey(path, key string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	var kept []string
+	removed := false
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		existing, _, ok := strings.Cut(strings.TrimPrefix(strings.TrimSpace(line), "export "), "=")
+		if ok && strings.TrimSpace(existing) == key {
+			removed = true
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if !removed {
+		return false, nil
+	}
+	return true, os.WriteFile(path, []byte(strings.Join(kept, "\n")+"\n"), 0o600)
+}
+
+// dotEnvFlags is the shared --service/--env pair for list/set/unset.
+func dotEnvFlags(name string, args []string) (dir string, envName string, rest []string, code int) {
+	flags := flag.NewFlagSet("env "+name, flag.ContinueOnError)
+	service := flags.String("service", "", "which service's .env (bridge or app; list shows both)")
+	env := flags.String("env", "", "edit the .env.<name> overlay instead of the base file")
+	if err := flags.Parse(args); err != nil {
+		return "", "", nil, exitUsage
+	}
+	return *service, *env, flags.Args(), -1
+}
+
+func runEnvList(ctx context.Context, rootDir string, args []string) int {
+	service, envName, _, code := dotEnvFlags("list", args)
+	if code >= 0 {
+		return code
+	}
+	if envName == "" {
+		envName = currentEnvName
+	}
+	for _, name := range []string{"bridge", "app"} {
+		if service != "" && service != name {
+			continue
+		}
+		dir := dotEnvServices(rootDir)[name]
+		vars := loadDotEnv(dir, envName)
+		if len(vars) == 0 {
+			fmt.Printf("%s: no .env values (%s)\n", name, dotEnvFile(dir, ""))
+			continue
+		}
+		fmt.Printf("%s (.env", name)
+		if envName != "" {
+			fmt.Printf(" + .env.%s", envName)
+		}
+		fmt.Println("):")
+		keys := make([]string, 0, len(vars))
+		for key := range vars {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("  %s=%s\n", key, maskDotEnvValue(key, vars[key]))
+		}
+	}
+	return 0
+}
+
+func runEnvSet(ctx context.Context, rootDir string, args []string) int {
+	service, envName, rest, code := dotEnvFlags("set", args)
+	if code >= 0 {
+		return code
+	}
+	if service == "" {
+		service = "bridge"
+	}
+	dir, ok := dotEnvServices(rootDir)[service]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "error: unknown service %q (bridge or app)\n", service)
+		return exitUsage
+	}
+	if len(rest) != 1 || !strings.Contains(rest[0], "=") {
+		fmt.Fprintln(os.Stderr, "usage: lurelands env set [--service bridge|app] [--env <name>] KEY=VALUE")
+		return exitUsage
+	}
+	key, value, _ := strings.Cut(rest[0], "=")
+	path := dotEnvFile(dir, envName)
+	if err := setDotEnvKey(path, key, value); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Printf("set %s in %s\n", key, path)
+	return 0
+}
+
+func runEnvUnset(ctx context.Context, rootDir string, args []string) int {
+	service, envName, rest, code := dotEnvFlags("unset", args)
+	if code >= 0 {
+		return code
+	}
+	if service == "" {
+		service = "bridge"
+	}
+	dir, ok := dotEnvServices(rootDir)[service]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "error: unknown service %q (bridge or app)\n", service)
+		return exitUsage
+	}
+	if len(rest) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands env unset [--service bridge|app] [--env <name>] KEY")
+		return exitUsage
+	}
+	path := dotEnvFile(dir, envName)
+	removed, err := unsetDotEnvKey(path, rest[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if !removed {
+		fmt.Fprintf(os.Stderr, "%s is not set in %s\n", rest[0], path)
+		return 1
+	}
+	fmt.Printf("unset %s in %s\n", rest[0], path)
+	return 0
+}
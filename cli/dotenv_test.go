@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	vars := parseDotEnv(`# comment
+export API_URL=http://localhost:8080
+BRIDGE_TOKEN="abc 123"
+EMPTY=
+not a pair
+QUOTED='single'
+`)
+	want := map[string]string{
+		"API_URL":      "http://localhost:8080",
+		"BRIDGE_TOKEN": "abc 123",
+		"EMPTY":        "",
+		"QUOTED":       "single",
+	}
+	if len(vars) != len(want) {
+		t.Fatalf("parsed %d vars, want %d: %v", len(vars), len(want), vars)
+	}
+	for key, value := range want {
+		if vars[key] != value {
+			t.Errorf("%s = %q, want %q", key, vars[key], value)
+		}
+	}
+}
+
+func TestLoadDotEnvOverlayWins(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, ".env"), []byte("A=base\nB=base\n"), 0o600)
+	os.WriteFile(filepath.Join(dir, ".env.staging"), []byte("B=staging\n"), 0o600)
+
+	vars := loadDotEnv(dir, "staging")
+	if vars["A"] != "base" || vars["B"] != "staging" {
+		t.Errorf("merged vars = %v", vars)
+	}
+}
+
+func TestSetDotEnvKeyPreservesComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(path, []byte("# bridge settings\nA=1\nB=2\n"), 0o600)
+
+	if err := setDotEnvKey(path, "A", "updated"); err != nil {
+		t.Fatal(err)
+	}
+	if err := setDotEnvKey(path, "C", "new"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "# bridge settings\nA=updated\nB=2\nC=new\n" {
+		t.Errorf("file after set:\n%s", data)
+	}
+
+	removed, err := unsetDotEnvKey(path, "B")
+	if err != nil || !removed {
+		t.Fatalf("unset B: removed=%v err=%v", removed, err)
+	}
+	data, _ = os.ReadFile(path)
+	if string(data) != "# bridge settings\nA=updated\nC=new\n" {
+		t.Errorf("file after unset:\n%s", data)
+	}
+}
+
+func TestMaskDotEnvValue(t *testing.T) {
+	if got := maskDotEnvValue("SENTRY_TOKEN", "s3cret"); got != "•••••" {
+		t.Errorf("secret key not masked: %q", got)
+	}
+	if got := maskDotEnvValue("API_URL", "http://x"); got != "http://x" {
+		t.Errorf("plain key masked: %q", got)
+	}
+}
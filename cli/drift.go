@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// drift answers "is what's running actually what this checkout says
+// should be running". It compares, per environment: the module schema
+// hash recorded at the last deploy against the current sources, the
+// content version table on the server against content/version.json,
+// and (where the env runs in k8s) the bridge image in the cluster
+// against the expected tag. Deploys write a small metadata file after
+// smoke passes so drift has ground truth for what went out.
+
+type deployMeta struct {
+	SchemaHash string    `json:"schema_hash"`
+	GitRef     string    `json:"git_ref"`
+	DeployedAt time.Time `json:"deployed_at"`
+}
+
+func deployMetaPath(rootDir string, env environment) string {
+	return filepath.Join(deploysDir(rootDir), env.Name+"-meta.json")
+}
+
+func writeDeployMeta(rootDir string, env environment) {
+	hash, err := moduleSchemaHash(rootDir)
+	if err != nil {
+		return
+	}
+	ref := ""
+	if out, err := exec.Command("git", "-C", rootDir, "rev-parse", "--short", "HEAD").Output(); err == nil {
+		ref = strings.TrimSpace(string(out))
+	}
+	meta := deployMeta{SchemaHash: hash, GitRef: ref, DeployedAt: time.Now()}
+	data, _ := json.MarshalIndent(meta, "", "  ")
+	os.MkdirAll(deploysDir(rootDir), 0o755)
+	os.WriteFile(deployMetaPath(rootDir, env), data, 0o644)
+}
+
+type driftCheck struct {
+	name     string
+	expected string
+	actual   string
+	err      error
+}
+
+func (c driftCheck) drifted() bool {
+	return c.err == nil && c.expected != c.actual
+}
+
+func checkModuleDrift(rootDir string, env environment) driftCheck {
+	check := driftCheck{name: "module schema"}
+	hash, err := moduleSchemaHash(rootDir)
+	if err != nil {
+		check.err = err
+		return check
+	}
+	check.expected = hash[:12]
+	data, err := os.ReadFile(deployMetaPath(rootDir, env))
+	if err != nil {
+		check.err = fmt.Errorf("no deploy metadata for %s (deploy at least once from this machine)", env.Name)
+		return check
+	}
+	var meta deployMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		check.err = err
+		return check
+	}
+	check.actual = meta.SchemaHash[:12]
+	return check
+}
+
+func checkContentDrift(ctx context.Context, rootDir string, env environment) driftCheck {
+	check := driftCheck{name: "content version"}
+	data, err := os.ReadFile(filepath.Join(contentDir(rootDir), "version.json"))
+	if err != nil {
+		check.err = err
+		return check
+	}
+	var local struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &local); err != nil {
+		check.err = err
+		return check
+	}
+	check.expected = local.Version
+
+	result, err := runner.Run(ctx, runner.Spec{
+		Name: "spacetime",
+		Args: []string{"sql", "--server", env.Server, env.Module,
+			"SELECT version FROM content_version LIMIT 1", "--json"},
+	})
+	if err != nil {
+		check.err = fmt.Errorf("querying deployed content version: %w", err)
+		return check
+	}
+	var rows []struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(result.Output), &rows); err != nil || len(rows) == 0 {
+		check.err = fmt.Errorf("no content_version row on %s", env.Name)
+		return check
+	}
+	check.actual = rows[0].Version
+	return check
+}
+
+func checkBridgeImageDrift(ctx context.Context, rootDir string, env environment) driftCheck {
+	check := driftCheck{name: "bridge image"}
+	values, ok := k8sEnvValues[env.Name]
+	if !ok {
+		check.err = fmt.Errorf("%s does not run in k8s", env.Name)
+		return check
+	}
+	check.expected = values.Image
+	if _, ok := lookupTool(rootDir, "kubectl"); !ok {
+		check.err = errToolNotFound("kubectl", "inspecting the cluster")
+		return check
+	}
+	result, err := runner.Run(ctx, runner.Spec{
+		Name: "kubectl",
+		Args: []string{"--context", values.Context, "get", "deployment", "bridge",
+			"-o", "jsonpath={.spec.template.spec.containers[0].image}"},
+	})
+	if err != nil {
+		check.err = fmt.Errorf("reading deployed image: %w", err)
+		return check
+	}
+	check.actual = strings.TrimSpace(result.Output)
+	return check
+}
+
+func runDrift(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("drift", flag.ContinueOnError)
+	envName := flags.String("env", "", "environment to check")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "staging"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	checks := []driftCheck{
+		checkModuleDrift(rootDir, env),
+		checkContentDrift(ctx, rootDir, env),
+		checkBridgeImageDrift(ctx, rootDir, env),
+	}
+
+	drifted := 0
+	for _, check := range checks {
+		switch {
+		case check.err != nil:
+			fmt.Printf("  %-16s ?  %v\n", check.name, check.err)
+		case check.drifted():
+			drifted++
+			fmt.Printf("  %-16s !  expected %s, deployed %s\n", check.name, check.expected, check.actual)
+		default:
+			fmt.Printf("  %-16s ok %s\n", check.name, check.actual)
+		}
+	}
+	if drifted > 0 {
+		fmt.Printf("\n%s has drifted in %d place(s)\n", env.Name, drifted)
+		return 1
+	}
+	fmt.Printf("\n%s matches this checkout\n", env.Name)
+	return 0
+}
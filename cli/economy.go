@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Economy report: estimates coins-per-hour per biome and pole tier from the
+// content data, and flags spots where the progression curve breaks (a better
+// pole earning less, or a pole that never pays for itself).
+
+type PoleDef struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Tier  int    `json:"tier"`
+	Price int    `json:"price"`
+}
+
+// Landing-rate model: chance the minigame is won once a fish bites, by fish
+// tier, plus a flat bonus per pole tier. This approximates the minigame
+// constants in apps/lurelands/lib/utils/constants.dart (bar size, gravity
+// bonuses) — good enough for tuning, not a physics sim.
+var (
+	landRateByFishTier = [4]float64{0.95, 0.85, 0.70, 0.50}
+	landBonusByPole    = [4]float64{0.0, 0.05, 0.10, 0.15}
+)
+
+// Star-rarity distribution when a fish is caught (1/2/3 stars) and the
+// matching sell multipliers from ItemDefinition.getSellPrice.
+var (
+	starChance     = [3]float64{0.70, 0.25, 0.05}
+	starMultiplier = [3]float64{1.0, 2.0, 4.0}
+)
+
+func loadPoles(rootDir string) ([]PoleDef, error) {
+	var poleFile struct {
+		Poles []PoleDef `json:"poles"`
+	}
+	if err := readJSONFile(filepath.Join(contentDir(rootDir), "poles.json"), &poleFile); err != nil {
+		return nil, err
+	}
+	return poleFile.Poles, nil
+}
+
+func landRate(fishTier, poleTier int) float64 {
+	rate := landRateByFishTier[clampTier(fishTier)-1] + landBonusByPole[clampTier(poleTier)-1]
+	if rate > 0.99 {
+		rate = 0.99
+	}
+	return rate
+}
+
+func clampTier(tier int) int {
+	if tier < 1 {
+		return 1
+	}
+	if tier > 4 {
+		return 4
+	}
+	return tier
+}
+
+// avgSellPrice is the expected coins from selling one catch of this fish,
+// averaged over the star-rarity distribution.
+func avgSellPrice(fish *FishDef) float64 {
+	total := 0.0
+	for i := range starChance {
+		total += starChance[i] * float64(fish.BasePrice) * starMultiplier[i]
+	}
+	return total
+}
+
+// coinsPerHour estimates earnings fishing a biome with a pole tier.
+func coinsPerHour(set *ContentSet, biome *BiomeDef, poleTier int) float64 {
+	rates := normalizedRates(effectiveWeights(set, biome, nil))
+	perCast := 0.0
+	for id, p := range rates {
+		fish := findFish(set, id)
+		if fish == nil {
+			continue
+		}
+		perCast += p * landRate(fish.Tier, poleTier) * avgSellPrice(fish)
+	}
+	return perCast * 3600 / castCycleSeconds
+}
+
+func runEconomyReport(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("economy:report", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	set, err := loadContent(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	poles, err := loadPoles(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	fmt.Println("Economy report: estimated coins/hour")
+	fmt.Println()
+	fmt.Printf("%-20s", "BIOME")
+	for _, pole := range poles {
+		fmt.Printf(" %14s", fmt.Sprintf("T%d %s", pole.Tier, shorten(pole.Name, 10)))
+	}
+	fmt.Println()
+
+	earnings := map[string][]float64{}
+	for i := range set.Biomes {
+		biome := &set.Biomes[i]
+		fmt.Printf("%-20s", biome.Name)
+		for _, pole := range poles {
+			cph := coinsPerHour(set, biome, pole.Tier)
+			earnings[biome.ID] = append(earnings[biome.ID], cph)
+			fmt.Printf(" %13.0fc", cph)
+		}
+		fmt.Println()
+	}
+
+	// Progression checks: a better pole should always earn more in the same
+	// biome, and each pole upgrade should pay for itself in reasonable time.
+	const maxPaybackHours = 10.0
+	var flagsFound []string
+	for i := range set.Biomes {
+		biome := &set.Biomes[i]
+		rows := earnings[biome.ID]
+		for p := 1; p < len(rows); p++ {
+			delta := rows[p] - rows[p-1]
+			if delta <= 0 {
+				flagsFound = append(flagsFound, fmt.Sprintf(
+					"%s: %s earns no more than %s (%.0fc/h vs %.0fc/h)",
+					biome.Name, poles[p].Name, poles[p-1].Name, rows[p], rows[p-1]))
+				continue
+			}
+			payback := float64(poles[p].Price) / delta
+			if payback > maxPaybackHours {
+				flagsFound = append(flagsFound, fmt.Sprintf(
+					"%s: %s takes %.1fh to pay for itself (+%.0fc/h for %dc)",
+					biome.Name, poles[p].Name, payback, delta, poles[p].Price))
+			}
+		}
+	}
+
+	fmt.Println()
+	if len(flagsFound) == 0 {
+		fmt.Println("progression: no outliers flagged")
+		return 0
+	}
+	fmt.Printf("progression: %d outlier(s) flagged\n", len(flagsFound))
+	for _, f := range flagsFound {
+		fmt.Println("  !", f)
+	}
+	return 1
+}
+
+func shorten(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// The env command manages the sticky current environment: `env` lists
+// what is configured (built-ins plus [env.*] sections), `env use`
+// persists a selection to .lurelands/state.json so commands run without
+// --env target it. See envOrCurrent for the precedence.
+
+func runEnvShow(ctx context.Context, rootDir string, args []string) int {
+	for _, name := range environmentNames() {
+		env := environments[name]
+		marker := " "
+		if name == currentEnvName {
+			marker = "*"
+		}
+		fmt.Printf("  %s %-10s server=%-10s module=%s\n", marker, name, env.Server, env.Module)
+	}
+	if currentEnvName == "" {
+		fmt.Println("no current environment set — commands use their own defaults (`env use <name>` to pin one)")
+	}
+	return 0
+}
+
+func runEnvUse(ctx context.Context, rootDir string, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands env use <name>")
+		return exitUsage
+	}
+	env, err := resolveEnvironment(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return exitUsage
+	}
+	state := loadUserState(rootDir)
+	state.CurrentEnv = env.Name
+	saveUserState(rootDir, state)
+	fmt.Printf("current environment: %s (server=%s module=%s)\n", env.Name, env.Server, env.Module)
+	return 0
+}
+
+func runEnvClear(ctx context.Context, rootDir string, args []string) int {
+	state := loadUserState(rootDir)
+	state.CurrentEnv = ""
+	saveUserState(rootDir, state)
+	fmt.Println("current environment cleared — commands use their own defaults")
+	return 0
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// Deploy environments the CLI can talk to. The spacetime CLI resolves the
+// server names; module names follow the <module>-<env> convention except
+// prod, which keeps the bare name players connect to.
+type environment struct {
+	Name   string
+	Server string
+	Module string
+	Health string // bridge health URL override, optional
+}
+
+var environments = map[string]environment{
+	"local":   {Name: "local", Server: "local", Module: "lurelands"},
+	"staging": {Name: "staging", Server: "maincloud", Module: "lurelands-staging"},
+	"prod":    {Name: "prod", Server: "maincloud", Module: "lurelands"},
+}
+
+// currentEnvName is the persistent "current environment" from
+// .lurelands/state.json, set by `lurelands env use`. It fills in for an
+// omitted --env flag; an explicit flag always wins. Loaded once per
+// invocation by mergeEnvironments.
+var currentEnvName string
+
+// mergeEnvironments grafts [env.<name>] sections from .lurelands.toml
+// onto the built-in environment set (same override-or-extend semantics
+// as custom commands) and loads the sticky current environment:
+//
+//	[env.dev2]
+//	server = "maincloud"
+//	module = "lurelands-dev2"
+//	health = "https://bridge-dev2.lurelands.com/health"
+func mergeEnvironments(rootDir string) {
+	for section, values := range loadConfigSections(rootDir) {
+		name, ok := strings.CutPrefix(section, "env.")
+		if !ok {
+			continue
+		}
+		env := environment{Name: name, Server: values["server"], Module: values["module"], Health: values["health"]}
+		if env.Server == "" || env.Module == "" {
+			fmt.Printf("warning: [env.%s] needs both server and module — ignoring\n", name)
+			continue
+		}
+		environments[name] = env
+	}
+	currentEnvName = loadUserState(rootDir).CurrentEnv
+}
+
+// envOrCurrent picks the environment name for a command: the flag if
+// given, else the sticky current environment, else the command's own
+// historical default.
+func envOrCurrent(flagValue, fallback string) string {
+	if flagValue != "" {
+		slog.Info("resolved environment", "env", flagValue, "source", "--env flag")
+		return flagValue
+	}
+	if currentEnvName != "" {
+		slog.Info("resolved environment", "env", currentEnvName, "source", "pinned via env use")
+		return currentEnvName
+	}
+	slog.Info("resolved environment", "env", fallback, "source", "command default")
+	return fallback
+}
+
+func environmentNames() []string {
+	names := make([]string, 0, len(environments))
+	for name := range environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func resolveEnvironment(name string) (environment, error) {
+	env, ok := environments[name]
+	if !ok {
+		return environment{}, fmt.Errorf("unknown environment %q (expected %s)",
+			name, strings.Join(environmentNames(), ", "))
+	}
+	return env, nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The error feed catches failures that would otherwise scroll past:
+// while dev (or bridge start, or anything running under bg) streams
+// output, lines that look like panics, unhandled exceptions, or
+// reducer errors are copied into .lurelands/errors.json with their
+// source service and timestamp. `lurelands errors` lists the recent
+// feed; --watch keeps a live view open next to a dev session.
+
+const errorFeedLimit = 200
+
+type errorEvent struct {
+	Service string    `json:"service"`
+	Line    string    `json:"line"`
+	At      time.Time `json:"at"`
+}
+
+// errorFeedPatterns is deliberately conservative — a feed that catches
+// every line containing "error" is as useless as no feed.
+var errorFeedPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`panic(:|ked at)`),
+	regexp.MustCompile(`Unhandled exception`),
+	regexp.MustCompile(`(?i)uncaught (error|exception)`),
+	regexp.MustCompile(`(?i)reducer \S+ (failed|panicked|errored)`),
+	regexp.MustCompile(`(?i)^\s*(error|fatal)[:\s]`),
+}
+
+func errorFeedPath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "errors.json")
+}
+
+func loadErrorFeed(rootDir string) []errorEvent {
+	var feed []errorEvent
+	data, err := os.ReadFile(errorFeedPath(rootDir))
+	if err == nil {
+		json.Unmarshal(data, &feed)
+	}
+	return feed
+}
+
+// errorFeedMu serializes appends — the dev multiplexer feeds from one
+// goroutine per process.
+var errorFeedMu sync.Mutex
+
+// feedErrorLine records line in the feed if it matches an error
+// signature. Safe to call from streaming sinks; non-matching lines
+// (the overwhelming majority) cost one regexp scan and no I/O.
+func feedErrorLine(rootDir, service, line string) {
+	matched := false
+	for _, pattern := range errorFeedPatterns {
+		if pattern.MatchString(line) {
+			matched = true
+			break
+		}
+	}
+	if !matched || rootDir == "" {
+		return
+	}
+	errorFeedMu.Lock()
+	defer errorFeedMu.Unlock()
+	feed := append(loadErrorFeed(rootDir), errorEvent{Service: service, Line: line, At: time.Now()})
+	if len(feed) > errorFeedLimit {
+		feed = feed[len(feed)-errorFeedLimit:]
+	}
+	os.MkdirAll(filepath.Dir(errorFeedPath(rootDir)), 0o755)
+	data, _ := json.MarshalIndent(feed, "", "  ")
+	os.WriteFile(errorFeedPath(rootDir), append(data, '\n'), 0o644)
+}
+
+func printErrorFeed(feed []errorEvent, limit int) {
+	if len(feed) > limit {
+		feed = feed[len(feed)-limit:]
+	}
+	for _, event := range feed {
+		fmt.Printf("  %s  %-10s %s\n",
+			event.At.Format("15:04:05"), event.Service, strings.TrimSpace(event.Line))
+	}
+}
+
+func runErrors(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("errors", flag.ContinueOnError)
+	limit := flags.Int("limit", 20, "number of recent errors to show")
+	watch := flags.Bool("watch", false, "keep a live view open (next to a dev session)")
+	clear := flags.Bool("clear", false, "empty the feed")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *clear {
+		if err := os.Remove(errorFeedPath(rootDir)); err != nil && !os.IsNotExist(err) {
+			return reportError(err)
+		}
+		fmt.Println("error feed cleared")
+		return 0
+	}
+	if *watch {
+		if code := requireInteractive("errors --watch", "run errors without --watch"); code >= 0 {
+			return code
+		}
+		if _, err := tea.NewProgram(errorsModel{rootDir: rootDir}, tea.WithAltScreen()).Run(); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		return 0
+	}
+	feed := loadErrorFeed(rootDir)
+	if len(feed) == 0 {
+		fmt.Println("no errors captured — the feed fills while dev or bg services run")
+		return 0
+	}
+	printErrorFeed(feed, *limit)
+	return 0
+}
+
+// errorsModel is the live view: the feed re-read from disk every
+// couple of seconds, newest at the bottom, same skeleton as stats.
+type errorsModel struct {
+	rootDir string
+	feed    []errorEvent
+	height  int
+}
+
+type errorsTickMsg struct{}
+
+func errorsTick() tea.Cmd {
+	return tea.Tick(2*time.Second, func(time.Time) tea.Msg { return errorsTickMsg{} })
+}
+
+func (m errorsModel) Init() tea.Cmd {
+	return func() tea.Msg { return errorsTickMsg{} }
+}
+
+func (m errorsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+	case errorsTickMsg:
+		m.feed = loadErrorFeed(m.rootDir)
+		return m, errorsTick()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case tuiKeys.Quit, "ctrl+c", "esc":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m errorsModel) View() string {
+	s := liveTitleStyle.Render("lurelands errors · live feed") + "\n\n"
+	visible := m.height - 4
+	if visible < 5 {
+		visible = 20
+	}
+	feed := m.feed
+	if len(feed) > visible {
+		feed = feed[len(feed)-visible:]
+	}
+	if len(feed) == 0 {
+		s += liveDimStyle.Render("  nothing captured yet — errors appear as services hit them") + "\n"
+	}
+	for _, event := range feed {
+		s += fmt.Sprintf("  %s  %s %s\n",
+			liveDimStyle.Render(event.At.Format("15:04:05")),
+			liveWarnStyle.Render(fmt.Sprintf("%-10s", event.Service)),
+			strings.TrimSpace(event.Line))
+	}
+	return s + "\n" + liveDimStyle.Render(tuiKeys.Quit+" quit")
+}
+
+func init() {
+	commandTree = append(commandTree, &command{
+		name: "errors", summary: "recent panics and error lines captured from running services", run: runErrors,
+	})
+}
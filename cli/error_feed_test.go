@@ -0,0 +1,46 @@
+package main
+
+import "testing"
+
+func TestFeedErrorLineMatching(t *testing.T) {
+	root := t.TempDir()
+	lines := []struct {
+		line string
+		want bool
+	}{
+		{"thread 'main' panicked at src/lib.rs:42", true},
+		{"Unhandled exception: RangeError (index)", true},
+		{"error: reducer cast_line failed: no such player", true},
+		{"reducer tick_world panicked in frame 9912", true},
+		{"[bun] Uncaught Error: connect ECONNREFUSED", true},
+		{"Compiling lurelands-module v0.4.1", false},
+		{"GET /health 200 3ms", false},
+		{"0 errors found", false},
+	}
+	for _, c := range lines {
+		feedErrorLine(root, "test", c.line)
+	}
+	feed := loadErrorFeed(root)
+	want := 0
+	for _, c := range lines {
+		if c.want {
+			want++
+		}
+	}
+	if len(feed) != want {
+		t.Fatalf("captured %d events, want %d: %+v", len(feed), want, feed)
+	}
+	if feed[0].Service != "test" || feed[0].At.IsZero() {
+		t.Errorf("event missing metadata: %+v", feed[0])
+	}
+}
+
+func TestErrorFeedCapsLength(t *testing.T) {
+	root := t.TempDir()
+	for i := 0; i < errorFeedLimit+25; i++ {
+		feedErrorLine(root, "bridge", "panic: overflow")
+	}
+	if got := len(loadErrorFeed(root)); got != errorFeedLimit {
+		t.Errorf("feed length = %d, want %d", got, errorFeedLimit)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Typed CLI errors. Each failure class carries a remediation hint and
+// maps to its own exit code, so humans get a next step and CI scripts
+// can branch on why a command failed instead of grepping stderr.
+
+const (
+	exitOK             = 0
+	exitFailure        = 1 // generic: the command itself failed
+	exitUsage          = 2 // bad flags or arguments
+	exitToolNotFound   = 3
+	exitWorkdirMissing = 4
+	exitAuthRequired   = 5
+	exitCancelled      = 130 // 128+SIGINT, matching shell convention
+)
+
+type cliError struct {
+	code int
+	msg  string
+	hint string
+}
+
+func (e *cliError) Error() string { return e.msg }
+
+// errToolNotFound: a required external tool is missing from PATH.
+func errToolNotFound(tool, neededFor string) *cliError {
+	return &cliError{
+		code: exitToolNotFound,
+		msg:  fmt.Sprintf("%s is required to %s but was not found in PATH", tool, neededFor),
+		hint: fmt.Sprintf("install %s and re-run, or check your PATH", tool),
+	}
+}
+
+// errWorkdirMissing: a directory a command must run in doesn't exist.
+func errWorkdirMissing(dir string) *cliError {
+	return &cliError{
+		code: exitWorkdirMissing,
+		msg:  fmt.Sprintf("working directory %s does not exist", dir),
+		hint: "run from inside the lurelands monorepo, or pull the missing service",
+	}
+}
+
+// errAuthRequired: the spacetime CLI is not logged in to the target.
+func errAuthRequired(target string) *cliError {
+	return &cliError{
+		code: exitAuthRequired,
+		msg:  fmt.Sprintf("not authenticated against %s", target),
+		hint: "run `spacetime login` and retry",
+	}
+}
+
+// reportError prints err (and its hint, if typed) to stderr and
+// returns the exit code the command should finish with. Context
+// cancellation is not an error worth a stack of text — just the
+// conventional 130.
+func reportError(err error) int {
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr, "cancelled")
+		return exitCancelled
+	}
+	var cli *cliError
+	if errors.As(err, &cli) {
+		fmt.Fprintln(os.Stderr, "error:", cli.msg)
+		if cli.hint != "" {
+			fmt.Fprintln(os.Stderr, "hint:", cli.hint)
+		}
+		return cli.code
+	}
+	fmt.Fprintln(os.Stderr, "error:", err)
+	return exitFailure
+}
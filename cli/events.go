@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+)
+
+// events manages timed in-game events (double-XP weekends, rare fish
+// migrations) through the module's event reducers. Event windows of the
+// same type may never overlap — the listing and create path both enforce
+// that before any reducer is called.
+
+type gameEvent struct {
+	ID    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Type  string `json:"event_type"`
+	Start int64  `json:"start_unix"`
+	End   int64  `json:"end_unix"`
+}
+
+func (e gameEvent) startTime() time.Time { return time.Unix(e.Start, 0).UTC() }
+func (e gameEvent) endTime() time.Time   { return time.Unix(e.End, 0).UTC() }
+
+func fetchEvents(env environment) ([]gameEvent, error) {
+	out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module,
+		"SELECT * FROM events", "--json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("querying events on %s: %w", env.Name, err)
+	}
+	var events []gameEvent
+	if err := json.Unmarshal(out, &events); err != nil {
+		return nil, fmt.Errorf("parsing event rows: %w", err)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Start < events[j].Start })
+	return events, nil
+}
+
+// overlappingEvent returns the first existing event of the same type whose
+// window intersects [start, end).
+func overlappingEvent(events []gameEvent, eventType string, start, end time.Time) *gameEvent {
+	for i := range events {
+		event := &events[i]
+		if event.Type != eventType {
+			continue
+		}
+		if start.Before(event.endTime()) && event.startTime().Before(end) {
+			return event
+		}
+	}
+	return nil
+}
+
+func runEvents(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands events <list|create|cancel> [flags]")
+		return 1
+	}
+	sub, rest := args[0], args[1:]
+
+	flags := flag.NewFlagSet("events "+sub, flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	name := flags.String("name", "", "event name (create)")
+	eventType := flags.String("type", "", "event type, e.g. double_xp, migration (create)")
+	start := flags.String("start", "", "event start, RFC3339 (create)")
+	end := flags.String("end", "", "event end, RFC3339 (create)")
+	id := flags.Uint64("id", 0, "event id (cancel)")
+	if err := flags.Parse(rest); err != nil {
+		return 1
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	switch sub {
+	case "list":
+		events, err := fetchEvents(env)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		if len(events) == 0 {
+			fmt.Printf("no events scheduled on %s\n", env.Name)
+			return 0
+		}
+		// Calendar-style: one heading per day, events beneath.
+		lastDay := ""
+		for _, event := range events {
+			day := event.startTime().Format("Mon 2006-01-02")
+			if day != lastDay {
+				fmt.Printf("%s\n", day)
+				lastDay = day
+			}
+			fmt.Printf("  #%-4d %-10s %s  %s – %s\n", event.ID, event.Type, event.Name,
+				event.startTime().Format("15:04"), event.endTime().Format("15:04 MST"))
+		}
+		return 0
+
+	case "create":
+		if *name == "" || *eventType == "" || *start == "" || *end == "" {
+			fmt.Fprintln(os.Stderr, "create requires --name, --type, --start, and --end")
+			return 1
+		}
+		startTime, err := time.Parse(time.RFC3339, *start)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error: invalid --start:", err)
+			return 1
+		}
+		endTime, err := time.Parse(time.RFC3339, *end)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error: invalid --end:", err)
+			return 1
+		}
+		if !endTime.After(startTime) {
+			fmt.Fprintln(os.Stderr, "error: --end must be after --start")
+			return 1
+		}
+		existing, err := fetchEvents(env)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		if overlap := overlappingEvent(existing, *eventType, startTime, endTime); overlap != nil {
+			fmt.Fprintf(os.Stderr, "error: overlaps event #%d (%s, %s – %s)\n",
+				overlap.ID, overlap.Name,
+				overlap.startTime().Format(time.RFC3339), overlap.endTime().Format(time.RFC3339))
+			return 1
+		}
+		payload, _ := json.Marshal(map[string]any{
+			"name":       *name,
+			"event_type": *eventType,
+			"start_unix": startTime.Unix(),
+			"end_unix":   endTime.Unix(),
+		})
+		cmd := exec.Command("spacetime", "call", "--server", env.Server, env.Module, "schedule_event", string(payload))
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintln(os.Stderr, "error: schedule_event failed:", err)
+			return 1
+		}
+		fmt.Printf("scheduled %q (%s) on %s\n", *name, *eventType, env.Name)
+		return 0
+
+	case "cancel":
+		if *id == 0 {
+			fmt.Fprintln(os.Stderr, "cancel requires --id")
+			return 1
+		}
+		cmd := exec.Command("spacetime", "call", "--server", env.Server, env.Module,
+			"cancel_event", fmt.Sprintf("%d", *id))
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintln(os.Stderr, "error: cancel_event failed:", err)
+			return 1
+		}
+		fmt.Printf("cancelled event #%d on %s\n", *id, env.Name)
+		return 0
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown events subcommand %q\n", sub)
+		return 1
+	}
+}
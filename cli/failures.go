@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Failure summaries: when a build or publish fails, the part that
+// matters is usually three lines out of three hundred. Each matcher
+// knows one tool's error shape and pulls out file, line, and message;
+// summarizeFailure runs the registry over captured output and prints
+// the hits instead of the scrollback. New tools get a new entry here —
+// the registry is ordered, and the first matcher to claim a line wins.
+
+type extractedError struct {
+	tool    string
+	file    string // empty when the tool doesn't say
+	line    string
+	message string
+}
+
+// errorMatcher matches one line. Group indices point into the
+// pattern's submatches; 0 means "not captured by this tool".
+type errorMatcher struct {
+	tool    string
+	pattern *regexp.Regexp
+	file    int
+	line    int
+	message int
+}
+
+var errorMatchers = []errorMatcher{
+	// Dart compile errors: lib/main.dart:10:3: Error: message
+	{"dart", regexp.MustCompile(`^([^\s:]+\.dart):(\d+):\d+:\s+Error:\s+(.+)$`), 1, 2, 3},
+	// Dart analyzer: error • message • lib/foo.dart:12:5 • code
+	{"dart", regexp.MustCompile(`^\s*error • (.+) • ([^\s:]+\.dart):(\d+):\d+`), 2, 3, 1},
+	// TypeScript: src/foo.ts(12,5): error TS2304: message
+	{"typescript", regexp.MustCompile(`^([^\s(]+\.tsx?)\((\d+),\d+\): error (TS\d+: .+)$`), 1, 2, 3},
+	// Bun/esbuild: error: message ... at src/foo.ts:12:5 on one line
+	{"typescript", regexp.MustCompile(`^error: (.+?)\s+at ([^\s:]+\.tsx?):(\d+)`), 2, 3, 1},
+	// spacetime publish rejections and other bare errors, no location.
+	{"spacetime", regexp.MustCompile(`(?i)^\s*Error:\s+(.+)$`), 0, 0, 1},
+}
+
+// rustErrorPattern and rustLocationPattern pair up across two lines:
+//
+//	error[E0308]: mismatched types
+//	 --> src/lib.rs:42:9
+var (
+	rustErrorPattern    = regexp.MustCompile(`^error(\[E\d+\])?: (.+)$`)
+	rustLocationPattern = regexp.MustCompile(`^\s*--> ([^\s:]+):(\d+):\d+`)
+)
+
+// extractErrors scans captured output for known error signatures.
+func extractErrors(output string) []extractedError {
+	var errors []extractedError
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		if match := rustErrorPattern.FindStringSubmatch(line); match != nil {
+			err := extractedError{tool: "rust", message: match[2]}
+			if i+1 < len(lines) {
+				if loc := rustLocationPattern.FindStringSubmatch(lines[i+1]); loc != nil {
+					err.file, err.line = loc[1], loc[2]
+				}
+			}
+			errors = append(errors, err)
+			continue
+		}
+		for _, matcher := range errorMatchers {
+			match := matcher.pattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			err := extractedError{tool: matcher.tool, message: match[matcher.message]}
+			if matcher.file > 0 {
+				err.file, err.line = match[matcher.file], match[matcher.line]
+			}
+			errors = append(errors, err)
+			break
+		}
+	}
+	return errors
+}
+
+const failureSummaryLimit = 10
+
+// summarizeFailure renders extracted errors as a concise file:line
+// summary, falling back to the last fallbackLines of raw output when
+// nothing in the registry matched.
+func summarizeFailure(output string, fallbackLines int) string {
+	errors := extractErrors(output)
+	if len(errors) == 0 {
+		return lastOutputLines(output, fallbackLines)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d error(s):\n", len(errors))
+	for i, err := range errors {
+		if i == failureSummaryLimit {
+			fmt.Fprintf(&b, "  … and %d more\n", len(errors)-failureSummaryLimit)
+			break
+		}
+		location := err.file
+		if err.line != "" {
+			location += ":" + err.line
+		}
+		if location == "" {
+			location = "(" + err.tool + ")"
+		}
+		fmt.Fprintf(&b, "  %-36s %s\n", location, err.message)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractErrors(t *testing.T) {
+	output := strings.Join([]string{
+		"Launching lib/main.dart on macOS...",
+		"lib/game/reel.dart:42:7: Error: The getter 'tension' isn't defined.",
+		"src/handlers/cast.ts(17,3): error TS2304: Cannot find name 'playerId'.",
+		"error[E0308]: mismatched types",
+		" --> src/lib.rs:88:5",
+		"Error: module rejected: schema migration required",
+		"some unrelated line",
+	}, "\n")
+
+	errors := extractErrors(output)
+	if len(errors) != 4 {
+		t.Fatalf("want 4 errors, got %d: %+v", len(errors), errors)
+	}
+	if errors[0].file != "lib/game/reel.dart" || errors[0].line != "42" {
+		t.Errorf("dart location wrong: %+v", errors[0])
+	}
+	if errors[1].tool != "typescript" || errors[1].file != "src/handlers/cast.ts" {
+		t.Errorf("typescript match wrong: %+v", errors[1])
+	}
+	if errors[2].tool != "rust" || errors[2].file != "src/lib.rs" || errors[2].line != "88" {
+		t.Errorf("rust two-line match wrong: %+v", errors[2])
+	}
+	if errors[3].file != "" || !strings.Contains(errors[3].message, "module rejected") {
+		t.Errorf("bare error match wrong: %+v", errors[3])
+	}
+}
+
+func TestSummarizeFailureFallsBack(t *testing.T) {
+	summary := summarizeFailure("nothing recognizable\nhere\n", 5)
+	if !strings.Contains(summary, "nothing recognizable") {
+		t.Errorf("no-match output should fall back to raw lines, got %q", summary)
+	}
+	summary = summarizeFailure("lib/a.dart:1:1: Error: bad\n", 5)
+	if !strings.Contains(summary, "lib/a.dart:1") || !strings.Contains(summary, "1 error(s)") {
+		t.Errorf("summary missing structured line: %q", summary)
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// flags manages feature flags in the module's config table. Values are
+// per-environment — setting a flag on staging never touches prod — and
+// every write shows the current value first so there are no blind flips.
+
+type featureFlag struct {
+	Key            string `json:"key"`
+	Value          string `json:"value"`
+	RolloutPercent int    `json:"rollout_percent"` // 100 = fully on for everyone the value enables
+}
+
+func fetchFlags(env environment) ([]featureFlag, error) {
+	out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module,
+		"SELECT * FROM feature_flag", "--json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("querying feature flags on %s: %w", env.Name, err)
+	}
+	var flags []featureFlag
+	if err := json.Unmarshal(out, &flags); err != nil {
+		return nil, fmt.Errorf("parsing flag rows: %w", err)
+	}
+	return flags, nil
+}
+
+func findFlag(flags []featureFlag, key string) *featureFlag {
+	for i := range flags {
+		if flags[i].Key == key {
+			return &flags[i]
+		}
+	}
+	return nil
+}
+
+func setFlag(env environment, key, value string, rollout int) error {
+	payload, _ := json.Marshal(map[string]any{
+		"key": key, "value": value, "rollout_percent": rollout,
+	})
+	cmd := exec.Command("spacetime", "call", "--server", env.Server, env.Module, "set_feature_flag", string(payload))
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("set_feature_flag failed: %w", err)
+	}
+	return nil
+}
+
+func runFlags(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands flags <list|set|rollout> [flags]")
+		return 1
+	}
+	sub, rest := args[0], args[1:]
+
+	fs := flag.NewFlagSet("flags "+sub, flag.ContinueOnError)
+	envName := fs.String("env", "", "target environment")
+	yes := fs.Bool("yes", false, "apply without the confirmation prompt")
+	if err := fs.Parse(rest); err != nil {
+		return 1
+	}
+	positional := fs.Args()
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	existing, err := fetchFlags(env)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	switch sub {
+	case "list":
+		if len(existing) == 0 {
+			fmt.Printf("no flags set on %s\n", env.Name)
+			return 0
+		}
+		fmt.Printf("feature flags on %s\n\n", env.Name)
+		for _, f := range existing {
+			rollout := ""
+			if f.RolloutPercent < 100 {
+				rollout = fmt.Sprintf("  (%d%% rollout)", f.RolloutPercent)
+			}
+			fmt.Printf("  %-30s %s%s\n", f.Key, f.Value, rollout)
+		}
+		return 0
+
+	case "set":
+		if len(positional) != 2 {
+			fmt.Fprintln(os.Stderr, "usage: lurelands flags set <key> <value> [--env <name>]")
+			return 1
+		}
+		key, value := positional[0], positional[1]
+		rollout := 100
+		if current := findFlag(existing, key); current != nil {
+			if current.Value == value {
+				fmt.Printf("%s is already %q on %s\n", key, value, env.Name)
+				return 0
+			}
+			rollout = current.RolloutPercent
+			fmt.Printf("%s on %s: %q -> %q\n", key, env.Name, current.Value, value)
+		} else {
+			fmt.Printf("%s on %s: (unset) -> %q\n", key, env.Name, value)
+		}
+		if !*yes && !confirmAction(fmt.Sprintf("apply to %s?", env.Name), key) {
+			fmt.Fprintln(os.Stderr, "aborted: confirmation did not match")
+			return 1
+		}
+		if err := setFlag(env, key, value, rollout); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Printf("set %s=%s on %s\n", key, value, env.Name)
+		return 0
+
+	case "rollout":
+		if len(positional) != 2 {
+			fmt.Fprintln(os.Stderr, "usage: lurelands flags rollout <key> <percent> [--env <name>]")
+			return 1
+		}
+		key := positional[0]
+		percent, err := strconv.Atoi(positional[1])
+		if err != nil || percent < 0 || percent > 100 {
+			fmt.Fprintln(os.Stderr, "error: percent must be an integer 0-100")
+			return 1
+		}
+		current := findFlag(existing, key)
+		if current == nil {
+			fmt.Fprintf(os.Stderr, "error: flag %q is not set on %s (set it first)\n", key, env.Name)
+			return 1
+		}
+		fmt.Printf("%s rollout on %s: %d%% -> %d%%\n", key, env.Name, current.RolloutPercent, percent)
+		if !*yes && !confirmAction(fmt.Sprintf("apply to %s?", env.Name), key) {
+			fmt.Fprintln(os.Stderr, "aborted: confirmation did not match")
+			return 1
+		}
+		if err := setFlag(env, key, current.Value, percent); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Printf("rolled %s to %d%% on %s\n", key, percent, env.Name)
+		return 0
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown flags subcommand %q\n", sub)
+		return 1
+	}
+}
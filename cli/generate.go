@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// generate keeps both binding targets in sync with the module in one
+// step: the bridge's TypeScript bindings (same path bridge:generate
+// takes) and the Flutter app's Dart bindings, generated in parallel
+// since they only read module sources. Afterwards it reports which
+// generated files actually changed — "regenerated, 0 files changed" and
+// "client code needs attention" are very different outcomes that used
+// to look identical.
+
+func dartBindingsDir(rootDir string) string {
+	return filepath.Join(appDir(rootDir), "lib", "module_bindings")
+}
+
+// ensureDartBindings is the Dart twin of ensureBridgeBindings, keyed
+// under "app" in the same record file.
+func ensureDartBindings(ctx context.Context, rootDir string, force bool) error {
+	hash, err := moduleSchemaHash(rootDir)
+	if err != nil {
+		return fmt.Errorf("hashing module schema: %w", err)
+	}
+	if !force && loadBindingRecords(rootDir)["app"] == hash {
+		fmt.Println("dart bindings up to date (schema unchanged)")
+		return nil
+	}
+
+	result, err := runner.Run(ctx, pinnedSpec(rootDir, runner.Spec{
+		Name: "spacetime",
+		Args: []string{"generate", "--lang", "dart",
+			"--out-dir", "lib/module_bindings",
+			"--project-path", moduleDir(rootDir)},
+		Dir: appDir(rootDir),
+	}))
+	if err != nil {
+		return fmt.Errorf("generating dart bindings: %w\n%s", err, result.Output)
+	}
+	saveBindingRecord(rootDir, "app", hash)
+	fmt.Println("dart bindings regenerated")
+	return nil
+}
+
+// dirFileHashes maps relative path → content hash for every file under
+// dir, for before/after comparison of a generation target.
+func dirFileHashes(dir string) map[string]string {
+	hashes := map[string]string{}
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		sum := sha256.Sum256(data)
+		rel, _ := filepath.Rel(dir, path)
+		hashes[filepath.ToSlash(rel)] = hex.EncodeToString(sum[:])
+		return nil
+	})
+	return hashes
+}
+
+// changedFiles lists paths that were added, removed, or rewritten.
+func changedFiles(before, after map[string]string) []string {
+	var changed []string
+	for path, hash := range after {
+		if before[path] != hash {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, still := after[path]; !still {
+			changed = append(changed, path+" (removed)")
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func runGenerate(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	force := flags.Bool("force", false, "regenerate even when the schema hash matches")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if _, ok := lookupTool(rootDir, "spacetime"); !ok {
+		return reportError(errToolNotFound("spacetime", "generating bindings"))
+	}
+
+	targets := []struct {
+		name   string
+		outDir string
+		run    func() error
+	}{
+		{"typescript", filepath.Join(bridgeDir(rootDir), "src", "module_bindings"),
+			func() error { return ensureBridgeBindings(ctx, rootDir, *force) }},
+		{"dart", dartBindingsDir(rootDir),
+			func() error { return ensureDartBindings(ctx, rootDir, *force) }},
+	}
+
+	before := make([]map[string]string, len(targets))
+	for i, target := range targets {
+		before[i] = dirFileHashes(target.outDir)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(targets))
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, run func() error) {
+			defer wg.Done()
+			errs[i] = run()
+		}(i, target.run)
+	}
+	wg.Wait()
+
+	code := 0
+	for i, target := range targets {
+		if errs[i] != nil {
+			fmt.Fprintf(os.Stderr, "error: %s: %v\n", target.name, errs[i])
+			code = 1
+			continue
+		}
+		changed := changedFiles(before[i], dirFileHashes(target.outDir))
+		if len(changed) == 0 {
+			fmt.Printf("%s: no output changes\n", target.name)
+			continue
+		}
+		fmt.Printf("%s: %d file(s) changed\n", target.name, len(changed))
+		for _, path := range changed {
+			fmt.Println("  ", path)
+		}
+	}
+	if code == 0 {
+		fmt.Println("bindings in sync")
+	}
+	return code
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Small git integration: enough shelling-out to show where the
+// checkout stands (branch, ahead/behind upstream, dirty files) in the
+// menu header, and to put a short uncommitted-change summary in front
+// of deploys — the code about to ship should be the code in git.
+
+type gitInfo struct {
+	Branch string
+	Ahead  int
+	Behind int
+	Dirty  int
+	OK     bool // false when rootDir isn't a git checkout
+}
+
+func git(rootDir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", rootDir}, args...)...).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+func loadGitInfo(rootDir string) gitInfo {
+	branch, err := git(rootDir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return gitInfo{}
+	}
+	info := gitInfo{Branch: branch, OK: true}
+	if status, err := git(rootDir, "status", "--porcelain"); err == nil && status != "" {
+		info.Dirty = len(strings.Split(status, "\n"))
+	}
+	// No upstream (fresh branch) just leaves ahead/behind at zero.
+	if counts, err := git(rootDir, "rev-list", "--left-right", "--count", "@{upstream}...HEAD"); err == nil {
+		if fields := strings.Fields(counts); len(fields) == 2 {
+			info.Behind, _ = strconv.Atoi(fields[0])
+			info.Ahead, _ = strconv.Atoi(fields[1])
+		}
+	}
+	return info
+}
+
+// headerSuffix renders the info for the menu title, empty when there
+// is nothing noteworthy to show.
+func (g gitInfo) headerSuffix() string {
+	if !g.OK {
+		return ""
+	}
+	s := " · " + g.Branch
+	if g.Ahead > 0 {
+		s += fmt.Sprintf(" ↑%d", g.Ahead)
+	}
+	if g.Behind > 0 {
+		s += fmt.Sprintf(" ↓%d", g.Behind)
+	}
+	if g.Dirty > 0 {
+		s += fmt.Sprintf(" ✚%d", g.Dirty)
+	}
+	return s
+}
+
+// printServiceChanges lists uncommitted changes under the deployable
+// service trees, truncated — the point is "you are shipping unreviewed
+// edits", not a full diff.
+func printServiceChanges(rootDir string) {
+	status, err := git(rootDir, "status", "--porcelain", "--",
+		"services/spacetime-server", "services/bridge")
+	if err != nil || status == "" {
+		return
+	}
+	lines := strings.Split(status, "\n")
+	fmt.Printf("warning: %d uncommitted change(s) in the services being deployed:\n", len(lines))
+	for i, line := range lines {
+		if i == 8 {
+			fmt.Printf("  … and %d more\n", len(lines)-i)
+			break
+		}
+		fmt.Println("  " + line)
+	}
+}
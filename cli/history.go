@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Command history. Every dispatched command is appended to
+// .lurelands/history.json with its environment, exit code, and
+// duration; `history` lists recent runs and `rerun [n]` replays one —
+// after a failed deploy, `lurelands rerun` retries the exact same
+// command line. The menu surfaces the most recent runs at the top of
+// its list. `again` remains the zero-memory shortcut for the very last
+// command; history is the durable log behind it.
+
+const historyLimit = 200
+
+type historyEntry struct {
+	Args     []string      `json:"args"`
+	Env      string        `json:"env,omitempty"`
+	ExitCode int           `json:"exit_code"`
+	Duration time.Duration `json:"duration_ns"`
+	At       time.Time     `json:"at"`
+}
+
+func historyPath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "history.json")
+}
+
+func loadHistory(rootDir string) []historyEntry {
+	var history []historyEntry
+	data, err := os.ReadFile(historyPath(rootDir))
+	if err == nil {
+		json.Unmarshal(data, &history)
+	}
+	return history
+}
+
+// appendHistory records one finished command, newest last.
+func appendHistory(rootDir string, entry historyEntry) {
+	if rootDir == "" || len(entry.Args) == 0 {
+		return
+	}
+	switch entry.Args[0] {
+	case "again", "rerun", "history", "shell", "workspace", "api", "menu":
+		return
+	}
+	for i, arg := range entry.Args {
+		if arg == "--env" && i+1 < len(entry.Args) {
+			entry.Env = entry.Args[i+1]
+		} else if strings.HasPrefix(arg, "--env=") {
+			entry.Env = strings.TrimPrefix(arg, "--env=")
+		}
+	}
+	history := append(loadHistory(rootDir), entry)
+	if len(history) > historyLimit {
+		history = history[len(history)-historyLimit:]
+	}
+	os.MkdirAll(filepath.Dir(historyPath(rootDir)), 0o755)
+	data, _ := json.MarshalIndent(history, "", "  ")
+	os.WriteFile(historyPath(rootDir), append(data, '\n'), 0o644)
+}
+
+// recentHistory returns up to n entries, newest first, deduplicated by
+// command line so a retried command shows once.
+func recentHistory(rootDir string, n int) []historyEntry {
+	history := loadHistory(rootDir)
+	seen := map[string]bool{}
+	var recent []historyEntry
+	for i := len(history) - 1; i >= 0 && len(recent) < n; i-- {
+		key := strings.Join(history[i].Args, " ")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		recent = append(recent, history[i])
+	}
+	return recent
+}
+
+func relativeAge(t time.Time) string {
+	age := time.Since(t)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
+
+func runHistory(ctx context.Context, rootDir string, args []string) int {
+	recent := recentHistory(rootDir, 20)
+	if len(recent) == 0 {
+		fmt.Println("no history yet")
+		return 0
+	}
+	for i, entry := range recent {
+		mark := "ok"
+		if entry.ExitCode != 0 {
+			mark = " !"
+		}
+		fmt.Printf("  %2d  %s  %-44s %-8s %s\n", i+1, mark,
+			strings.Join(entry.Args, " "), relativeAge(entry.At), entry.Duration.Round(time.Millisecond))
+	}
+	fmt.Println("\nreplay one with `lurelands rerun <n>` (or just `rerun` for the last)")
+	return 0
+}
+
+func runRerun(ctx context.Context, rootDir string, args []string) int {
+	recent := recentHistory(rootDir, 20)
+	if len(recent) == 0 {
+		fmt.Fprintln(os.Stderr, "nothing to re-run yet")
+		return 1
+	}
+	index := 1
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > len(recent) {
+			fmt.Fprintf(os.Stderr, "usage: lurelands rerun [1-%d] (see `lurelands history`)\n", len(recent))
+			return exitUsage
+		}
+		index = n
+	}
+	entry := recent[index-1]
+	fmt.Printf("re-running: lurelands %s\n", strings.Join(entry.Args, " "))
+	return dispatch(ctx, commandTree, rootDir, entry.Args)
+}
+
+// Registered in init: runRerun re-dispatches against commandTree, which
+// would be an initialization cycle as a literal tree entry.
+func init() {
+	commandTree = append(commandTree,
+		&command{name: "history", summary: "recent commands with exit codes and timing", run: runHistory},
+		&command{name: "rerun", summary: "replay a command from history", run: runRerun},
+	)
+}
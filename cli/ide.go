@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ide devcontainer writes a .devcontainer setup so a Codespaces (or VS
+// Code) contributor gets flutter, bun, rust with the wasm target, the
+// spacetime CLI, and this CLI itself without reading a setup doc. The
+// files are generated rather than maintained by hand for the same reason
+// as compose: the toolchain list lives in one place, here.
+
+const devcontainerJSON = `{
+  "name": "lurelands",
+  "build": { "dockerfile": "Dockerfile" },
+  "postCreateCommand": "cd cli && go build -o /usr/local/bin/lurelands .",
+  "customizations": {
+    "vscode": {
+      "extensions": [
+        "dart-code.flutter",
+        "rust-lang.rust-analyzer",
+        "golang.go"
+      ]
+    }
+  },
+  "forwardPorts": [3000, 8080],
+  "remoteEnv": { "LURELANDS_ROOT": "${containerWorkspaceFolder}" }
+}
+`
+
+const devcontainerDockerfile = `FROM mcr.microsoft.com/devcontainers/base:ubuntu
+
+# Flutter (stable channel)
+RUN git clone --depth 1 -b stable https://github.com/flutter/flutter /opt/flutter
+ENV PATH=$PATH:/opt/flutter/bin
+
+# Bun (bridge runtime)
+RUN curl -fsSL https://bun.sh/install | bash -s -- --no-interaction \
+    && ln -s /root/.bun/bin/bun /usr/local/bin/bun
+
+# Rust with the wasm target the module builds for
+RUN curl -fsSL https://sh.rustup.rs | sh -s -- -y --target wasm32-unknown-unknown
+ENV PATH=$PATH:/root/.cargo/bin
+
+# Go (for the dev CLI)
+RUN curl -fsSL https://go.dev/dl/go1.21.6.linux-amd64.tar.gz | tar -C /usr/local -xz
+ENV PATH=$PATH:/usr/local/go/bin
+
+# SpacetimeDB CLI
+RUN curl -fsSL https://install.spacetimedb.com | bash -s -- --yes
+ENV PATH=$PATH:/root/.local/bin
+`
+
+func runIDEDevcontainer(ctx context.Context, rootDir string, args []string) int {
+	dir := filepath.Join(rootDir, ".devcontainer")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	files := map[string]string{
+		"devcontainer.json": devcontainerJSON,
+		"Dockerfile":        devcontainerDockerfile,
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Println("wrote", path)
+	}
+	return 0
+}
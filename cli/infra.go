@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// infra wraps the Terraform/OpenTofu configs under infra/terraform that
+// provision the bridge host, DNS records, and the asset/cache buckets.
+// The wrapper exists so nobody points prod tooling at the staging state:
+// the backend config and workspace are both injected from the
+// environment name, and the plan summary is pulled out of the tool
+// output so the important line is impossible to miss in the scrollback.
+
+func terraformDir(rootDir string) string {
+	return filepath.Join(rootDir, "infra", "terraform")
+}
+
+// infraTool prefers OpenTofu when installed and falls back to terraform;
+// the CLIs are interchangeable for everything we invoke.
+func infraTool(rootDir string) (string, error) {
+	for _, name := range []string{"tofu", "terraform"} {
+		if _, ok := lookupTool(rootDir, name); ok {
+			return name, nil
+		}
+	}
+	return "", errToolNotFound("tofu (or terraform)", "provisioning infrastructure")
+}
+
+var planSummaryPattern = regexp.MustCompile(`(Plan: .*|No changes\..*|Apply complete!.*)`)
+
+func runInfraStep(ctx context.Context, rootDir, tool string, args []string) (summary string, exit int) {
+	result, err := runner.RunStreaming(ctx, runner.Spec{
+		Name: tool,
+		Args: args,
+		Dir:  terraformDir(rootDir),
+	}, func(line string, isStderr bool) {
+		if match := planSummaryPattern.FindString(line); match != "" {
+			summary = match
+		}
+		if isStderr {
+			fmt.Fprintln(os.Stderr, line)
+		} else {
+			fmt.Println(line)
+		}
+	})
+	if err != nil && result.ExitCode == 0 {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return summary, 1
+	}
+	return summary, result.ExitCode
+}
+
+func runInfraCommand(ctx context.Context, rootDir string, args []string, verb string) int {
+	flags := flag.NewFlagSet("infra "+verb, flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "staging"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if env.Name == "local" {
+		fmt.Fprintln(os.Stderr, "error: local has no provisioned infrastructure")
+		return exitUsage
+	}
+	tool, err := infraTool(rootDir)
+	if err != nil {
+		return reportError(err)
+	}
+	if _, statErr := os.Stat(terraformDir(rootDir)); statErr != nil {
+		return reportError(errWorkdirMissing(terraformDir(rootDir)))
+	}
+
+	backend := filepath.Join("backends", env.Name+".hcl")
+	if _, exit := runInfraStep(ctx, rootDir, tool, []string{"init", "-input=false", "-backend-config=" + backend}); exit != 0 {
+		return exit
+	}
+	if _, exit := runInfraStep(ctx, rootDir, tool, []string{"workspace", "select", "-or-create", env.Name}); exit != 0 {
+		return exit
+	}
+
+	varFile := "-var-file=" + env.Name + ".tfvars"
+	if verb == "apply" {
+		// apply stays interactive: terraform shows its own plan and the
+		// operator confirms it against the right workspace.
+		if err := runner.RunInteractive(ctx, runner.Spec{
+			Name: tool,
+			Args: []string{"apply", varFile},
+			Dir:  terraformDir(rootDir),
+		}); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		return 0
+	}
+	summary, exit := runInfraStep(ctx, rootDir, tool, []string{"plan", "-input=false", varFile})
+	if summary != "" {
+		fmt.Printf("\n%s %s\n", tui.Accent.Render(env.Name+":"), summary)
+	}
+	return exit
+}
+
+func runInfraPlan(ctx context.Context, rootDir string, args []string) int {
+	return runInfraCommand(ctx, rootDir, args, "plan")
+}
+
+func runInfraApply(ctx context.Context, rootDir string, args []string) int {
+	return runInfraCommand(ctx, rootDir, args, "apply")
+}
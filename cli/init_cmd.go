@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// init bootstraps a fresh checkout: it walks the standard monorepo
+// layout piece by piece, offers to create whatever is missing, and
+// writes .lurelands.toml — the marker every other command discovers the
+// root by. Before that marker exists getRootDir has nothing to find,
+// so init (like workspace) is dispatched without a resolved root and
+// operates on the directory you point it at.
+
+type initPiece struct {
+	rel  string
+	what string
+}
+
+// initLayout is the layout the rest of the CLI assumes (pkg/workspace
+// hardcodes these paths); init confirms it rather than inventing an
+// alternative one nothing else would honor.
+var initLayout = []initPiece{
+	{rel: "apps/lurelands", what: "Flutter app"},
+	{rel: "services/spacetime-server", what: "SpacetimeDB module"},
+	{rel: "services/bridge", what: "websocket bridge"},
+	{rel: "content", what: "content JSON source of truth"},
+}
+
+// starterConfig documents the sections other commands read, commented
+// out so a fresh file configures nothing until someone means it to.
+const starterConfig = `# Lurelands workspace config. This file marks the monorepo root — the
+# CLI walks upward from the working directory until it finds it.
+# Uncomment sections as you need them.
+
+# [toolchain]
+# flutter = "3.19.0"
+# bun = "1.0.25"
+# rust = "1.75.0"
+# spacetime = "0.8.0"
+
+# [env.staging2]
+# server = "staging2.spacetimedb.com"
+# module = "lurelands-staging2"
+
+# [command.fmt-all]
+# run = "dart format apps/lurelands"
+# summary = "format everything"
+
+# [theme]
+# title = "#00CED1"
+
+# [keys]
+# quit = "x"
+`
+
+func runInit(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("init", flag.ContinueOnError)
+	skeleton := flags.Bool("skeleton", false, "create missing directories without asking")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	target := "."
+	if flags.NArg() > 0 {
+		target = flags.Arg(0)
+	}
+	target, err := filepath.Abs(target)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if fileExists(configPath(target)) {
+		fmt.Printf("%s is already a lurelands workspace (%s exists)\n", target, configPath(target))
+		return 0
+	}
+
+	fmt.Printf("initializing %s\n", target)
+	reader := bufio.NewReader(os.Stdin)
+	var skipped []string
+	for _, piece := range initLayout {
+		path := filepath.Join(target, piece.rel)
+		if fileExists(path) {
+			fmt.Printf("  ok %-28s %s\n", piece.rel, piece.what)
+			continue
+		}
+		create := *skeleton
+		if !create && !ciMode {
+			fmt.Printf("  %s (%s) is missing — create it? [Y/n] ", piece.rel, piece.what)
+			line, _ := reader.ReadString('\n')
+			answer := strings.ToLower(strings.TrimSpace(line))
+			create = answer == "" || answer == "y" || answer == "yes"
+		}
+		if !create {
+			skipped = append(skipped, piece.rel)
+			fmt.Printf("  -- %-28s missing, not created\n", piece.rel)
+			continue
+		}
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Printf("  ++ %-28s created\n", piece.rel)
+	}
+
+	if err := os.WriteFile(configPath(target), []byte(starterConfig), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Printf("wrote %s\n", configPath(target))
+	if len(skipped) > 0 {
+		fmt.Printf("note: %s not created — commands that need them will say so\n", strings.Join(skipped, ", "))
+	}
+	fmt.Println("next: `lurelands doctor` checks the toolchain; `lurelands workspace add <name> " + target + "` lets you run from anywhere")
+	return 0
+}
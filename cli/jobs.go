@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Background jobs. `bg <command…>` runs any CLI command detached from
+// the terminal — it re-execs the CLI itself with output to a log under
+// .lurelands/jobs/ and a pid record beside it, so closing the terminal
+// no longer takes the bridge down with it. `ps` lists jobs with
+// liveness, `attach` follows a job's log (ctrl+c detaches, the job
+// keeps running), and `stop` terminates one gracefully. The local
+// spacetime server keeps its dedicated db start/stop; bg is for
+// everything else (bridge start, dev --no-app, monitor run, …).
+
+type jobRecord struct {
+	Name      string    `json:"name"`
+	PID       int       `json:"pid"`
+	Command   []string  `json:"command"`
+	Log       string    `json:"log"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+func jobsDir(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "jobs")
+}
+
+func jobRecordPath(rootDir, name string) string {
+	return filepath.Join(jobsDir(rootDir), name+".json")
+}
+
+func loadJobs(rootDir string) []jobRecord {
+	entries, err := os.ReadDir(jobsDir(rootDir))
+	if err != nil {
+		return nil
+	}
+	var jobs []jobRecord
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(jobsDir(rootDir), entry.Name()))
+		if err != nil {
+			continue
+		}
+		var job jobRecord
+		if json.Unmarshal(data, &job) == nil {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].StartedAt.Before(jobs[j].StartedAt) })
+	return jobs
+}
+
+func findJob(rootDir, name string) *jobRecord {
+	for _, job := range loadJobs(rootDir) {
+		if job.Name == name {
+			return &job
+		}
+	}
+	return nil
+}
+
+func runningJobs(rootDir string) int {
+	count := 0
+	for _, job := range loadJobs(rootDir) {
+		if processAlive(job.PID) {
+			count++
+		}
+	}
+	return count
+}
+
+func runBG(ctx context.Context, rootDir string, args []string) int {
+	name := ""
+	if len(args) > 1 && args[0] == "--name" {
+		name, args = args[1], args[2:]
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands bg [--name <name>] <command> [args…]")
+		return exitUsage
+	}
+	if name == "" {
+		name = args[0]
+	}
+	if job := findJob(rootDir, name); job != nil && processAlive(job.PID) {
+		fmt.Fprintf(os.Stderr, "error: job %q is already running (pid %d) — stop it first\n", name, job.PID)
+		return 1
+	}
+	self, err := os.Executable()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	os.MkdirAll(jobsDir(rootDir), 0o755)
+	logPath := filepath.Join(jobsDir(rootDir), name+".log")
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	defer logFile.Close()
+
+	// Plain exec.Command on purpose: the job must outlive this process,
+	// so no context and no process-group cancel.
+	cmd := exec.Command(self, append(args, "--root", rootDir)...)
+	cmd.Stdout, cmd.Stderr = logFile, logFile
+	if err := cmd.Start(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	record := jobRecord{Name: name, PID: cmd.Process.Pid, Command: args, Log: logPath, StartedAt: time.Now()}
+	data, _ := json.MarshalIndent(record, "", "  ")
+	os.WriteFile(jobRecordPath(rootDir, name), append(data, '\n'), 0o644)
+	cmd.Process.Release()
+	fmt.Printf("started %q (pid %d) — `lurelands attach %s` to follow, `lurelands stop %s` to end\n",
+		name, record.PID, name, name)
+	return 0
+}
+
+func runPS(ctx context.Context, rootDir string, args []string) int {
+	jobs := loadJobs(rootDir)
+	if len(jobs) == 0 {
+		fmt.Println("no background jobs (start one with `lurelands bg <command>`)")
+		return 0
+	}
+	for _, job := range jobs {
+		state := "running"
+		if !processAlive(job.PID) {
+			state = "dead"
+		}
+		fmt.Printf("  %-16s %-8s pid %-7d up %-9s %s\n",
+			job.Name, state, job.PID, relativeAge(job.StartedAt), strings.Join(job.Command, " "))
+	}
+	return 0
+}
+
+func runAttach(ctx context.Context, rootDir string, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands attach <name> (see `lurelands ps`)")
+		return exitUsage
+	}
+	job := findJob(rootDir, args[0])
+	if job == nil {
+		fmt.Fprintf(os.Stderr, "error: no job named %q\n", args[0])
+		return 1
+	}
+	f, err := os.Open(job.Log)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	defer f.Close()
+	fmt.Printf("attached to %q (pid %d) — ctrl+c detaches, the job keeps running\n", job.Name, job.PID)
+
+	// Follow the log: drain to EOF, then poll for growth. A detach is
+	// just this loop ending; the job itself is untouched.
+	for {
+		if _, err := io.Copy(os.Stdout, f); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Println("\ndetached")
+			return 0
+		case <-time.After(300 * time.Millisecond):
+		}
+		if !processAlive(job.PID) {
+			io.Copy(os.Stdout, f)
+			fmt.Printf("job %q exited\n", job.Name)
+			return 0
+		}
+	}
+}
+
+func runStop(ctx context.Context, rootDir string, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands stop <name> (see `lurelands ps`)")
+		return exitUsage
+	}
+	job := findJob(rootDir, args[0])
+	if job == nil {
+		fmt.Fprintf(os.Stderr, "error: no job named %q\n", args[0])
+		return 1
+	}
+	defer os.Remove(jobRecordPath(rootDir, job.Name))
+	if !processAlive(job.PID) {
+		fmt.Printf("job %q was already dead — record cleaned up\n", job.Name)
+		return 0
+	}
+	process, err := os.FindProcess(job.PID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	// Same term-then-kill contract as cancelled foreground children.
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		process.Kill()
+	} else {
+		deadline := time.Now().Add(runner.GracePeriod)
+		for time.Now().Before(deadline) && processAlive(job.PID) {
+			time.Sleep(200 * time.Millisecond)
+		}
+		if processAlive(job.PID) {
+			process.Kill()
+		}
+	}
+	fmt.Printf("stopped %q (pid %d)\n", job.Name, job.PID)
+	return 0
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// k8s manages the bridge's cluster manifests. We emit plain
+// Deployment/Service/Ingress YAML rather than a Helm chart — the bridge
+// is one stateless service, and the only things that vary per
+// environment are replicas, image tag, hostname, and the spacetime URI,
+// all of which fit in k8sValues below. `diff`/`apply` wrap kubectl so
+// the --context is always the one matching the environment.
+
+type k8sValues struct {
+	Replicas  int
+	Image     string
+	Host      string
+	Spacetime string
+	Context   string
+}
+
+var k8sEnvValues = map[string]k8sValues{
+	"staging": {
+		Replicas:  1,
+		Image:     "ghcr.io/hipsterreed/lurelands-bridge:staging",
+		Host:      "bridge-staging.lurelands.com",
+		Spacetime: "wss://maincloud.spacetimedb.com",
+		Context:   "lurelands-staging",
+	},
+	"prod": {
+		Replicas:  3,
+		Image:     "ghcr.io/hipsterreed/lurelands-bridge:prod",
+		Host:      "bridge.lurelands.com",
+		Spacetime: "wss://maincloud.spacetimedb.com",
+		Context:   "lurelands-prod",
+	},
+}
+
+func k8sManifestPath(rootDir, envName string) string {
+	return filepath.Join(rootDir, "infra", "k8s", "bridge-"+envName+".yaml")
+}
+
+func renderBridgeManifests(env environment, values k8sValues) string {
+	return fmt.Sprintf(`# Generated by lurelands k8s generate — do not edit by hand.
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bridge
+  labels:
+    app: bridge
+    env: %[1]s
+spec:
+  replicas: %[2]d
+  selector:
+    matchLabels:
+      app: bridge
+  template:
+    metadata:
+      labels:
+        app: bridge
+    spec:
+      containers:
+        - name: bridge
+          image: %[3]s
+          ports:
+            - containerPort: 8080
+          env:
+            - name: SPACETIME_URI
+              value: %[4]s
+            - name: LURELANDS_MODULE
+              value: %[5]s
+          readinessProbe:
+            httpGet:
+              path: /health
+              port: 8080
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: bridge
+spec:
+  selector:
+    app: bridge
+  ports:
+    - port: 80
+      targetPort: 8080
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: bridge
+  annotations:
+    cert-manager.io/cluster-issuer: letsencrypt
+spec:
+  rules:
+    - host: %[6]s
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: bridge
+                port:
+                  number: 80
+  tls:
+    - hosts:
+        - %[6]s
+      secretName: bridge-tls
+`, env.Name, values.Replicas, values.Image, values.Spacetime, env.Module, values.Host)
+}
+
+func resolveK8sEnv(name string) (environment, k8sValues, error) {
+	env, err := resolveEnvironment(name)
+	if err != nil {
+		return environment{}, k8sValues{}, err
+	}
+	values, ok := k8sEnvValues[env.Name]
+	if !ok {
+		return environment{}, k8sValues{}, fmt.Errorf("no cluster configured for %s (only staging and prod run in k8s)", env.Name)
+	}
+	return env, values, nil
+}
+
+func runK8sGenerate(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("k8s generate", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, values, err := resolveK8sEnv(envOrCurrent(*envName, "staging"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	path := k8sManifestPath(rootDir, env.Name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if err := os.WriteFile(path, []byte(renderBridgeManifests(env, values)), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Println("wrote", path)
+	return 0
+}
+
+func runKubectl(ctx context.Context, rootDir, envName, verb string) int {
+	env, values, err := resolveK8sEnv(envName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if _, ok := lookupTool(rootDir, "kubectl"); !ok {
+		return reportError(errToolNotFound("kubectl", "managing the bridge cluster"))
+	}
+	path := k8sManifestPath(rootDir, env.Name)
+	if !fileExists(path) {
+		fmt.Fprintln(os.Stderr, "error: no manifests yet — run `lurelands k8s generate --env "+env.Name+"` first")
+		return 1
+	}
+	result, err := runner.RunStreaming(ctx, runner.Spec{
+		Name: "kubectl",
+		Args: []string{"--context", values.Context, verb, "-f", path},
+		Dir:  rootDir,
+	}, func(line string, isStderr bool) {
+		if isStderr {
+			fmt.Fprintln(os.Stderr, line)
+		} else {
+			fmt.Println(line)
+		}
+	})
+	if err != nil && result.ExitCode == 0 {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	// kubectl diff exits 1 when there are differences — that is the answer,
+	// not a failure.
+	return result.ExitCode
+}
+
+func runK8sDiff(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("k8s diff", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	return runKubectl(ctx, rootDir, envOrCurrent(*envName, "staging"), "diff")
+}
+
+func runK8sApply(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("k8s apply", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	return runKubectl(ctx, rootDir, envOrCurrent(*envName, "staging"), "apply")
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// l10n:check cross-references the strings the game needs (content entries
+// and l10n keys referenced from Dart code) against the ARB files in
+// apps/lurelands/lib/l10n, reporting missing, unused, and untranslated keys.
+
+const baseLocale = "en"
+
+var dartL10nRef = regexp.MustCompile(`\bl10n\.([A-Za-z][A-Za-z0-9_]*)`)
+
+// expectedContentKeys lists the ARB keys every content entry is expected to
+// have: <id>_name and <id>_description.
+func expectedContentKeys(set *ContentSet) []string {
+	var keys []string
+	addEntry := func(id string) {
+		keys = append(keys, id+"_name", id+"_description")
+	}
+	for _, fish := range set.Fish {
+		addEntry(fish.ID)
+	}
+	for _, lure := range set.Lures {
+		addEntry(lure.ID)
+	}
+	for _, biome := range set.Biomes {
+		addEntry(biome.ID)
+	}
+	return keys
+}
+
+// dartReferencedKeys scans the Flutter app's Dart sources for l10n.<key>
+// references.
+func dartReferencedKeys(rootDir string) ([]string, error) {
+	libDir := filepath.Join(rootDir, "apps", "lurelands", "lib")
+	seen := map[string]bool{}
+	err := filepath.WalkDir(libDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".dart") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range dartL10nRef.FindAllStringSubmatch(string(data), -1) {
+			seen[match[1]] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// loadARBFiles returns locale -> key -> value for every app_<locale>.arb.
+// Metadata keys (@-prefixed) are skipped.
+func loadARBFiles(rootDir string) (map[string]map[string]string, error) {
+	l10nDir := filepath.Join(rootDir, "apps", "lurelands", "lib", "l10n")
+	entries, err := os.ReadDir(l10nDir)
+	if err != nil {
+		return nil, fmt.Errorf("no l10n directory at %s: %w", l10nDir, err)
+	}
+	locales := map[string]map[string]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "app_") || !strings.HasSuffix(name, ".arb") {
+			continue
+		}
+		locale := strings.TrimSuffix(strings.TrimPrefix(name, "app_"), ".arb")
+		var raw map[string]any
+		if err := readJSONFile(filepath.Join(l10nDir, name), &raw); err != nil {
+			return nil, err
+		}
+		keys := map[string]string{}
+		for key, value := range raw {
+			if strings.HasPrefix(key, "@") {
+				continue
+			}
+			if s, ok := value.(string); ok {
+				keys[key] = s
+			}
+		}
+		locales[locale] = keys
+	}
+	return locales, nil
+}
+
+func runL10nCheck(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("l10n:check", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	set, err := loadContent(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	locales, err := loadARBFiles(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	base, ok := locales[baseLocale]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "error: base locale %q has no ARB file\n", baseLocale)
+		return 1
+	}
+
+	expected := expectedContentKeys(set)
+	dartKeys, err := dartReferencedKeys(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	expected = append(expected, dartKeys...)
+
+	expectedSet := map[string]bool{}
+	for _, key := range expected {
+		expectedSet[key] = true
+	}
+
+	problems := 0
+
+	var missing []string
+	for key := range expectedSet {
+		if _, ok := base[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		fmt.Printf("missing   %s (%s)\n", key, baseLocale)
+	}
+	problems += len(missing)
+
+	var unused []string
+	for key := range base {
+		// appTitle-style keys referenced only at build time by gen_l10n
+		// count as used when Dart references them; content keys must match
+		// a content entry.
+		if !expectedSet[key] {
+			unused = append(unused, key)
+		}
+	}
+	sort.Strings(unused)
+	for _, key := range unused {
+		fmt.Printf("unused    %s (%s)\n", key, baseLocale)
+	}
+
+	localeNames := make([]string, 0, len(locales))
+	for locale := range locales {
+		localeNames = append(localeNames, locale)
+	}
+	sort.Strings(localeNames)
+	for _, locale := range localeNames {
+		if locale == baseLocale {
+			continue
+		}
+		keys := locales[locale]
+		var untranslated []string
+		for key, baseValue := range base {
+			value, ok := keys[key]
+			if !ok || value == baseValue {
+				untranslated = append(untranslated, key)
+			}
+		}
+		sort.Strings(untranslated)
+		for _, key := range untranslated {
+			fmt.Printf("untranslated %s (%s)\n", key, locale)
+		}
+		problems += len(untranslated)
+	}
+
+	fmt.Printf("\nl10n: %d locale(s), %d missing, %d unused\n", len(locales), len(missing), len(unused))
+	if problems > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// lb inspects the module's leaderboard tables. The community Discord bot
+// consumes the JSON output, so keep field names stable.
+
+var leaderboardBoards = []string{
+	"biggest_catch",
+	"total_coins",
+	"fish_caught",
+	"rare_catches",
+}
+
+type leaderboardRow struct {
+	Rank     int     `json:"rank"`
+	PlayerID string  `json:"player_id"`
+	Username string  `json:"username"`
+	Value    float64 `json:"value"`
+	Detail   string  `json:"detail,omitempty"` // e.g. fish name for biggest_catch
+}
+
+func fetchLeaderboard(env environment, board string, limit int) ([]leaderboardRow, error) {
+	query := fmt.Sprintf("SELECT * FROM leaderboard WHERE board = '%s' ORDER BY value DESC LIMIT %d", board, limit)
+	out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module, query, "--json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("querying %s leaderboard on %s: %w", board, env.Name, err)
+	}
+	var rows []leaderboardRow
+	if err := json.Unmarshal(out, &rows); err != nil {
+		return nil, fmt.Errorf("parsing leaderboard rows: %w", err)
+	}
+	for i := range rows {
+		rows[i].Rank = i + 1
+	}
+	return rows, nil
+}
+
+func runLeaderboard(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 || args[0] != "top" {
+		fmt.Fprintln(os.Stderr, "usage: lurelands lb top [--board <name>] [--limit N] [--json]")
+		return 1
+	}
+
+	flags := flag.NewFlagSet("lb top", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	board := flags.String("board", "biggest_catch", "board name ("+strings.Join(leaderboardBoards, ", ")+")")
+	limit := flags.Int("limit", 50, "number of rows")
+	asJSON := flags.Bool("json", false, "emit JSON instead of a table")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 1
+	}
+
+	if !contains(leaderboardBoards, *board) {
+		fmt.Fprintf(os.Stderr, "unknown board %q (available: %s)\n", *board, strings.Join(leaderboardBoards, ", "))
+		return 1
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	rows, err := fetchLeaderboard(env, *board, *limit)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	if *asJSON {
+		data, _ := json.MarshalIndent(rows, "", "  ")
+		fmt.Println(string(data))
+		return 0
+	}
+
+	if len(rows) == 0 {
+		fmt.Printf("no entries on %s (%s)\n", *board, env.Name)
+		return 0
+	}
+	fmt.Printf("%s — top %d on %s\n\n", *board, len(rows), env.Name)
+	fmt.Printf("%4s  %-20s %12s  %s\n", "#", "player", "value", "detail")
+	for _, row := range rows {
+		name := row.Username
+		if name == "" {
+			name = row.PlayerID
+		}
+		fmt.Printf("%4d  %-20s %12.1f  %s\n", row.Rank, name, row.Value, row.Detail)
+	}
+	return 0
+}
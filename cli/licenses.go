@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// licenses aggregates dependency licenses across all three ecosystems —
+// cargo (module), bun (bridge), pub (app) — into one report, because a
+// GPL dependency is a problem no matter which lockfile it came in
+// through. Anything outside the allowlist is flagged; --attribution
+// writes the credits file the app ships. Cargo and bun declare licenses
+// in metadata; pub packages only ship a LICENSE file, so those are
+// classified by a keyword heuristic and unknowns are flagged for a
+// human look.
+
+var licenseAllowlist = []string{
+	"MIT", "Apache-2.0", "BSD-2-Clause", "BSD-3-Clause", "ISC",
+	"Zlib", "Unlicense", "CC0-1.0", "MPL-2.0", "0BSD", "MIT-0",
+}
+
+type depLicense struct {
+	Ecosystem string
+	Name      string
+	Version   string
+	License   string
+}
+
+func licenseAllowed(license string) bool {
+	// Dual licenses ("MIT OR Apache-2.0") pass if any arm is allowed.
+	for _, arm := range strings.FieldsFunc(license, func(r rune) bool { return r == '/' || r == ' ' }) {
+		for _, allowed := range licenseAllowlist {
+			if strings.EqualFold(arm, allowed) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func collectCargoLicenses(ctx context.Context, rootDir string) ([]depLicense, error) {
+	result, err := runner.Run(ctx, runner.Spec{
+		Name: "cargo",
+		Args: []string{"metadata", "--format-version", "1"},
+		Dir:  moduleDir(rootDir),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cargo metadata: %w", err)
+	}
+	var metadata struct {
+		Packages []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			License string `json:"license"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal([]byte(result.Output), &metadata); err != nil {
+		return nil, fmt.Errorf("parsing cargo metadata: %w", err)
+	}
+	deps := make([]depLicense, 0, len(metadata.Packages))
+	for _, pkg := range metadata.Packages {
+		license := pkg.License
+		if license == "" {
+			license = "unknown"
+		}
+		deps = append(deps, depLicense{Ecosystem: "cargo", Name: pkg.Name, Version: pkg.Version, License: license})
+	}
+	return deps, nil
+}
+
+func collectBunLicenses(rootDir string) ([]depLicense, error) {
+	modules := filepath.Join(bridgeDir(rootDir), "node_modules")
+	entries, err := os.ReadDir(modules)
+	if err != nil {
+		return nil, fmt.Errorf("bridge node_modules not found — run bun install first: %w", err)
+	}
+	var deps []depLicense
+	readPkg := func(dir string) {
+		data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+		if err != nil {
+			return
+		}
+		var pkg struct {
+			Name    string          `json:"name"`
+			Version string          `json:"version"`
+			License json.RawMessage `json:"license"`
+		}
+		if json.Unmarshal(data, &pkg) != nil || pkg.Name == "" {
+			return
+		}
+		license := "unknown"
+		var asString string
+		var asObject struct {
+			Type string `json:"type"`
+		}
+		if json.Unmarshal(pkg.License, &asString) == nil && asString != "" {
+			license = asString
+		} else if json.Unmarshal(pkg.License, &asObject) == nil && asObject.Type != "" {
+			license = asObject.Type
+		}
+		deps = append(deps, depLicense{Ecosystem: "bun", Name: pkg.Name, Version: pkg.Version, License: license})
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if strings.HasPrefix(entry.Name(), "@") { // scoped packages nest one level
+			scoped, _ := os.ReadDir(filepath.Join(modules, entry.Name()))
+			for _, sub := range scoped {
+				readPkg(filepath.Join(modules, entry.Name(), sub.Name()))
+			}
+			continue
+		}
+		readPkg(filepath.Join(modules, entry.Name()))
+	}
+	return deps, nil
+}
+
+// classifyLicense guesses a license identifier from LICENSE file text.
+func classifyLicense(text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "mit license") || strings.Contains(lower, "permission is hereby granted, free of charge"):
+		return "MIT"
+	case strings.Contains(lower, "apache license"):
+		return "Apache-2.0"
+	case strings.Contains(lower, "redistribution and use in source and binary forms"):
+		return "BSD-3-Clause"
+	case strings.Contains(lower, "mozilla public license"):
+		return "MPL-2.0"
+	case strings.Contains(lower, "gnu lesser general public license"):
+		return "LGPL"
+	case strings.Contains(lower, "gnu general public license"):
+		return "GPL"
+	case strings.Contains(lower, "this is free and unencumbered software"):
+		return "Unlicense"
+	default:
+		return "unknown"
+	}
+}
+
+func pubCacheDir() string {
+	if cache := os.Getenv("PUB_CACHE"); cache != "" {
+		return cache
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".pub-cache")
+}
+
+type pubPackage struct {
+	Name    string
+	Version string
+}
+
+// lockedPubPackages walks pubspec.lock without a YAML dependency:
+// two-space-indented names under "packages:", deeper "version:" lines
+// beneath them.
+func lockedPubPackages(rootDir string) ([]pubPackage, error) {
+	data, err := os.ReadFile(filepath.Join(appDir(rootDir), "pubspec.lock"))
+	if err != nil {
+		return nil, fmt.Errorf("reading pubspec.lock: %w", err)
+	}
+	var packages []pubPackage
+	name := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "   ") && strings.HasSuffix(strings.TrimSpace(line), ":") {
+			name = strings.TrimSuffix(strings.TrimSpace(line), ":")
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if name == "" || !strings.HasPrefix(trimmed, "version:") {
+			continue
+		}
+		version := strings.Trim(strings.TrimSpace(strings.TrimPrefix(trimmed, "version:")), `"`)
+		packages = append(packages, pubPackage{Name: name, Version: version})
+		name = ""
+	}
+	return packages, nil
+}
+
+func collectPubLicenses(rootDir string) ([]depLicense, error) {
+	packages, err := lockedPubPackages(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	hosted := filepath.Join(pubCacheDir(), "hosted", "pub.dev")
+	deps := make([]depLicense, 0, len(packages))
+	for _, pkg := range packages {
+		license := "unknown"
+		for _, candidate := range []string{"LICENSE", "LICENSE.md", "LICENSE.txt"} {
+			text, err := os.ReadFile(filepath.Join(hosted, pkg.Name+"-"+pkg.Version, candidate))
+			if err == nil {
+				license = classifyLicense(string(text))
+				break
+			}
+		}
+		deps = append(deps, depLicense{Ecosystem: "pub", Name: pkg.Name, Version: pkg.Version, License: license})
+	}
+	return deps, nil
+}
+
+func attributionFile(deps []depLicense) string {
+	byLicense := map[string][]string{}
+	for _, dep := range deps {
+		byLicense[dep.License] = append(byLicense[dep.License], dep.Name+" "+dep.Version)
+	}
+	licenses := make([]string, 0, len(byLicense))
+	for license := range byLicense {
+		licenses = append(licenses, license)
+	}
+	sort.Strings(licenses)
+	var b strings.Builder
+	b.WriteString("# Third-party software\n\nLurelands ships with these open source packages:\n")
+	for _, license := range licenses {
+		sort.Strings(byLicense[license])
+		fmt.Fprintf(&b, "\n## %s\n\n", license)
+		for _, pkg := range byLicense[license] {
+			fmt.Fprintf(&b, "- %s\n", pkg)
+		}
+	}
+	return b.String()
+}
+
+func runLicenses(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("licenses", flag.ContinueOnError)
+	attribution := flags.String("attribution", "", "write the attribution markdown to this path")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	var deps []depLicense
+	collectors := []struct {
+		name string
+		run  func() ([]depLicense, error)
+	}{
+		{"cargo", func() ([]depLicense, error) { return collectCargoLicenses(ctx, rootDir) }},
+		{"bun", func() ([]depLicense, error) { return collectBunLicenses(rootDir) }},
+		{"pub", func() ([]depLicense, error) { return collectPubLicenses(rootDir) }},
+	}
+	for _, collector := range collectors {
+		found, err := collector.run()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %s: %v\n", collector.name, err)
+			continue
+		}
+		deps = append(deps, found...)
+	}
+	if len(deps) == 0 {
+		fmt.Fprintln(os.Stderr, "error: no dependencies found in any ecosystem")
+		return 1
+	}
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].Ecosystem != deps[j].Ecosystem {
+			return deps[i].Ecosystem < deps[j].Ecosystem
+		}
+		return deps[i].Name < deps[j].Name
+	})
+
+	flagged := 0
+	for _, dep := range deps {
+		marker := "  "
+		if !licenseAllowed(dep.License) {
+			marker = "! "
+			flagged++
+		}
+		fmt.Printf("%s%-6s %-40s %-12s %s\n", marker, dep.Ecosystem, dep.Name, dep.Version, dep.License)
+	}
+	fmt.Printf("\n%d dependencies, %d outside the allowlist\n", len(deps), flagged)
+
+	if *attribution != "" {
+		if err := os.WriteFile(*attribution, []byte(attributionFile(deps)), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Println("wrote", *attribution)
+	}
+	if flagged > 0 {
+		return 1
+	}
+	return 0
+}
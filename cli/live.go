@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// live is the launch-day dashboard: a full-screen TUI that polls the prod
+// (or any) environment for headline metrics and redraws on an interval.
+// It intentionally reads aggregate views, not raw tables, so refreshing
+// every few seconds is cheap even mid-event.
+
+const liveRefreshInterval = 3 * time.Second
+
+type liveMetrics struct {
+	PlayersOnline  int     `json:"players_online"`
+	ActiveSessions int     `json:"active_fishing_sessions"`
+	CatchesPerMin  float64 `json:"catches_per_minute"`
+	ErrorsPerMin   float64 `json:"errors_per_minute"`
+	ReducerP95ms   float64 `json:"reducer_p95_ms"`
+	FetchedAt      time.Time
+}
+
+type liveSample struct {
+	metrics liveMetrics
+	err     error
+}
+
+type liveTickMsg time.Time
+
+func fetchLiveMetrics(env environment) liveSample {
+	out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module,
+		"SELECT * FROM live_metrics", "--json").Output()
+	if err != nil {
+		return liveSample{err: fmt.Errorf("querying live_metrics on %s: %w", env.Name, err)}
+	}
+	var rows []liveMetrics
+	if err := json.Unmarshal(out, &rows); err != nil || len(rows) == 0 {
+		return liveSample{err: fmt.Errorf("no live_metrics row on %s", env.Name)}
+	}
+	m := rows[0]
+	m.FetchedAt = time.Now()
+	return liveSample{metrics: m}
+}
+
+type liveModel struct {
+	env     environment
+	sample  liveSample
+	history []liveMetrics // recent samples, for the catches sparkline
+	started time.Time
+}
+
+func (m liveModel) Init() tea.Cmd {
+	return tea.Batch(m.poll(), liveTick())
+}
+
+func (m liveModel) poll() tea.Cmd {
+	env := m.env
+	return func() tea.Msg { return fetchLiveMetrics(env) }
+}
+
+func liveTick() tea.Cmd {
+	return tea.Tick(liveRefreshInterval, func(t time.Time) tea.Msg { return liveTickMsg(t) })
+}
+
+func (m liveModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case tuiKeys.Quit, "ctrl+c", "esc":
+			return m, tea.Quit
+		case tuiKeys.Refresh:
+			return m, m.poll()
+		}
+	case liveTickMsg:
+		return m, tea.Batch(m.poll(), liveTick())
+	case liveSample:
+		m.sample = msg
+		if msg.err == nil {
+			m.history = append(m.history, msg.metrics)
+			if len(m.history) > 40 {
+				m.history = m.history[1:]
+			}
+		}
+	}
+	return m, nil
+}
+
+var (
+	liveTitleStyle = tui.Title
+	liveStatStyle  = tui.Stat
+	liveWarnStyle  = tui.Warn
+	liveDimStyle   = tui.Dim
+)
+
+// sparkline renders recent catches-per-minute as a tiny bar chart.
+func sparkline(history []liveMetrics) string {
+	values := make([]float64, len(history))
+	for i, m := range history {
+		values[i] = m.CatchesPerMin
+	}
+	return tui.Sparkline(values)
+}
+
+func (m liveModel) View() string {
+	s := liveTitleStyle.Render(fmt.Sprintf("lurelands live — %s (%s/%s)", m.env.Name, m.env.Server, m.env.Module)) + "\n\n"
+
+	if m.sample.err != nil {
+		s += liveWarnStyle.Render("error: "+m.sample.err.Error()) + "\n\n"
+		s += liveDimStyle.Render("retrying every " + liveRefreshInterval.String())
+		return s + "\n\n" + liveDimStyle.Render("q quit · r refresh")
+	}
+	if m.sample.metrics.FetchedAt.IsZero() {
+		return s + "connecting…\n"
+	}
+
+	metrics := m.sample.metrics
+	s += fmt.Sprintf("  players online            %s\n", liveStatStyle.Render(fmt.Sprintf("%d", metrics.PlayersOnline)))
+	s += fmt.Sprintf("  active fishing sessions   %s\n", liveStatStyle.Render(fmt.Sprintf("%d", metrics.ActiveSessions)))
+	s += fmt.Sprintf("  catches / minute          %s  %s\n",
+		liveStatStyle.Render(fmt.Sprintf("%.1f", metrics.CatchesPerMin)), sparkline(m.history))
+	errLine := fmt.Sprintf("%.2f", metrics.ErrorsPerMin)
+	if metrics.ErrorsPerMin > 1.0 {
+		errLine = liveWarnStyle.Render(errLine + " ⚠")
+	} else {
+		errLine = liveStatStyle.Render(errLine)
+	}
+	s += fmt.Sprintf("  errors / minute           %s\n", errLine)
+	s += fmt.Sprintf("  reducer p95               %s ms\n", liveStatStyle.Render(fmt.Sprintf("%.1f", metrics.ReducerP95ms)))
+	s += "\n" + liveDimStyle.Render(fmt.Sprintf("updated %s · watching for %s",
+		metrics.FetchedAt.Format("15:04:05"), time.Since(m.started).Round(time.Second)))
+	return s + "\n" + liveDimStyle.Render("q quit · r refresh")
+}
+
+func runLive(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("live", flag.ContinueOnError)
+	envName := flags.String("env", "", "environment to watch")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "prod"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	model := liveModel{env: env, started: time.Now()}
+	if code := requireInteractive("live", "use `lurelands analytics` for point-in-time numbers"); code >= 0 {
+		return code
+	}
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
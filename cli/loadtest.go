@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// loadtest ramps bot traffic against an environment according to a named
+// profile and samples the module while it runs: query round-trip latency,
+// reducer error rate, and players online. The run ends with a Markdown
+// report plus a machine-readable samples.json for later comparison.
+
+type loadStage struct {
+	Bots     int           `json:"bots"`
+	Duration time.Duration `json:"duration"`
+}
+
+type loadProfile struct {
+	Name   string
+	Stages []loadStage
+}
+
+// Profiles are intentionally code, not config — they change rarely and a
+// review on every change is a feature.
+var loadProfiles = map[string]loadProfile{
+	"smoke": {Name: "smoke", Stages: []loadStage{
+		{Bots: 5, Duration: 1 * time.Minute},
+	}},
+	"launch_day": {Name: "launch_day", Stages: []loadStage{
+		{Bots: 10, Duration: 2 * time.Minute},
+		{Bots: 50, Duration: 5 * time.Minute},
+		{Bots: 150, Duration: 10 * time.Minute},
+		{Bots: 50, Duration: 3 * time.Minute},
+	}},
+	"soak": {Name: "soak", Stages: []loadStage{
+		{Bots: 30, Duration: 60 * time.Minute},
+	}},
+}
+
+type loadSample struct {
+	At           time.Time `json:"at"`
+	Bots         int       `json:"bots"`
+	LatencyMs    float64   `json:"latency_ms"`
+	ErrorsPerMin float64   `json:"errors_per_minute"`
+	Players      int       `json:"players_online"`
+}
+
+type loadRunResult struct {
+	Profile  string       `json:"profile"`
+	Env      string       `json:"env"`
+	Started  time.Time    `json:"started"`
+	Samples  []loadSample `json:"samples"`
+	Crashes  int          `json:"bot_crashes"`
+	MaxBots  int          `json:"max_bots"`
+	Duration string       `json:"duration"`
+}
+
+// sampleLatency times one trivial query round trip. Crude, but it tracks
+// the same path every client request takes and needs no module support.
+func sampleLatency(env environment) (float64, error) {
+	start := time.Now()
+	err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module, "SELECT 1", "--json").Run()
+	if err != nil {
+		return 0, err
+	}
+	return float64(time.Since(start).Microseconds()) / 1000, nil
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+func latencyPercentiles(samples []loadSample) (p50, p95, p99 float64) {
+	values := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		if s.LatencyMs > 0 {
+			values = append(values, s.LatencyMs)
+		}
+	}
+	sort.Float64s(values)
+	return percentile(values, 0.50), percentile(values, 0.95), percentile(values, 0.99)
+}
+
+func loadtestReport(result loadRunResult) string {
+	var b strings.Builder
+	p50, p95, p99 := latencyPercentiles(result.Samples)
+
+	fmt.Fprintf(&b, "# Load test report — %s\n\n", result.Profile)
+	fmt.Fprintf(&b, "- environment: %s\n- started: %s\n- duration: %s\n- peak bots: %d\n- bot crash-restarts: %d\n\n",
+		result.Env, result.Started.Format(time.RFC3339), result.Duration, result.MaxBots, result.Crashes)
+
+	b.WriteString("## Latency (query round trip)\n\n")
+	fmt.Fprintf(&b, "| p50 | p95 | p99 |\n| --- | --- | --- |\n| %.1f ms | %.1f ms | %.1f ms |\n\n", p50, p95, p99)
+
+	b.WriteString("## Timeline\n\n")
+	b.WriteString("| time | bots | latency (ms) | errors/min | players |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, s := range result.Samples {
+		fmt.Fprintf(&b, "| %s | %d | %.1f | %.2f | %d |\n",
+			s.At.Format("15:04:05"), s.Bots, s.LatencyMs, s.ErrorsPerMin, s.Players)
+	}
+	return b.String()
+}
+
+func runLoadtest(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("loadtest", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	profileName := flags.String("profile", "smoke", "load profile (smoke, launch_day, soak)")
+	behavior := flags.String("behavior", "fish_loop", "bot behavior during the test")
+	saveName := flags.String("save-baseline", "", "save this run's numbers as a named baseline")
+	compareName := flags.String("compare", "", "compare against a named baseline")
+	threshold := flags.Float64("threshold", 0.15, "allowed regression fraction for --compare")
+	ci := flags.Bool("ci", false, "exit non-zero on regression instead of warning")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	profile, ok := loadProfiles[*profileName]
+	if !ok {
+		names := make([]string, 0, len(loadProfiles))
+		for name := range loadProfiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(os.Stderr, "unknown profile %q (available: %s)\n", *profileName, strings.Join(names, ", "))
+		return 1
+	}
+	if _, err := exec.LookPath("bun"); err != nil {
+		return reportError(errToolNotFound("bun", "run bridge bot scripts"))
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	outDir := filepath.Join(rootDir, "build", "loadtest", time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	result := loadRunResult{Profile: profile.Name, Env: env.Name, Started: time.Now()}
+	supervisor := &botSupervisor{}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	running := 0
+	nextID := 0
+
+	fmt.Printf("loadtest %s on %s -> %s\n", profile.Name, env.Name, outDir)
+
+	for _, stage := range profile.Stages {
+		// Ramp up; ramping down just means waiting for the stage with the
+		// supervisor's done channel shared, so we only ever grow the pool
+		// to the stage target and let extras die off via the stage count.
+		for running < stage.Bots {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				supervisor.run(rootDir, env, id, *behavior, done)
+			}(nextID)
+			nextID++
+			running++
+			time.Sleep(50 * time.Millisecond)
+		}
+		if stage.Bots > result.MaxBots {
+			result.MaxBots = stage.Bots
+		}
+		fmt.Printf("stage: %d bots for %s\n", stage.Bots, stage.Duration)
+
+		stageEnd := time.Now().Add(stage.Duration)
+		for time.Now().Before(stageEnd) {
+			sample := loadSample{At: time.Now(), Bots: running}
+			if ms, err := sampleLatency(env); err == nil {
+				sample.LatencyMs = ms
+			}
+			if live := fetchLiveMetrics(env); live.err == nil {
+				sample.ErrorsPerMin = live.metrics.ErrorsPerMin
+				sample.Players = live.metrics.PlayersOnline
+			}
+			result.Samples = append(result.Samples, sample)
+			time.Sleep(10 * time.Second)
+		}
+	}
+
+	close(done)
+	wg.Wait()
+	supervisor.mu.Lock()
+	result.Crashes = supervisor.crashes
+	supervisor.mu.Unlock()
+	result.Duration = time.Since(result.Started).Round(time.Second).String()
+
+	data, _ := json.MarshalIndent(result, "", "  ")
+	if err := os.WriteFile(filepath.Join(outDir, "samples.json"), data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	report := loadtestReport(result)
+	if err := os.WriteFile(filepath.Join(outDir, "report.md"), []byte(report), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	p50, p95, _ := latencyPercentiles(result.Samples)
+	fmt.Printf("done: p50 %.1f ms, p95 %.1f ms, %d crash-restarts\n", p50, p95, result.Crashes)
+	fmt.Println("report:", filepath.Join(outDir, "report.md"))
+
+	if *saveName != "" {
+		if err := saveBaseline(rootDir, baselineFromRun(*saveName, result)); err != nil {
+			fmt.Fprintln(os.Stderr, "error: saving baseline:", err)
+			return 1
+		}
+		fmt.Printf("saved baseline %q\n", *saveName)
+	}
+	if *compareName != "" {
+		baseline, err := loadBaseline(rootDir, *compareName)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		if compareToBaseline(baselineFromRun("current", result), *baseline, *threshold) {
+			if *ci {
+				fmt.Fprintln(os.Stderr, "error: performance regressed beyond threshold")
+				return 1
+			}
+			fmt.Println("warning: performance regressed beyond threshold")
+		}
+	}
+	return 0
+}
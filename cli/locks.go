@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Per-resource lock files under .lurelands/locks/, so two lurelands
+// invocations (or a daemon plus a manual run) can't publish the module
+// or rewrite local DB state at the same time. A lock is a file whose
+// content is the holder's PID; waiting is a 1s poll with a "held by
+// PID…" message, and locks whose holder is dead are reclaimed rather
+// than requiring a manual rm.
+
+func lockPath(rootDir, resource string) string {
+	return filepath.Join(rootDir, ".lurelands", "locks", resource+".lock")
+}
+
+// acquireResourceLock blocks until the named resource is free (or ctx
+// is cancelled) and returns a release func. Resources are coarse on
+// purpose: "module", "bridge", "localdb".
+func acquireResourceLock(ctx context.Context, rootDir, resource string) (func(), error) {
+	path := lockPath(rootDir, resource)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	waiting := false
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			fmt.Fprintln(f, os.Getpid())
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		holder := lockHolder(path)
+		if holder > 0 && !processAlive(holder) {
+			// Holder died without releasing; reclaim on the next loop.
+			os.Remove(path)
+			continue
+		}
+		if !waiting {
+			if holder > 0 {
+				fmt.Printf("waiting for %s lock held by PID %d…\n", resource, holder)
+			} else {
+				fmt.Printf("waiting for %s lock…\n", resource)
+			}
+			waiting = true
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func lockHolder(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// logs tails the module's reducer logs without leaving the CLI: a
+// wrapper over `spacetime logs --follow` that colorizes severities and
+// optionally filters lines, against whatever environment is current
+// (--env switches between local and maincloud targets).
+
+var logSeverityPattern = regexp.MustCompile(`\b(ERROR|WARN(?:ING)?|INFO|DEBUG|TRACE)\b`)
+
+var logSeverityStyles = map[string]lipgloss.Style{
+	"ERROR": lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true),
+	"WARN":  lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+	"INFO":  lipgloss.NewStyle().Foreground(lipgloss.Color("39")),
+	"DEBUG": lipgloss.NewStyle().Foreground(lipgloss.Color("243")),
+	"TRACE": lipgloss.NewStyle().Foreground(lipgloss.Color("243")),
+}
+
+// colorizeLogLine highlights the first severity token in the line; the
+// rest stays as spacetime printed it.
+func colorizeLogLine(line string) string {
+	match := logSeverityPattern.FindString(line)
+	if match == "" {
+		return line
+	}
+	key := match
+	if strings.HasPrefix(key, "WARN") {
+		key = "WARN"
+	}
+	return strings.Replace(line, match, logSeverityStyles[key].Render(match), 1)
+}
+
+func runLogs(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("logs", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	filter := flags.String("filter", "", "only show lines containing this text")
+	follow := flags.Bool("follow", true, "keep streaming new lines (--follow=false for a snapshot)")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if _, ok := lookupTool(rootDir, "spacetime"); !ok {
+		return reportError(errToolNotFound("spacetime", "tailing module logs"))
+	}
+
+	spacetimeArgs := []string{"logs", "--server", env.Server, env.Module}
+	if *follow {
+		spacetimeArgs = append(spacetimeArgs, "--follow")
+	}
+	fmt.Printf("logs: %s on %s — ctrl+c to stop\n", env.Module, env.Name)
+	result, err := runner.RunStreaming(ctx, runner.Spec{
+		Name: "spacetime",
+		Args: spacetimeArgs,
+	}, func(line string, isStderr bool) {
+		if *filter != "" && !strings.Contains(line, *filter) {
+			return
+		}
+		if isStderr {
+			fmt.Fprintln(os.Stderr, line)
+			return
+		}
+		fmt.Println(colorizeLogLine(line))
+	})
+	if ctx.Err() != nil {
+		return exitCancelled
+	}
+	if err != nil && result.ExitCode == 0 {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return result.ExitCode
+}
@@ -0,0 +1,436 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+	"github.com/hipsterreed/lurelands/cli/pkg/workspace"
+)
+
+// cliVersion is stamped by release:cli via -ldflags; "dev" for source builds.
+var cliVersion = "dev"
+
+// getRootDir locates the monorepo root via pkg/workspace; the string
+// root is still what gets threaded through the command funcs.
+// Precedence: --root, then LURELANDS_ROOT, then the active registered
+// workspace (`lurelands workspace use`), then marker-file discovery
+// upward from the current directory.
+func getRootDir(override string) (string, error) {
+	if override == "" && os.Getenv("LURELANDS_ROOT") == "" {
+		override = activeWorkspaceRoot()
+	}
+	var ws *workspace.Workspace
+	var err error
+	if override != "" {
+		ws, err = workspace.FromRoot(override)
+	} else {
+		ws, err = workspace.Find()
+	}
+	if err != nil {
+		return "", err
+	}
+	slog.Info("resolved workspace root", "root", ws.Root)
+	return ws.Root, nil
+}
+
+// extractRootFlag pulls a global --root out of args, wherever it sits,
+// mirroring how --debug is handled in setupDebugLogging.
+func extractRootFlag(args []string) (rest []string, root string) {
+	rest = args[:0:0]
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--root" && i+1 < len(args):
+			root = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--root="):
+			root = strings.TrimPrefix(args[i], "--root=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return rest, root
+}
+
+// dryRun mirrors runner.DryRun for the parts of the CLI that plan work
+// themselves (pipelines, history) rather than spawning processes.
+var dryRun bool
+
+// extractDryRunFlag pulls a global --dry-run out of args, same
+// contract as extractRootFlag, and arms the runner's dry-run mode.
+func extractDryRunFlag(args []string) []string {
+	rest := args[:0:0]
+	for _, arg := range args {
+		if arg == "--dry-run" {
+			dryRun = true
+			runner.DryRun = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest
+}
+
+// commandTree is the full command surface. Groups nest; legacy colon
+// spellings (content:validate) keep working via dispatch.
+var commandTree = []*command{
+	{
+		name: "content", summary: "edit and manage game content", run: func(ctx context.Context, rootDir string, args []string) int {
+			if len(args) > 0 {
+				fmt.Fprintf(os.Stderr, "unknown content subcommand %q\n", args[0])
+				return 1
+			}
+			return runContentEditor(ctx, rootDir)
+		},
+		children: []*command{
+			{name: "validate", summary: "validate content files against their schemas",
+				run: func(ctx context.Context, rootDir string, args []string) int { return runContentValidate(ctx, rootDir) }},
+			{name: "diff", summary: "diff local content against deployed tables", run: runContentDiff},
+			{name: "push", summary: "push validated content to an environment", run: runContentPush},
+			{name: "seed-dev", summary: "seed the local module with synthetic content", run: runContentSeedDev},
+			{name: "migrate", summary: "apply pending content schema migrations", run: runContentMigrate},
+			{name: "export", summary: "export content tables as Markdown or CSV", run: runContentExport},
+			{name: "maps", summary: "Tiled map checks", children: []*command{
+				{name: "validate", summary: "validate Tiled maps and spawn zones", run: runMapsValidate},
+			}},
+		},
+	},
+	{
+		name: "sim", summary: "spawn and economy simulations",
+		children: []*command{
+			{name: "spawns", summary: "simulate casts against a biome's spawn table", run: runSimSpawns},
+			{name: "report", summary: "rarity/loot distribution report with charts", run: runSimReport},
+			{name: "odds", summary: "catch probability breakdown for one combo", run: runSimOdds},
+		},
+	},
+	{
+		name: "economy", summary: "economy tuning reports",
+		children: []*command{
+			{name: "report", summary: "coins-per-hour curves per biome and pole tier", run: runEconomyReport},
+		},
+	},
+	{
+		name: "l10n", summary: "localization checks",
+		children: []*command{
+			{name: "check", summary: "check content/Dart strings against ARB files", run: runL10nCheck},
+		},
+	},
+	{
+		name: "assets", summary: "asset pipeline",
+		children: []*command{
+			{name: "build", summary: "compress images and pack texture atlases", run: runAssetsBuild},
+			{name: "audio", summary: "convert WAV sources to ogg/m4a and check refs", run: runAssetsAudio},
+			{name: "budget", summary: "check built assets against size budgets", run: runAssetsBudget},
+			{name: "unused", summary: "list asset files nothing references", run: runAssetsUnused},
+			{name: "spritesheets", summary: "merge per-frame exports into strip sheets", run: runAssetsSpritesheets},
+		},
+	},
+	{
+		name: "store", summary: "store listing tooling",
+		children: []*command{
+			{name: "screenshots", summary: "capture the store-listing screenshot set", run: runStoreScreenshots},
+		},
+	},
+	{
+		name: "test", summary: "run service test suites (--watch, --shards, --parallel)", run: runTest,
+		children: []*command{
+			{name: "e2e", summary: "run the integration suite against a fresh stack", run: runTestE2E},
+			{name: "flaky", summary: "re-run suites and report per-test flake rates", run: runTestFlaky},
+			{name: "screenshots", summary: "pixel-diff key screens against baselines", run: runTestScreenshots},
+		},
+	},
+	{
+		name: "db", summary: "local database state",
+		children: []*command{
+			{name: "start", summary: "launch a local spacetime server in the background", run: runDBStart},
+			{name: "stop", summary: "stop the recorded local spacetime server", run: runDBStop},
+			{name: "status", summary: "is the local spacetime server up?", run: runDBStatus},
+			{name: "seed", summary: "load a fixture from fixtures/ into the local module", run: runDBSeed},
+			{name: "snapshot", summary: "save/load named local database states", run: runDBSnapshot},
+			{name: "backup", summary: "archive an environment's tables to a tarball", run: runDBBackup},
+			{name: "restore", summary: "replay a backup archive into an instance", run: runDBRestore},
+			{name: "export", summary: "dump tables for debugging (--anonymize for sharing)", run: runDBExport},
+		},
+	},
+	{
+		name: "check", summary: "stack invariants",
+		children: []*command{
+			{name: "determinism", summary: "replay reducer calls twice and diff final state", run: runCheckDeterminism},
+		},
+	},
+	{
+		name: "cache", summary: "local artifact cache maintenance",
+		children: []*command{
+			{name: "clear", summary: "forget recorded input hashes so every step rebuilds", run: runCacheClear},
+			{name: "prune", summary: "evict cache entries over a size/age budget", run: runCachePrune},
+			{name: "stats", summary: "show cache size and blob counts", run: runCacheStats},
+		},
+	},
+	{
+		name: "compose", summary: "containerized local stack via docker compose",
+		children: []*command{
+			{name: "generate", summary: "write the docker-compose file for the stack", run: runComposeGenerate},
+			{name: "up", summary: "start the containerized stack (detached)", run: runComposeUp},
+			{name: "down", summary: "stop the containerized stack", run: runComposeDown},
+		},
+	},
+	{
+		name: "docker", summary: "bridge image and one-command container stack",
+		children: []*command{
+			{name: "build", summary: "build the bridge into a standalone image", run: runDockerBuild},
+			{name: "up", summary: "generate the compose file if needed and start the stack", run: runDockerUp},
+			{name: "down", summary: "stop the container stack", run: runDockerDown},
+		},
+	},
+	{
+		name: "docs", summary: "browse current data definitions",
+		children: []*command{
+			{name: "serve", summary: "serve content, schema, and route docs locally", run: runDocsServe},
+		},
+	},
+	{
+		name: "ide", summary: "editor and devcontainer setup",
+		children: []*command{
+			{name: "devcontainer", summary: "generate a .devcontainer with the full toolchain", run: runIDEDevcontainer},
+		},
+	},
+	{
+		name: "infra", summary: "provision bridge host, DNS, and buckets",
+		children: []*command{
+			{name: "plan", summary: "plan infrastructure changes for an environment", run: runInfraPlan},
+			{name: "apply", summary: "apply infrastructure changes (interactive)", run: runInfraApply},
+		},
+	},
+	{
+		name: "k8s", summary: "bridge cluster manifests and kubectl wrappers",
+		children: []*command{
+			{name: "generate", summary: "emit bridge Deployment/Service/Ingress manifests", run: runK8sGenerate},
+			{name: "diff", summary: "diff generated manifests against the cluster", run: runK8sDiff},
+			{name: "apply", summary: "apply generated manifests to the cluster", run: runK8sApply},
+		},
+	},
+	{
+		name: "backup", summary: "scheduled database backups",
+		children: []*command{
+			{name: "daemon", summary: "run backups on a cron schedule with rotation", run: runBackupDaemon},
+		},
+	},
+	{
+		name: "monitor", summary: "uptime checks and alerting",
+		children: []*command{
+			{name: "setup", summary: "register health checks for an environment", run: runMonitorSetup},
+			{name: "run", summary: "run the built-in checker loop", run: runMonitorRun},
+			{name: "test-alert", summary: "send a test page to the alert channel", run: runMonitorTestAlert},
+		},
+	},
+	{
+		name: "new", summary: "scaffolding generators",
+		children: []*command{
+			{name: "bridge-handler", summary: "scaffold a bridge HTTP/websocket handler", run: runNewBridgeHandler},
+			{name: "service", summary: "scaffold and register a new service", run: runNewService},
+			{name: "table", summary: "scaffold a module table struct", run: runNewTable},
+			{name: "reducer", summary: "scaffold a module reducer with auth check", run: runNewReducer},
+			{name: "feature", summary: "scaffold a Flutter feature (screen, service, repo, test)", run: runNewFeature},
+		},
+	},
+	{
+		name: "profile", summary: "instrumented profiling of CLI tasks",
+		children: []*command{
+			{name: "build", summary: "run a task with phase/cache/CPU profiling", run: runProfileBuild},
+		},
+	},
+	{
+		name: "bridge", summary: "bridge service tooling",
+		children: []*command{
+			{name: "generate", summary: "regenerate TS bindings when the module schema changed", run: runBridgeGenerate},
+			{name: "deploy", summary: "build, sync, and restart the bridge on its host over SSH", run: runBridgeDeploy},
+			{name: "health", summary: "probe the bridge health endpoint (status, latency, version)", run: runBridgeHealth},
+			{name: "start", summary: "run the bridge with streamed logs (--target for remote)", run: runBridgeStart},
+		},
+	},
+	{
+		name: "release", summary: "cut a game release: bump, tag, build artifacts",
+		run: runRelease,
+		children: []*command{
+			{name: "cli", summary: "cross-compile, publish, and update the brew tap", run: runReleaseCLI},
+		},
+	},
+	{
+		name: "toolchain", summary: "pinned tool versions from .lurelands.toml",
+		children: []*command{
+			{name: "show", summary: "list pinned toolchain versions", run: runToolchainShow},
+			{name: "sync", summary: "install pinned versions via mise/asdf", run: runToolchainSync},
+		},
+	},
+	{name: "devices", summary: "list attached devices/emulators (cached)", run: runDevices},
+	{name: "analytics", summary: "run canned analytics queries (table or CSV)", run: runAnalytics},
+	{name: "audit", summary: "merged security audit across all ecosystems", run: runAudit},
+	{name: "crashes", summary: "triage recent crash groups from Sentry", run: runCrashes},
+	{name: "events", summary: "list, schedule, or cancel timed in-game events", run: runEvents},
+	{name: "lb", summary: "inspect leaderboard tables", run: runLeaderboard},
+	{name: "licenses", summary: "cross-stack dependency license report", run: runLicenses},
+	{name: "player", summary: "live-ops admin actions on a player", run: runPlayer},
+	{name: "live", summary: "real-time launch metrics dashboard", run: runLive},
+	{name: "logs", summary: "tail module logs with colorized severities", run: runLogs,
+		children: []*command{
+			{name: "last", summary: "print the most recent session log", run: runLogsLast},
+		}},
+	{name: "mod", summary: "work the player report / chat moderation queue", run: runModQueue},
+	{name: "flags", summary: "manage per-environment feature flags", run: runFlags},
+	{name: "generate", summary: "regenerate TS and Dart bindings together", run: runGenerate},
+	{name: "attach", summary: "follow a background job's output (ctrl+c detaches)", run: runAttach},
+	{name: "bg", summary: "run a command as a detached background job", run: runBG},
+	{name: "bots", summary: "run scripted headless clients for load/sync testing", run: runBots},
+	{name: "loadtest", summary: "ramp bot load by profile and produce a report", run: runLoadtest},
+	{name: "coverage", summary: "merged coverage report across all services", run: runCoverage},
+	{name: "chaos", summary: "inject scheduled failures into the local stack", run: runChaos},
+	{
+		name: "deploy", summary: "publish the module, then smoke-test (and roll back)",
+		run: runDeploy,
+		children: []*command{
+			{name: "all", summary: "publish the module and deploy the bridge as one operation", run: runDeployAll},
+		},
+	},
+	{name: "dev", summary: "run spacetime + bridge + flutter together", run: runDev},
+	{name: "doctor", summary: "check tools, versions, and workspace layout", run: runDoctor},
+	{name: "init", summary: "bootstrap a fresh checkout (marker file + layout)", run: runInit},
+	{name: "drift", summary: "compare deployed state against this checkout", run: runDrift},
+	{
+		name: "env", summary: "list environments and pin the current one",
+		run: runEnvShow,
+		children: []*command{
+			{name: "use", summary: "set the current environment for commands run without --env", run: runEnvUse},
+			{name: "clear", summary: "unset the current environment", run: runEnvClear},
+			{name: "list", summary: "show per-service .env values (secrets masked)", run: runEnvList},
+			{name: "set", summary: "set a KEY=VALUE in a service's .env file", run: runEnvSet},
+			{name: "unset", summary: "remove a key from a service's .env file", run: runEnvUnset},
+		},
+	},
+	{name: "prebuild", summary: "watch sources and keep build artifacts warm", run: runPrebuild},
+	{name: "ps", summary: "list background jobs", run: runPS},
+	{name: "record", summary: "capture a client session's bridge traffic to a file", run: runRecord},
+	{name: "run", summary: "flutter run on a picked device, mode, and flavor", run: runApp},
+	{name: "sql", summary: "interactive SQL console against the module", run: runSQL},
+	{name: "stats", summary: "playtest telemetry dashboard with sparklines", run: runStats,
+		children: []*command{
+			{name: "tasks", summary: "per-command timing report from history", run: runStatsTasks},
+		}},
+	{name: "stop", summary: "stop a background job gracefully", run: runStop},
+	{name: "status", summary: "workspace health at a glance (backups, …)", run: runStatus},
+	{name: "tunnel", summary: "expose the local bridge publicly for device testing", run: runTunnel},
+	{name: "watch", summary: "regenerate bindings and rebuild the bridge on change", run: runWatch},
+	{name: "replay", summary: "play a recorded session back against a local stack", run: runReplay},
+	{name: "workspace", summary: "register checkouts and switch the active one", run: runWorkspace},
+}
+
+func usage() {
+	fmt.Println("lurelands - dev CLI for the Lurelands monorepo (" + cliVersion + ")")
+	fmt.Println()
+	fmt.Println("Usage: lurelands <command> [subcommand] [flags]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	for _, top := range sortedTopLevel(commandTree) {
+		fmt.Printf("  %-12s %s\n", top.name, top.summary)
+	}
+	fmt.Println()
+	fmt.Println("Global flags: --root <dir>, --dry-run (print what would run), -v/-vv (verbose), --quiet, --debug")
+	fmt.Println()
+	fmt.Println("Run 'lurelands <command> --help' for subcommands and flags.")
+}
+
+func handleDirectCommand(ctx context.Context, args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "help", "--help", "-h":
+			usage()
+			return 0
+		}
+	}
+	args, rootOverride := extractRootFlag(args)
+	rootDir, err := getRootDir(rootOverride)
+	if err != nil {
+		// The workspace registry must be manageable from anywhere, and
+		// init's whole job is creating the root the resolver just
+		// failed to find.
+		if len(args) > 0 && (args[0] == "workspace" || args[0] == "init") {
+			return dispatch(ctx, commandTree, "", args)
+		}
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	mergeCustomCommands(rootDir)
+	mergePipelines(rootDir)
+	mergeEnvironments(rootDir)
+	mergePlugins(rootDir)
+	loadTheme(rootDir)
+	if dryRun {
+		// Nothing will execute, so skip the prod confirmation and keep
+		// history and session logs free of rehearsals.
+		args, _ = extractYesFlag(args)
+		return dispatch(ctx, commandTree, rootDir, args)
+	}
+	args, proceed := confirmDangerous(ctx, rootDir, args)
+	if !proceed {
+		return 1
+	}
+	recordCommand(rootDir, args)
+	stopLog := startSessionLog(rootDir, args)
+	start := time.Now()
+	code := dispatch(ctx, commandTree, rootDir, args)
+	stopLog(code)
+	appendHistory(rootDir, historyEntry{Args: args, ExitCode: code, Duration: time.Since(start), At: time.Now()})
+	notifyCompletion(rootDir, args, code, time.Since(start))
+	return code
+}
+
+func main() {
+	args, logCleanup, err := setupDebugLogging(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+	if len(args) == 0 {
+		usage()
+		os.Exit(0)
+	}
+	args, _, jsonOut, jsonSet := extractCIFlags(args)
+	args = extractDryRunFlag(args)
+	// How long cancelled children get to exit before a force-kill; some
+	// teams want longer for gradle, some want snappier ctrl+c.
+	if grace := os.Getenv("LURELANDS_GRACE_PERIOD"); grace != "" {
+		if d, err := time.ParseDuration(grace); err == nil && d > 0 {
+			runner.GracePeriod = d
+		}
+	}
+	// One cancellation root for the whole process: the first SIGINT or
+	// SIGTERM cancels ctx and every command, child process, and goroutine
+	// is expected to wind down from that. A second signal kills us the
+	// default way because NotifyContext unregisters after cancel.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	restoreStdout := func() *os.File { return os.Stdout }
+	if quiet {
+		restoreStdout = silenceStdout()
+	}
+	start := time.Now()
+	code := handleDirectCommand(ctx, args)
+	if quiet {
+		out := restoreStdout()
+		status := "ok"
+		if code != 0 {
+			status = fmt.Sprintf("failed (exit %d)", code)
+		}
+		fmt.Fprintf(out, "lurelands %s: %s (%s)\n", strings.Join(args, " "), status, time.Since(start).Round(time.Millisecond))
+	}
+	if jsonSet {
+		writeCISummary(args, time.Since(start), code, jsonOut)
+	}
+	stop()
+	logCleanup()
+	os.Exit(code)
+}
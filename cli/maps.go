@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// content:maps:validate parses the Tiled maps under assets/maps and checks
+// the structural rules the game relies on at load time: layer data matches
+// the declared dimensions, tileset sources exist, a collision layer is
+// present, fishing-spot objects reference real biomes, and spawn zones
+// don't overlap.
+
+type tmxMap struct {
+	Width        int              `xml:"width,attr"`
+	Height       int              `xml:"height,attr"`
+	Tilesets     []tmxTileset     `xml:"tileset"`
+	Layers       []tmxLayer       `xml:"layer"`
+	ObjectGroups []tmxObjectGroup `xml:"objectgroup"`
+}
+
+type tmxTileset struct {
+	Source string `xml:"source,attr"`
+}
+
+type tmxLayer struct {
+	Name   string  `xml:"name,attr"`
+	Width  int     `xml:"width,attr"`
+	Height int     `xml:"height,attr"`
+	Data   tmxData `xml:"data"`
+}
+
+type tmxData struct {
+	Encoding string `xml:"encoding,attr"`
+	Text     string `xml:",chardata"`
+}
+
+type tmxObjectGroup struct {
+	Name    string      `xml:"name,attr"`
+	Objects []tmxObject `xml:"object"`
+}
+
+type tmxObject struct {
+	ID         int           `xml:"id,attr"`
+	X          float64       `xml:"x,attr"`
+	Y          float64       `xml:"y,attr"`
+	Width      float64       `xml:"width,attr"`
+	Height     float64       `xml:"height,attr"`
+	Properties []tmxProperty `xml:"properties>property"`
+}
+
+type tmxProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+func (o tmxObject) property(name string) string {
+	for _, p := range o.Properties {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+func (o tmxObject) overlaps(other tmxObject) bool {
+	return o.X < other.X+other.Width && other.X < o.X+o.Width &&
+		o.Y < other.Y+other.Height && other.Y < o.Y+o.Height
+}
+
+// validateMap checks one TMX file and returns violation messages prefixed
+// with the map's file name.
+func validateMap(rootDir, path string, biomeSet map[string]bool) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m tmxMap
+	if err := xml.Unmarshal(data, &m); err != nil {
+		return []string{fmt.Sprintf("%s: invalid XML: %v", filepath.Base(path), err)}, nil
+	}
+
+	name := filepath.Base(path)
+	var violations []string
+	report := func(format string, args ...any) {
+		violations = append(violations, fmt.Sprintf("%s: %s", name, fmt.Sprintf(format, args...)))
+	}
+
+	for _, tileset := range m.Tilesets {
+		resolved := filepath.Join(filepath.Dir(path), filepath.FromSlash(tileset.Source))
+		if _, err := os.Stat(resolved); err != nil {
+			report("tileset source %s does not exist", tileset.Source)
+		}
+	}
+
+	hasCollision := false
+	for _, layer := range m.Layers {
+		if layer.Width != m.Width || layer.Height != m.Height {
+			report("layer %q is %dx%d but the map is %dx%d", layer.Name, layer.Width, layer.Height, m.Width, m.Height)
+		}
+		// The game looks the collision layer up by name; accept the known
+		// misspelling in existing maps but make sure one exists.
+		lower := strings.ToLower(layer.Name)
+		if strings.HasPrefix(lower, "collis") {
+			hasCollision = true
+		}
+		if layer.Data.Encoding == "csv" {
+			cells := strings.Split(strings.TrimSpace(strings.ReplaceAll(layer.Data.Text, "\n", "")), ",")
+			if len(cells) != layer.Width*layer.Height {
+				report("layer %q has %d tiles, expected %d", layer.Name, len(cells), layer.Width*layer.Height)
+			}
+		}
+	}
+	if !hasCollision {
+		report("no collision layer found")
+	}
+
+	var spawnZones []tmxObject
+	for _, group := range m.ObjectGroups {
+		for _, obj := range group.Objects {
+			objType := obj.property("type")
+			if objType == "fishing_spot" || objType == "spawn_zone" {
+				biome := obj.property("biome")
+				if biome == "" {
+					report("object %d in %q (%s) has no biome property", obj.ID, group.Name, objType)
+				} else if !biomeSet[biome] {
+					report("object %d in %q references unknown biome %q", obj.ID, group.Name, biome)
+				}
+			}
+			if objType == "spawn_zone" {
+				spawnZones = append(spawnZones, obj)
+			}
+		}
+	}
+
+	// Spawn zones may touch, but two zones covering the same water would
+	// double-spawn fish — flag any overlap.
+	for i := 0; i < len(spawnZones); i++ {
+		for j := i + 1; j < len(spawnZones); j++ {
+			if spawnZones[i].overlaps(spawnZones[j]) {
+				report("spawn zones %d and %d overlap", spawnZones[i].ID, spawnZones[j].ID)
+			}
+		}
+	}
+
+	return violations, nil
+}
+
+var tmxRef = regexp.MustCompile(`'([A-Za-z0-9_/.-]+\.tmx)'`)
+
+// referencedMaps scans the Flutter app's Dart sources for .tmx file names.
+func referencedMaps(rootDir string) ([]string, error) {
+	libDir := filepath.Join(appDir(rootDir), "lib")
+	seen := map[string]bool{}
+	err := filepath.WalkDir(libDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".dart") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range tmxRef.FindAllStringSubmatch(string(data), -1) {
+			seen[filepath.Base(match[1])] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func runMapsValidate(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("content:maps:validate", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	set, err := loadContent(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	biomeSet := map[string]bool{}
+	for _, biome := range set.Biomes {
+		biomeSet[biome.ID] = true
+	}
+
+	// Only validate maps the game actually loads; assets/maps also holds
+	// older maps kept for reference in Tiled.
+	names, err := referencedMaps(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(os.Stderr, "error: no .tmx files referenced from Dart code")
+		return 1
+	}
+	mapsDir := filepath.Join(appDir(rootDir), "assets", "maps")
+
+	var violations []string
+	for _, name := range names {
+		mapViolations, err := validateMap(rootDir, filepath.Join(mapsDir, name), biomeSet)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		violations = append(violations, mapViolations...)
+	}
+
+	if len(violations) == 0 {
+		fmt.Printf("maps: %d map(s) valid\n", len(names))
+		return 0
+	}
+	for _, v := range violations {
+		fmt.Fprintln(os.Stderr, v)
+	}
+	fmt.Fprintf(os.Stderr, "\nmaps: %d violation(s)\n", len(violations))
+	return 1
+}
@@ -0,0 +1,547 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// menu is the TUI front door: every leaf command in one list, and the
+// selected command's output streamed live into a scrollable viewport
+// instead of dumping the terminal back to the shell. The command runs
+// as a child of the CLI itself (same re-exec trick as the control API),
+// so long-running tasks like flutter run stay usable — scroll while it
+// streams, esc to cancel, and land back on the menu when it exits.
+
+func init() {
+	commandTree = append(commandTree, &command{
+		name: "menu", summary: "browse and run commands from an interactive menu", run: runMenu,
+	})
+}
+
+type menuEntry struct {
+	path    string
+	summary string
+	recent  bool // history rows; excluded from filter matches
+}
+
+// menuFuzzyMatch reports whether pattern is a case-insensitive
+// subsequence of text, and the rune positions that matched.
+func menuFuzzyMatch(pattern, text string) ([]int, bool) {
+	if pattern == "" {
+		return nil, true
+	}
+	var positions []int
+	want := []rune(strings.ToLower(pattern))
+	j := 0
+	for i, r := range []rune(strings.ToLower(text)) {
+		if j < len(want) && r == want[j] {
+			positions = append(positions, i)
+			j++
+		}
+	}
+	return positions, j == len(want)
+}
+
+// highlightRunes re-renders the runes at positions in the accent
+// style; the input must already be padded, since styling adds
+// invisible width.
+func highlightRunes(text string, positions []int) string {
+	if len(positions) == 0 {
+		return text
+	}
+	matched := map[int]bool{}
+	for _, p := range positions {
+		matched[p] = true
+	}
+	var b strings.Builder
+	for i, r := range []rune(text) {
+		if matched[i] {
+			b.WriteString(tui.Accent.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func flattenCommands(tree []*command, prefix string) []menuEntry {
+	var entries []menuEntry
+	for _, node := range tree {
+		if prefix == "" && node.name == "menu" {
+			continue // no menus inside menus
+		}
+		path := strings.TrimSpace(prefix + " " + node.name)
+		if node.run != nil {
+			entries = append(entries, menuEntry{path: path, summary: node.summary})
+		}
+		entries = append(entries, flattenCommands(node.children, path)...)
+	}
+	return entries
+}
+
+type menuOutputMsg string
+type menuDoneMsg struct{ exit int }
+
+type menuModel struct {
+	rootDir string
+	entries []menuEntry
+	cursor  int // index into matches, not entries
+
+	// Fuzzy filter over path and summary, started with the filter key.
+	filtering bool
+	filter    string
+	matches   []int // entry indices that pass the filter
+
+	// dryRun forwards --dry-run to every command the menu launches, so
+	// their would-run plans stream into the viewport instead of running.
+	dryRun bool
+
+	// showHelp overlays the hotkey reference; any key dismisses it.
+	showHelp bool
+
+	running    string
+	viewport   viewport.Model
+	lines      []string
+	output     chan tea.Msg
+	cancel     context.CancelFunc
+	dbUp       bool
+	bridgeUp   bool
+	containers int
+	jobCount   int
+	git        gitInfo
+	finished   bool
+	width      int
+	height     int
+
+	// Multi-select: space marks entries, enter runs the marked set in
+	// list order (or just the cursor entry when nothing is marked).
+	marked  map[int]bool
+	queue   []string
+	results []menuStepResult
+}
+
+type menuStepResult struct {
+	path string
+	exit int
+}
+
+func newMenuModel(rootDir string) menuModel {
+	// Recent history first, so retrying the last few commands is one
+	// keypress from the top of the list.
+	var entries []menuEntry
+	for _, recent := range recentHistory(rootDir, 5) {
+		mark := "ok"
+		if recent.ExitCode != 0 {
+			mark = "failed"
+		}
+		entries = append(entries, menuEntry{
+			path:    strings.Join(recent.Args, " "),
+			summary: fmt.Sprintf("↻ recent — %s, %s", mark, relativeAge(recent.At)),
+			recent:  true,
+		})
+	}
+	bridgeEnv, _ := resolveEnvironment(envOrCurrent("", "local"))
+	containersUp, _ := dockerStackStatus(rootDir)
+	m := menuModel{
+		rootDir:    rootDir,
+		dbUp:       localSpacetimeUp(rootDir),
+		bridgeUp:   probeBridgeHealth(bridgeEnv).healthy(),
+		containers: containersUp,
+		jobCount:   runningJobs(rootDir),
+		git:        loadGitInfo(rootDir),
+		entries:    append(entries, flattenCommands(commandTree, "")...),
+		dryRun:     dryRun,
+		marked:     map[int]bool{},
+		viewport:   viewport.New(80, 20),
+	}
+	m.applyFilter()
+	return m
+}
+
+// applyFilter recomputes the visible entry set. Recent rows are
+// history noise, not commands, so a non-empty filter drops them.
+func (m *menuModel) applyFilter() {
+	m.matches = m.matches[:0]
+	for i, entry := range m.entries {
+		if m.filter != "" && entry.recent {
+			continue
+		}
+		if _, ok := menuFuzzyMatch(m.filter, entry.path+"  "+entry.summary); ok {
+			m.matches = append(m.matches, i)
+		}
+	}
+	if m.cursor >= len(m.matches) {
+		m.cursor = 0
+	}
+}
+
+func (m menuModel) Init() tea.Cmd { return nil }
+
+func waitForOutput(output chan tea.Msg) tea.Cmd {
+	return func() tea.Msg { return <-output }
+}
+
+// startQueue begins executing m.queue from the front; startNext is
+// called again after each step finishes.
+func (m menuModel) startQueue() (tea.Model, tea.Cmd) {
+	m.lines = nil
+	m.results = nil
+	m.finished = false
+	m.viewport.SetContent("")
+	m.viewport.GotoTop()
+	return m.startNext()
+}
+
+func (m menuModel) startNext() (tea.Model, tea.Cmd) {
+	path := m.queue[len(m.results)]
+	self, err := os.Executable()
+	if err != nil {
+		return m, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.running = path
+	m.output = make(chan tea.Msg, 64)
+	if len(m.queue) > 1 {
+		m.lines = append(m.lines, fmt.Sprintf("── %s (%d/%d) ──", path, len(m.results)+1, len(m.queue)))
+	}
+
+	childArgs := append(strings.Fields(path), "--root", m.rootDir)
+	if m.dryRun {
+		childArgs = append(childArgs, "--dry-run")
+	}
+	output := m.output
+	go func() {
+		result, _ := runner.RunStreaming(ctx, runner.Spec{
+			Name: self,
+			Args: childArgs,
+		}, func(line string, _ bool) {
+			output <- menuOutputMsg(line)
+		})
+		output <- menuDoneMsg{exit: result.ExitCode}
+	}()
+	return m, waitForOutput(output)
+}
+
+func (m menuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.viewport.Width = msg.Width
+		m.viewport.Height = msg.Height - 4
+		return m, nil
+
+	case menuOutputMsg:
+		m.lines = append(m.lines, string(msg))
+		atBottom := m.viewport.AtBottom()
+		m.viewport.SetContent(strings.Join(m.lines, "\n"))
+		if atBottom {
+			m.viewport.GotoBottom()
+		}
+		return m, waitForOutput(m.output)
+
+	case menuDoneMsg:
+		m.results = append(m.results, menuStepResult{path: m.running, exit: msg.exit})
+		// A failing step stops the rest of the queue, like a pipeline.
+		if len(m.results) < len(m.queue) && msg.exit == 0 {
+			return m.startNext()
+		}
+		m.finished = true
+		if len(m.queue) > 1 {
+			m.lines = append(m.lines, "", "summary:")
+			for _, result := range m.results {
+				mark := "ok"
+				if result.exit != 0 {
+					mark = " !"
+				}
+				m.lines = append(m.lines, fmt.Sprintf("  %s  %s", mark, result.path))
+			}
+			if len(m.results) < len(m.queue) {
+				m.lines = append(m.lines, fmt.Sprintf("  stopped — %d step(s) not run", len(m.queue)-len(m.results)))
+			}
+			m.viewport.SetContent(strings.Join(m.lines, "\n"))
+			m.viewport.GotoBottom()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.running != "" {
+			return m.updateRunning(msg)
+		}
+		if m.showHelp {
+			m.showHelp = false
+			return m, nil
+		}
+		if m.filtering {
+			return m.updateFiltering(msg)
+		}
+		switch msg.String() {
+		case tuiKeys.Quit, "ctrl+c":
+			return m, tea.Quit
+		case tuiKeys.Filter:
+			m.filtering = true
+			return m, nil
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+		case " ":
+			if len(m.matches) > 0 {
+				idx := m.matches[m.cursor]
+				m.marked[idx] = !m.marked[idx]
+			}
+		case "D":
+			m.dryRun = !m.dryRun
+		case "?":
+			m.showHelp = true
+		case "r":
+			if recent := recentHistory(m.rootDir, 1); len(recent) > 0 {
+				m.queue = []string{strings.Join(recent[0].Args, " ")}
+				return m.startQueue()
+			}
+		case tuiKeys.Logs:
+			m.queue = []string{"logs last"}
+			return m.startQueue()
+		case "g":
+			m.queue = []string{"generate"}
+			return m.startQueue()
+		case "d":
+			m.jumpTo("deploy")
+		case "e":
+			m.cycleEnvironment()
+		case tuiKeys.Run:
+			return m.runSelection()
+		}
+	}
+	return m, nil
+}
+
+// updateFiltering handles keys while the filter prompt is active:
+// typing edits the pattern, arrows still move, enter runs, esc clears.
+func (m menuModel) updateFiltering(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.filtering = false
+		m.filter = ""
+		m.applyFilter()
+		return m, nil
+	case "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case "down":
+		if m.cursor < len(m.matches)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case "backspace":
+		if m.filter != "" {
+			runes := []rune(m.filter)
+			m.filter = string(runes[:len(runes)-1])
+			m.applyFilter()
+		}
+		return m, nil
+	case "enter":
+		m.filtering = false
+		return m.runSelection()
+	}
+	if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+		m.filter += string(msg.Runes)
+		if msg.Type == tea.KeySpace {
+			m.filter += " "
+		}
+		m.applyFilter()
+	}
+	return m, nil
+}
+
+// jumpTo moves the cursor to the first visible entry under the given
+// command path, so `d` lands on the deploy section in one press.
+func (m *menuModel) jumpTo(prefix string) {
+	for i, idx := range m.matches {
+		path := m.entries[idx].path
+		if !m.entries[idx].recent && (path == prefix || strings.HasPrefix(path, prefix+" ")) {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// cycleEnvironment pins the next configured environment, same effect
+// as `env use` (see envOrCurrent for how commands pick it up).
+func (m *menuModel) cycleEnvironment() {
+	names := environmentNames()
+	if len(names) == 0 {
+		return
+	}
+	next := names[0]
+	for i, name := range names {
+		if name == currentEnvName {
+			next = names[(i+1)%len(names)]
+		}
+	}
+	state := loadUserState(m.rootDir)
+	state.CurrentEnv = next
+	saveUserState(m.rootDir, state)
+	currentEnvName = next
+}
+
+func (m menuModel) runSelection() (tea.Model, tea.Cmd) {
+	m.queue = nil
+	for i, entry := range m.entries {
+		if m.marked[i] {
+			m.queue = append(m.queue, entry.path)
+		}
+	}
+	if len(m.queue) == 0 {
+		if len(m.matches) == 0 {
+			return m, nil
+		}
+		m.queue = []string{m.entries[m.matches[m.cursor]].path}
+	}
+	m.marked = map[int]bool{}
+	return m.startQueue()
+}
+
+func (m menuModel) updateRunning(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		if m.cancel != nil {
+			m.cancel()
+		}
+		return m, tea.Quit
+	case "esc":
+		if !m.finished {
+			m.cancel() // cancel first; a second esc returns to the menu
+			return m, nil
+		}
+		m.running = ""
+		return m, nil
+	case "enter":
+		if m.finished {
+			m.running = ""
+		}
+		return m, nil
+	default:
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+}
+
+func (m menuModel) View() string {
+	if m.running != "" {
+		status := "running… esc cancels"
+		if len(m.queue) > 1 {
+			status = fmt.Sprintf("step %d/%d — %s", len(m.results)+1, len(m.queue), status)
+		}
+		if m.finished {
+			last := m.results[len(m.results)-1]
+			status = fmt.Sprintf("exited (%d) — enter/esc returns to menu", last.exit)
+		}
+		return liveTitleStyle.Render("lurelands "+m.running) + "\n" +
+			m.viewport.View() + "\n" +
+			liveDimStyle.Render(status+" · ↑/↓ scroll")
+	}
+
+	if m.showHelp {
+		return liveTitleStyle.Render("lurelands · hotkeys") + "\n\n" +
+			"  r  re-run the last command\n" +
+			"  " + tuiKeys.Logs + "  open the most recent session log\n" +
+			"  d  jump to the deploy section\n" +
+			"  e  switch the pinned environment\n" +
+			"  g  regenerate bindings\n" +
+			"  D  toggle dry-run for launched commands\n" +
+			"  " + tuiKeys.Filter + "  fuzzy filter · space mark · " + tuiKeys.Run + " run · " + tuiKeys.Quit + " quit\n\n" +
+			liveDimStyle.Render("any key returns to the menu")
+	}
+
+	title := "lurelands"
+	if currentEnvName != "" {
+		title += " · env: " + currentEnvName
+	}
+	if m.dbUp {
+		title += " · db: up"
+	} else {
+		title += " · db: down"
+	}
+	if m.containers > 0 {
+		title += fmt.Sprintf(" · containers: %d", m.containers)
+	}
+	if m.jobCount > 0 {
+		title += fmt.Sprintf(" · jobs: %d", m.jobCount)
+	}
+	if m.dryRun {
+		title += " · dry-run"
+	}
+	title += m.git.headerSuffix()
+	s := liveTitleStyle.Render(title) + " " + bridgeUpIndicator(m.bridgeUp) + "\n\n"
+	visible := m.height - 6
+	if visible < 5 {
+		visible = len(m.matches)
+	}
+	start := 0
+	if m.cursor >= visible {
+		start = m.cursor - visible + 1
+	}
+	end := start + visible
+	if end > len(m.matches) {
+		end = len(m.matches)
+	}
+	for i := start; i < end; i++ {
+		entry := m.entries[m.matches[i]]
+		box := "[ ]"
+		if m.marked[m.matches[i]] {
+			box = "[x]"
+		}
+		path := fmt.Sprintf("%-28s", entry.path)
+		if m.filter != "" {
+			positions, _ := menuFuzzyMatch(m.filter, entry.path+"  "+entry.summary)
+			path = highlightRunes(path, positions)
+		}
+		line := fmt.Sprintf("%s %s %s", box, path, tui.Accent.Render(entry.summary))
+		if i == m.cursor {
+			line = tui.Selected.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		s += line + "\n"
+	}
+	if m.filtering || m.filter != "" {
+		prompt := tuiKeys.Filter + m.filter
+		if m.filtering {
+			prompt += "█"
+		}
+		s += "\n" + prompt + liveDimStyle.Render(fmt.Sprintf("  (%d match(es))", len(m.matches)))
+	}
+	return s + "\n" + liveDimStyle.Render("↑/↓ select · space mark · enter run marked (in order) · "+tuiKeys.Filter+" filter · ? hotkeys · "+tuiKeys.Quit+" quit")
+}
+
+func runMenu(ctx context.Context, rootDir string, args []string) int {
+	if code := requireInteractive("menu", "invoke commands directly"); code >= 0 {
+		return code
+	}
+	// The menu only re-execs; dry-run travels to the children via their
+	// flag so plans land in the viewport rather than on raw stdout.
+	runner.DryRun = false
+	if _, err := tea.NewProgram(newMenuModel(rootDir), tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
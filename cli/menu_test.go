@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestMenuFuzzyMatch(t *testing.T) {
+	cases := []struct {
+		pattern, text string
+		want          bool
+		positions     int
+	}{
+		{"", "db seed", true, 0},
+		{"dbs", "db seed", true, 3},
+		{"DBS", "db seed", true, 3},
+		{"seed db", "db seed", false, 0},
+		{"bst", "bridge start", true, 3},
+	}
+	for _, c := range cases {
+		positions, ok := menuFuzzyMatch(c.pattern, c.text)
+		if ok != c.want {
+			t.Errorf("menuFuzzyMatch(%q, %q) = %v, want %v", c.pattern, c.text, ok, c.want)
+		}
+		if ok && len(positions) != c.positions {
+			t.Errorf("menuFuzzyMatch(%q, %q) matched %d positions, want %d", c.pattern, c.text, len(positions), c.positions)
+		}
+	}
+}
+
+func TestMenuJumpTo(t *testing.T) {
+	m := menuModel{entries: []menuEntry{
+		{path: "deploy --env prod", recent: true},
+		{path: "db seed"},
+		{path: "deploy"},
+		{path: "deploy status"},
+	}, matches: []int{0, 1, 2, 3}}
+	m.jumpTo("deploy")
+	if m.cursor != 2 {
+		t.Errorf("jumpTo landed on %d, want 2 (recent rows and prefixes like db skipped)", m.cursor)
+	}
+	m.cursor = 0
+	m.jumpTo("k8s")
+	if m.cursor != 0 {
+		t.Errorf("jumpTo moved to %d on a miss, want unchanged cursor", m.cursor)
+	}
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// mod queue is the moderation inbox: pending player reports and flagged
+// chat messages, worked through one at a time with single-key actions.
+// Every action calls the matching moderation reducer; resolved items move
+// into a paginated history view (tab to switch).
+
+const modHistoryPageSize = 15
+
+type modItem struct {
+	ID         uint64 `json:"id"`
+	Kind       string `json:"kind"` // "report" or "chat"
+	ReporterID string `json:"reporter_id"`
+	TargetID   string `json:"target_id"`
+	Message    string `json:"message"`
+	CreatedAt  int64  `json:"created_unix"`
+	Resolution string `json:"resolution"` // empty while pending
+}
+
+func fetchModQueue(env environment, resolved bool) ([]modItem, error) {
+	cond := "resolution = ''"
+	if resolved {
+		cond = "resolution != ''"
+	}
+	query := fmt.Sprintf("SELECT * FROM moderation_item WHERE %s ORDER BY created_unix", cond)
+	out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module, query, "--json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("querying moderation queue on %s: %w", env.Name, err)
+	}
+	var items []modItem
+	if err := json.Unmarshal(out, &items); err != nil {
+		return nil, fmt.Errorf("parsing moderation rows: %w", err)
+	}
+	return items, nil
+}
+
+func resolveModItem(env environment, id uint64, action string) error {
+	// One reducer per action keeps the module-side audit trail explicit.
+	reducer := map[string]string{
+		"dismiss":  "mod_dismiss",
+		"warn":     "mod_warn",
+		"escalate": "mod_escalate",
+	}[action]
+	return exec.Command("spacetime", "call", "--server", env.Server, env.Module,
+		reducer, fmt.Sprintf("%d", id)).Run()
+}
+
+type modQueueModel struct {
+	env         environment
+	pending     []modItem
+	history     []modItem
+	cursor      int
+	historyMode bool
+	historyPage int
+	status      string
+	loadErr     error
+}
+
+type modQueueLoaded struct {
+	pending []modItem
+	history []modItem
+	err     error
+}
+
+func (m modQueueModel) Init() tea.Cmd { return m.reload() }
+
+func (m modQueueModel) reload() tea.Cmd {
+	env := m.env
+	return func() tea.Msg {
+		pending, err := fetchModQueue(env, false)
+		if err != nil {
+			return modQueueLoaded{err: err}
+		}
+		history, err := fetchModQueue(env, true)
+		if err != nil {
+			return modQueueLoaded{err: err}
+		}
+		return modQueueLoaded{pending: pending, history: history}
+	}
+}
+
+func (m modQueueModel) act(action string) (tea.Model, tea.Cmd) {
+	if m.historyMode || m.cursor >= len(m.pending) {
+		return m, nil
+	}
+	item := m.pending[m.cursor]
+	if err := resolveModItem(m.env, item.ID, action); err != nil {
+		m.status = fmt.Sprintf("error: %s #%d: %v", action, item.ID, err)
+		return m, nil
+	}
+	m.status = fmt.Sprintf("%s #%d (%s)", action, item.ID, item.TargetID)
+	return m, m.reload()
+}
+
+func (m modQueueModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case modQueueLoaded:
+		m.loadErr = msg.err
+		if msg.err == nil {
+			m.pending, m.history = msg.pending, msg.history
+			if m.cursor >= len(m.pending) && m.cursor > 0 {
+				m.cursor = len(m.pending) - 1
+			}
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "tab":
+			m.historyMode = !m.historyMode
+			return m, nil
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.pending)-1 {
+				m.cursor++
+			}
+		case "left", "h":
+			if m.historyMode && m.historyPage > 0 {
+				m.historyPage--
+			}
+		case "right", "l":
+			if m.historyMode && (m.historyPage+1)*modHistoryPageSize < len(m.history) {
+				m.historyPage++
+			}
+		case "d":
+			return m.act("dismiss")
+		case "w":
+			return m.act("warn")
+		case "e":
+			return m.act("escalate")
+		case "r":
+			return m, m.reload()
+		}
+	}
+	return m, nil
+}
+
+var (
+	modSelectedStyle = tui.Selected
+	modKindStyle     = tui.Accent
+)
+
+func modItemLine(item modItem) string {
+	when := time.Unix(item.CreatedAt, 0).Format("01-02 15:04")
+	line := fmt.Sprintf("#%-5d %s %s  %s → %s  %q", item.ID, when,
+		modKindStyle.Render(fmt.Sprintf("%-6s", item.Kind)), item.ReporterID, item.TargetID, shorten(item.Message, 48))
+	if item.Resolution != "" {
+		line += liveDimStyle.Render("  [" + item.Resolution + "]")
+	}
+	return line
+}
+
+func (m modQueueModel) View() string {
+	s := liveTitleStyle.Render("moderation queue — "+m.env.Name) + "\n\n"
+	if m.loadErr != nil {
+		return s + liveWarnStyle.Render("error: "+m.loadErr.Error()) + "\n\n" + liveDimStyle.Render("r retry · q quit")
+	}
+
+	if m.historyMode {
+		start := m.historyPage * modHistoryPageSize
+		end := start + modHistoryPageSize
+		if end > len(m.history) {
+			end = len(m.history)
+		}
+		s += fmt.Sprintf("history (%d–%d of %d)\n\n", start+1, end, len(m.history))
+		for _, item := range m.history[start:end] {
+			s += "  " + modItemLine(item) + "\n"
+		}
+		return s + "\n" + liveDimStyle.Render("←/→ page · tab queue · q quit")
+	}
+
+	if len(m.pending) == 0 {
+		s += "queue is empty 🎉\n"
+	}
+	for i, item := range m.pending {
+		line := modItemLine(item)
+		if i == m.cursor {
+			line = modSelectedStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		s += line + "\n"
+	}
+	s += "\n"
+	if m.status != "" {
+		s += m.status + "\n"
+	}
+	return s + liveDimStyle.Render("d dismiss · w warn · e escalate · tab history · r refresh · q quit")
+}
+
+func runModQueue(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 || args[0] != "queue" {
+		fmt.Fprintln(os.Stderr, "usage: lurelands mod queue [--env <name>]")
+		return 1
+	}
+
+	flags := flag.NewFlagSet("mod queue", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 1
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "prod"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	if code := requireInteractive("mod", "use `lurelands sql` against the report tables"); code >= 0 {
+		return code
+	}
+	if _, err := tea.NewProgram(modQueueModel{env: env}, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// Module schema introspection, for the publish preflight: `spacetime
+// describe --json` gives us tables (with columns) and reducers (with
+// params) for both the local module and whatever is deployed on the
+// target server, and diffSchemas reports what a publish would change.
+// Additions are routine; dropped tables, dropped columns, and changed
+// column types are flagged destructive because the instance migrates by
+// discarding the data they held.
+
+type moduleSchema struct {
+	// table name → column name → type
+	Tables map[string]map[string]string
+	// reducer name → comma-joined param types
+	Reducers map[string]string
+}
+
+// parseDescribeOutput handles the describe JSON loosely — the exact
+// shape has shifted across spacetime releases, so we walk for the
+// fields we need instead of binding a strict struct.
+func parseDescribeOutput(data []byte) (*moduleSchema, error) {
+	var raw struct {
+		Tables []struct {
+			Name    string `json:"name"`
+			Columns []struct {
+				Name string          `json:"name"`
+				Type json.RawMessage `json:"type"`
+			} `json:"columns"`
+		} `json:"tables"`
+		Reducers []struct {
+			Name   string `json:"name"`
+			Params []struct {
+				Type json.RawMessage `json:"type"`
+			} `json:"params"`
+		} `json:"reducers"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing describe output: %w", err)
+	}
+	schema := &moduleSchema{Tables: map[string]map[string]string{}, Reducers: map[string]string{}}
+	for _, table := range raw.Tables {
+		columns := map[string]string{}
+		for _, column := range table.Columns {
+			columns[column.Name] = string(column.Type)
+		}
+		schema.Tables[table.Name] = columns
+	}
+	for _, reducer := range raw.Reducers {
+		var params []string
+		for _, param := range reducer.Params {
+			params = append(params, string(param.Type))
+		}
+		schema.Reducers[reducer.Name] = strings.Join(params, ", ")
+	}
+	return schema, nil
+}
+
+// deployedSchema describes what the target server is running.
+func deployedSchema(ctx context.Context, env environment) (*moduleSchema, error) {
+	out, err := exec.CommandContext(ctx, "spacetime", "describe",
+		"--server", env.Server, env.Module, "--json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("describing %s on %s: %w", env.Module, env.Server, err)
+	}
+	return parseDescribeOutput(out)
+}
+
+// localModuleSchema describes the module as built from this checkout.
+func localModuleSchema(ctx context.Context, rootDir string) (*moduleSchema, error) {
+	cmd := exec.CommandContext(ctx, "spacetime", "describe", "--json")
+	cmd.Dir = moduleDir(rootDir)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("describing the local module: %w", err)
+	}
+	return parseDescribeOutput(out)
+}
+
+type schemaChange struct {
+	desc        string
+	destructive bool
+}
+
+func sortedTableNames(tables map[string]map[string]string) []string {
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedNames(m map[string]string) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// diffSchemas reports what publishing `local` over `deployed` changes.
+func diffSchemas(deployed, local *moduleSchema) []schemaChange {
+	var changes []schemaChange
+	add := func(destructive bool, format string, args ...any) {
+		changes = append(changes, schemaChange{desc: fmt.Sprintf(format, args...), destructive: destructive})
+	}
+
+	for _, table := range sortedTableNames(local.Tables) {
+		old, exists := deployed.Tables[table]
+		if !exists {
+			add(false, "+ table %s", table)
+			continue
+		}
+		for _, column := range sortedNames(local.Tables[table]) {
+			oldType, had := old[column]
+			switch {
+			case !had:
+				add(false, "+ column %s.%s", table, column)
+			case oldType != local.Tables[table][column]:
+				add(true, "! column %s.%s changed type", table, column)
+			}
+		}
+		for _, column := range sortedNames(old) {
+			if _, still := local.Tables[table][column]; !still {
+				add(true, "- column %s.%s (data dropped)", table, column)
+			}
+		}
+	}
+	for _, table := range sortedTableNames(deployed.Tables) {
+		if _, still := local.Tables[table]; !still {
+			add(true, "- table %s (data dropped)", table)
+		}
+	}
+
+	for _, reducer := range sortedNames(local.Reducers) {
+		old, exists := deployed.Reducers[reducer]
+		switch {
+		case !exists:
+			add(false, "+ reducer %s", reducer)
+		case old != local.Reducers[reducer]:
+			add(false, "~ reducer %s signature changed", reducer)
+		}
+	}
+	for _, reducer := range sortedNames(deployed.Reducers) {
+		if _, still := local.Reducers[reducer]; !still {
+			add(false, "- reducer %s", reducer)
+		}
+	}
+	return changes
+}
+
+// schemaPreflight shows the schema diff and reports whether the publish
+// should proceed. Introspection failures warn and wave the deploy
+// through — a flaky describe must not block shipping — but a real diff
+// with destructive changes blocks unless forced.
+func schemaPreflight(ctx context.Context, rootDir string, env environment, force bool) bool {
+	deployed, err := deployedSchema(ctx, env)
+	if err != nil {
+		fmt.Println("  schema diff skipped:", err)
+		return true
+	}
+	local, err := localModuleSchema(ctx, rootDir)
+	if err != nil {
+		fmt.Println("  schema diff skipped:", err)
+		return true
+	}
+	changes := diffSchemas(deployed, local)
+	if len(changes) == 0 {
+		fmt.Println("  schema: no changes")
+		return true
+	}
+	destructive := 0
+	fmt.Printf("  schema: %d change(s) vs %s\n", len(changes), env.Name)
+	for _, change := range changes {
+		fmt.Println("   ", change.desc)
+		if change.destructive {
+			destructive++
+		}
+	}
+	if destructive > 0 && !force {
+		fmt.Printf("  %d destructive change(s) — rerun with --force to publish anyway\n", destructive)
+		return false
+	}
+	return true
+}
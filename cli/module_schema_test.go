@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func schemaFixture() *moduleSchema {
+	return &moduleSchema{
+		Tables: map[string]map[string]string{
+			"player": {"id": `"u64"`, "name": `"string"`},
+			"fish":   {"id": `"u64"`, "weight": `"f64"`},
+		},
+		Reducers: map[string]string{"cast_line": `"u64"`},
+	}
+}
+
+func TestDiffSchemasNoChanges(t *testing.T) {
+	if changes := diffSchemas(schemaFixture(), schemaFixture()); len(changes) != 0 {
+		t.Errorf("identical schemas produced changes: %v", changes)
+	}
+}
+
+func TestDiffSchemasFlagsDestructive(t *testing.T) {
+	deployed := schemaFixture()
+	local := schemaFixture()
+	delete(local.Tables["player"], "name")     // dropped column
+	local.Tables["fish"]["weight"] = `"u32"`   // changed type
+	local.Tables["tournament"] = nil           // new table
+	local.Reducers["sell_fish"] = `"u64, u32"` // new reducer
+
+	changes := diffSchemas(deployed, local)
+	destructive := 0
+	for _, change := range changes {
+		if change.destructive {
+			destructive++
+		}
+	}
+	if destructive != 2 {
+		t.Errorf("want 2 destructive changes, got %d: %v", destructive, changes)
+	}
+	var descs []string
+	for _, change := range changes {
+		descs = append(descs, change.desc)
+	}
+	joined := strings.Join(descs, "\n")
+	for _, want := range []string{"- column player.name", "! column fish.weight", "+ table tournament", "+ reducer sell_fish"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing %q in:\n%s", want, joined)
+		}
+	}
+}
+
+func TestParseDescribeOutput(t *testing.T) {
+	schema, err := parseDescribeOutput([]byte(`{
+		"tables": [{"name": "player", "columns": [{"name": "id", "type": "u64"}]}],
+		"reducers": [{"name": "ping", "params": []}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := schema.Tables["player"]["id"]; !ok {
+		t.Error("player.id not parsed")
+	}
+	if _, ok := schema.Reducers["ping"]; !ok {
+		t.Error("ping reducer not parsed")
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// monitor keeps launch weekend boring. `monitor setup` registers the
+// two checks that matter — the bridge /health endpoint and the module's
+// ping reducer — either with Healthchecks.io when HEALTHCHECKS_API_KEY
+// is set, or in a local monitors.json that `monitor run` polls
+// cron-style. Alerts go to the webhook in LURELANDS_ALERT_WEBHOOK
+// (Slack/Discord both accept the {"text": …} shape), and
+// `monitor test-alert` fires a test page because an alert channel you
+// have never tested does not exist.
+
+type monitorCheck struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"` // "http" or "ping-reducer"
+	URL  string `json:"url,omitempty"`
+	Env  string `json:"env,omitempty"`
+}
+
+func monitorsPath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "monitors.json")
+}
+
+func alertWebhook() string {
+	return os.Getenv("LURELANDS_ALERT_WEBHOOK")
+}
+
+func sendAlert(ctx context.Context, message string) error {
+	webhook := alertWebhook()
+	if webhook == "" {
+		return fmt.Errorf("LURELANDS_ALERT_WEBHOOK is not set")
+	}
+	payload, _ := json.Marshal(map[string]string{"text": message})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func runCheck(ctx context.Context, check monitorCheck) error {
+	switch check.Kind {
+	case "http":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, check.URL, nil)
+		if err != nil {
+			return err
+		}
+		client := http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s returned %s", check.URL, resp.Status)
+		}
+		return nil
+	case "ping-reducer":
+		env, err := resolveEnvironment(check.Env)
+		if err != nil {
+			return err
+		}
+		return smokePing(env)
+	default:
+		return fmt.Errorf("unknown check kind %q", check.Kind)
+	}
+}
+
+func registerWithHealthchecks(ctx context.Context, check monitorCheck, apiKey string) error {
+	payload, _ := json.Marshal(map[string]any{
+		"name":     "lurelands " + check.Name,
+		"timeout":  300,
+		"grace":    120,
+		"unique":   []string{"name"},
+		"channels": "*",
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		"https://healthchecks.io/api/v3/checks/", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Api-Key", apiKey)
+	client := http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("healthchecks.io returned %s", resp.Status)
+	}
+	return nil
+}
+
+func monitorChecksFor(env environment) []monitorCheck {
+	return []monitorCheck{
+		{Name: env.Name + " bridge health", Kind: "http", URL: bridgeHealthURLs[env.Name]},
+		{Name: env.Name + " ping reducer", Kind: "ping-reducer", Env: env.Name},
+	}
+}
+
+func runMonitorSetup(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("monitor setup", flag.ContinueOnError)
+	envName := flags.String("env", "", "environment to monitor")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "prod"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	checks := monitorChecksFor(env)
+	data, _ := json.MarshalIndent(checks, "", "  ")
+	os.MkdirAll(filepath.Dir(monitorsPath(rootDir)), 0o755)
+	if err := os.WriteFile(monitorsPath(rootDir), data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Println("wrote", monitorsPath(rootDir))
+
+	if apiKey := os.Getenv("HEALTHCHECKS_API_KEY"); apiKey != "" {
+		for _, check := range checks {
+			if err := registerWithHealthchecks(ctx, check, apiKey); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: registering %q: %v\n", check.Name, err)
+				continue
+			}
+			fmt.Printf("  registered %q with healthchecks.io\n", check.Name)
+		}
+	} else {
+		fmt.Println("no HEALTHCHECKS_API_KEY — run `lurelands monitor run` for the built-in checker")
+	}
+	return 0
+}
+
+func runMonitorRun(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("monitor run", flag.ContinueOnError)
+	interval := flags.Duration("interval", 60*time.Second, "time between check rounds")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	data, err := os.ReadFile(monitorsPath(rootDir))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error: no monitors configured — run `lurelands monitor setup` first")
+		return 1
+	}
+	var checks []monitorCheck
+	if err := json.Unmarshal(data, &checks); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	fmt.Printf("monitoring %d check(s) every %s — ctrl+c to stop\n", len(checks), interval)
+	failing := map[string]bool{}
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		for _, check := range checks {
+			err := runCheck(ctx, check)
+			stamp := time.Now().Format("15:04:05")
+			switch {
+			case err != nil && !failing[check.Name]:
+				failing[check.Name] = true
+				fmt.Printf("[%s] DOWN %s: %v\n", stamp, check.Name, err)
+				if alertErr := sendAlert(ctx, "🔴 "+check.Name+" is down: "+err.Error()); alertErr != nil {
+					fmt.Fprintln(os.Stderr, "warning: alert not delivered:", alertErr)
+				}
+			case err == nil && failing[check.Name]:
+				failing[check.Name] = false
+				fmt.Printf("[%s] RECOVERED %s\n", stamp, check.Name)
+				sendAlert(ctx, "🟢 "+check.Name+" recovered")
+			case err == nil:
+				fmt.Printf("[%s] ok %s\n", stamp, check.Name)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return exitCancelled
+		case <-ticker.C:
+		}
+	}
+}
+
+func runMonitorTestAlert(ctx context.Context, rootDir string, args []string) int {
+	host, _ := os.Hostname()
+	message := fmt.Sprintf("🔔 test alert from lurelands monitor on %s — if you can read this, paging works", host)
+	if err := sendAlert(ctx, message); err != nil {
+		fmt.Fprintln(os.Stderr, "error: test alert failed:", err)
+		return 1
+	}
+	fmt.Println("test alert sent — confirm it reached the channel/pager")
+	return 0
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// new:bridge-handler scaffolds a bridge endpoint the way we already
+// write them: a handler module under src/handlers/, a test beside the
+// existing ones, and the route registration appended to src/routes.ts.
+// The templates encode the service's conventions (zod validation at the
+// boundary, handler returns plain data, errors thrown as HttpError) so
+// a new endpoint starts consistent instead of being pasted from the
+// nearest old one.
+
+var identPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// camelCase turns "player-stats" into "playerStats".
+func camelCase(kebab string) string {
+	parts := strings.Split(kebab, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func bridgeHandlerTemplate(name, ident string, ws bool) string {
+	if ws {
+		return fmt.Sprintf(`import { z } from "zod";
+import type { GameConnection } from "../connection";
+
+const %[2]sMessage = z.object({
+  // TODO: declare the inbound message shape
+});
+
+export function handle%[3]s(conn: GameConnection, raw: unknown) {
+  const msg = %[2]sMessage.parse(raw);
+  // TODO: implement %[1]s
+}
+`, name, ident, strings.ToUpper(ident[:1])+ident[1:])
+	}
+	return fmt.Sprintf(`import { z } from "zod";
+import { HttpError } from "../errors";
+
+const %[2]sQuery = z.object({
+  // TODO: declare the query/body shape
+});
+
+export async function %[2]sHandler(raw: unknown) {
+  const query = %[2]sQuery.parse(raw);
+  // TODO: implement %[1]s
+  throw new HttpError(501, "%[1]s not implemented");
+}
+`, name, ident)
+}
+
+func bridgeHandlerTestTemplate(name, ident string) string {
+	return fmt.Sprintf(`import { describe, expect, it } from "bun:test";
+import { %[2]sHandler } from "../src/handlers/%[1]s";
+
+describe("%[1]s", () => {
+  it("rejects malformed input", () => {
+    expect(%[2]sHandler(null)).rejects.toThrow();
+  });
+});
+`, name, ident)
+}
+
+// registerBridgeRoute appends the registration line to src/routes.ts,
+// just above the trailing export/closing brace if one exists.
+func registerBridgeRoute(routesPath, name, ident string, ws bool) error {
+	line := fmt.Sprintf(`app.get("/%s", %sHandler);`, name, ident)
+	if ws {
+		line = fmt.Sprintf(`registerMessage("%s", handle%s);`, ident, strings.ToUpper(ident[:1])+ident[1:])
+	}
+	importLine := fmt.Sprintf(`import { %sHandler } from "./handlers/%s";`, ident, name)
+	if ws {
+		importLine = fmt.Sprintf(`import { handle%s } from "./handlers/%s";`, strings.ToUpper(ident[:1])+ident[1:], name)
+	}
+
+	data, err := os.ReadFile(routesPath)
+	if err != nil {
+		return err
+	}
+	contents := importLine + "\n" + string(data)
+	if !strings.HasSuffix(contents, "\n") {
+		contents += "\n"
+	}
+	contents += line + "\n"
+	return os.WriteFile(routesPath, []byte(contents), 0o644)
+}
+
+func runNewBridgeHandler(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 || !identPattern.MatchString(args[0]) {
+		fmt.Fprintln(os.Stderr, "usage: lurelands new bridge-handler <kebab-case-name> [--ws]")
+		return exitUsage
+	}
+	name := args[0]
+	flags := flag.NewFlagSet("new bridge-handler", flag.ContinueOnError)
+	ws := flags.Bool("ws", false, "websocket message handler instead of HTTP")
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+	ident := camelCase(name)
+
+	bridge := bridgeDir(rootDir)
+	if !fileExists(bridge) {
+		return reportError(errWorkdirMissing(bridge))
+	}
+
+	handlerPath := filepath.Join(bridge, "src", "handlers", name+".ts")
+	if fileExists(handlerPath) {
+		fmt.Fprintf(os.Stderr, "error: %s already exists\n", handlerPath)
+		return 1
+	}
+	testPath := filepath.Join(bridge, "test", name+".test.ts")
+
+	writes := map[string]string{
+		handlerPath: bridgeHandlerTemplate(name, ident, *ws),
+	}
+	if !*ws {
+		writes[testPath] = bridgeHandlerTestTemplate(name, ident)
+	}
+	for path, contents := range writes {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Println("wrote", path)
+	}
+
+	routesPath := filepath.Join(bridge, "src", "routes.ts")
+	if fileExists(routesPath) {
+		if err := registerBridgeRoute(routesPath, name, ident, *ws); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: could not register route:", err)
+		} else {
+			fmt.Println("registered in", routesPath)
+		}
+	} else {
+		fmt.Println("note: no src/routes.ts found — register the handler manually")
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// new:feature stamps out the app-side skeleton for a new piece of
+// gameplay UI the way the existing screens are laid out: a screen under
+// lib/screens/, a ChangeNotifier service under lib/services/, a
+// repository under lib/data/ that will talk to the generated bindings,
+// and a widget test. Names follow the <name>_screen.dart convention the
+// existing files use.
+
+func featureScreenTemplate(name string) string {
+	pascal := pascalCase(name)
+	return fmt.Sprintf(`import 'package:flutter/material.dart';
+
+import '../services/%[1]s_service.dart';
+
+class %[2]sScreen extends StatefulWidget {
+  const %[2]sScreen({super.key});
+
+  @override
+  State<%[2]sScreen> createState() => _%[2]sScreenState();
+}
+
+class _%[2]sScreenState extends State<%[2]sScreen> {
+  final _service = %[2]sService();
+
+  @override
+  Widget build(BuildContext context) {
+    return Scaffold(
+      appBar: AppBar(title: const Text('%[2]s')),
+      body: const Center(
+        // TODO: build the %[1]s UI
+        child: Text('%[1]s'),
+      ),
+    );
+  }
+}
+`, snakeCase(name), pascal)
+}
+
+func featureServiceTemplate(name string) string {
+	pascal := pascalCase(name)
+	return fmt.Sprintf(`import 'package:flutter/foundation.dart';
+
+import '../data/%[1]s_repository.dart';
+
+/// State holder for the %[1]s feature; widgets listen and rebuild.
+class %[2]sService extends ChangeNotifier {
+  %[2]sService({%[2]sRepository? repository})
+      : _repository = repository ?? %[2]sRepository();
+
+  final %[2]sRepository _repository;
+
+  bool _loading = false;
+  bool get loading => _loading;
+
+  Future<void> refresh() async {
+    _loading = true;
+    notifyListeners();
+    try {
+      await _repository.fetch();
+    } finally {
+      _loading = false;
+      notifyListeners();
+    }
+  }
+}
+`, snakeCase(name), pascal)
+}
+
+func featureRepositoryTemplate(name string) string {
+	pascal := pascalCase(name)
+	return fmt.Sprintf(`/// Data access for the %[1]s feature. Talks to the generated module
+/// bindings; keep widget code out of here.
+class %[2]sRepository {
+  Future<void> fetch() async {
+    // TODO: subscribe to / query the relevant module tables
+  }
+}
+`, snakeCase(name), pascal)
+}
+
+func featureTestTemplate(name string) string {
+	pascal := pascalCase(name)
+	return fmt.Sprintf(`import 'package:flutter/material.dart';
+import 'package:flutter_test/flutter_test.dart';
+import 'package:lurelands/screens/%[1]s_screen.dart';
+
+void main() {
+  testWidgets('%[1]s screen renders', (tester) async {
+    await tester.pumpWidget(const MaterialApp(home: %[2]sScreen()));
+    expect(find.byType(%[2]sScreen), findsOneWidget);
+  });
+}
+`, snakeCase(name), pascal)
+}
+
+func runNewFeature(ctx context.Context, rootDir string, args []string) int {
+	if len(args) != 1 || !identPattern.MatchString(args[0]) {
+		fmt.Fprintln(os.Stderr, "usage: lurelands new feature <kebab-case-name>")
+		return exitUsage
+	}
+	name := args[0]
+	snake := snakeCase(name)
+
+	app := appDir(rootDir)
+	if !fileExists(app) {
+		return reportError(errWorkdirMissing(app))
+	}
+
+	files := map[string]string{
+		filepath.Join(app, "lib", "screens", snake+"_screen.dart"):   featureScreenTemplate(name),
+		filepath.Join(app, "lib", "services", snake+"_service.dart"): featureServiceTemplate(name),
+		filepath.Join(app, "lib", "data", snake+"_repository.dart"):  featureRepositoryTemplate(name),
+		filepath.Join(app, "test", snake+"_screen_test.dart"):        featureTestTemplate(name),
+	}
+	for path := range files {
+		if fileExists(path) {
+			fmt.Fprintf(os.Stderr, "error: %s already exists\n", path)
+			return 1
+		}
+	}
+	for path, contents := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Println("wrote", path)
+	}
+	fmt.Printf("feature %s scaffolded — wire %sScreen into navigation to use it\n", name, pascalCase(name))
+	return 0
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// new:table and new:reducer generate the SpacetimeDB module boilerplate
+// that everyone otherwise copies from the nearest existing table: the
+// table struct with a primary key and index, and a reducer skeleton
+// with the sender auth check that every mutating reducer must start
+// with. Generated files land under src/generated_stubs/ in the module
+// crate; moving the code into its final home is a human decision, but
+// the shape is right from the start. Both generators offer to refresh
+// client bindings since the schema just changed.
+
+func snakeCase(kebab string) string {
+	return strings.ReplaceAll(kebab, "-", "_")
+}
+
+func pascalCase(kebab string) string {
+	parts := strings.Split(kebab, "-")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func tableTemplate(name string) string {
+	snake, pascal := snakeCase(name), pascalCase(name)
+	return fmt.Sprintf(`use spacetimedb::{table, Identity, Timestamp};
+
+#[table(name = %[1]s, public)]
+pub struct %[2]s {
+    #[primary_key]
+    #[auto_inc]
+    pub id: u64,
+    #[index(btree)]
+    pub owner: Identity,
+    pub created_at: Timestamp,
+    // TODO: columns for %[1]s
+}
+`, snake, pascal)
+}
+
+func reducerTemplate(name string) string {
+	snake := snakeCase(name)
+	return fmt.Sprintf(`use spacetimedb::{reducer, ReducerContext};
+
+#[reducer]
+pub fn %[1]s(ctx: &ReducerContext) -> Result<(), String> {
+    // Auth first: every mutating reducer validates the sender before
+    // touching state.
+    let _player = ctx
+        .db
+        .player()
+        .identity()
+        .find(ctx.sender)
+        .ok_or("unknown player")?;
+
+    // TODO: implement %[1]s
+    Ok(())
+}
+`, snake)
+}
+
+func writeModuleStub(ctx context.Context, rootDir, kind, name, contents string, regen bool) int {
+	module := moduleDir(rootDir)
+	if !fileExists(module) {
+		return reportError(errWorkdirMissing(module))
+	}
+	path := filepath.Join(module, "src", "generated_stubs", kind+"_"+snakeCase(name)+".rs")
+	if fileExists(path) {
+		fmt.Fprintf(os.Stderr, "error: %s already exists\n", path)
+		return 1
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Println("wrote", path)
+	fmt.Println("note: add `mod generated_stubs;` (or move the code) before building")
+
+	if regen {
+		if err := ensureBridgeBindings(ctx, rootDir, true); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: binding regeneration failed:", err)
+		}
+	} else {
+		fmt.Println("run `lurelands bridge generate` once the module builds to refresh bindings")
+	}
+	return 0
+}
+
+func runNewTable(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 || !identPattern.MatchString(args[0]) {
+		fmt.Fprintln(os.Stderr, "usage: lurelands new table <kebab-case-name> [--regen]")
+		return exitUsage
+	}
+	flags := flag.NewFlagSet("new table", flag.ContinueOnError)
+	regen := flags.Bool("regen", false, "regenerate client bindings afterwards")
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+	return writeModuleStub(ctx, rootDir, "table", args[0], tableTemplate(args[0]), *regen)
+}
+
+func runNewReducer(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 || !identPattern.MatchString(args[0]) {
+		fmt.Fprintln(os.Stderr, "usage: lurelands new reducer <kebab-case-name> [--regen]")
+		return exitUsage
+	}
+	flags := flag.NewFlagSet("new reducer", flag.ContinueOnError)
+	regen := flags.Bool("regen", false, "regenerate client bindings afterwards")
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+	return writeModuleStub(ctx, rootDir, "reducer", args[0], reducerTemplate(args[0]), *regen)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// new:service stamps out a service directory under services/ and
+// registers it in .lurelands.toml, which is all the wiring the rest of
+// the CLI needs — the dev orchestrator and doctor work off the registry
+// rather than a hardcoded service list.
+
+func bunServiceFiles(name string) map[string]string {
+	return map[string]string{
+		"package.json": fmt.Sprintf(`{
+  "name": "%s",
+  "private": true,
+  "scripts": {
+    "start": "bun run src/index.ts",
+    "build": "bun build src/index.ts --outdir dist --target bun",
+    "test": "bun test"
+  }
+}
+`, name),
+		"src/index.ts": fmt.Sprintf(`console.log("%s up");
+`, name),
+		"test/index.test.ts": `import { expect, it } from "bun:test";
+
+it("starts", () => {
+  expect(true).toBe(true);
+});
+`,
+	}
+}
+
+func rustServiceFiles(name string) map[string]string {
+	crate := strings.ReplaceAll(name, "-", "_")
+	return map[string]string{
+		"Cargo.toml": fmt.Sprintf(`[package]
+name = "%s"
+version = "0.1.0"
+edition = "2021"
+
+[dependencies]
+`, crate),
+		"src/main.rs": fmt.Sprintf(`fn main() {
+    println!("%s up");
+}
+`, name),
+	}
+}
+
+func runNewService(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 || !identPattern.MatchString(args[0]) {
+		fmt.Fprintln(os.Stderr, "usage: lurelands new service <kebab-case-name> [--template bun|rust]")
+		return exitUsage
+	}
+	name := args[0]
+	flags := flag.NewFlagSet("new service", flag.ContinueOnError)
+	template := flags.String("template", "bun", "service template: bun or rust")
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+
+	var files map[string]string
+	var tasks serviceTasks
+	switch *template {
+	case "bun":
+		files = bunServiceFiles(name)
+		tasks = serviceTasks{Name: name, Dev: "bun run start", Build: "bun run build", Test: "bun test"}
+	case "rust":
+		files = rustServiceFiles(name)
+		tasks = serviceTasks{Name: name, Dev: "cargo run", Build: "cargo build --release", Test: "cargo test"}
+	default:
+		fmt.Fprintf(os.Stderr, "error: unknown template %q (bun or rust)\n", *template)
+		return exitUsage
+	}
+
+	dir := serviceDir(rootDir, name)
+	if fileExists(dir) {
+		fmt.Fprintf(os.Stderr, "error: %s already exists\n", dir)
+		return 1
+	}
+	for _, service := range registeredServices(rootDir) {
+		if service.Name == name {
+			fmt.Fprintf(os.Stderr, "error: service %q is already registered\n", name)
+			return 1
+		}
+	}
+
+	for rel, contents := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Println("wrote", path)
+	}
+
+	if err := appendServiceSection(rootDir, tasks); err != nil {
+		fmt.Fprintln(os.Stderr, "error: registering service:", err)
+		return 1
+	}
+	fmt.Printf("registered [service.%s] in .lurelands.toml\n", name)
+	return 0
+}
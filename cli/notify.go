@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Completion notifications, for the "kick off a deploy, switch
+// windows, never notice it failed" problem. Opt-in via a [notify]
+// section in .lurelands.toml:
+//
+//	[notify]
+//	threshold = "90s"   # only commands that ran at least this long
+//	desktop = "true"    # osascript / notify-send / powershell toast
+//	bell = "true"       # terminal bell — tmux and most terminals flag it
+//
+// Notification failures are swallowed: a missing notify-send must never
+// turn a green deploy red.
+
+const defaultNotifyThreshold = 90 * time.Second
+
+type notifySettings struct {
+	threshold time.Duration
+	desktop   bool
+	bell      bool
+}
+
+func loadNotifySettings(rootDir string) notifySettings {
+	section := loadConfigSections(rootDir)["notify"]
+	if section == nil {
+		return notifySettings{}
+	}
+	settings := notifySettings{
+		threshold: defaultNotifyThreshold,
+		desktop:   section["desktop"] == "true",
+		bell:      section["bell"] == "true",
+	}
+	if d, err := time.ParseDuration(section["threshold"]); err == nil && d > 0 {
+		settings.threshold = d
+	}
+	return settings
+}
+
+// notifyCompletion fires after dispatch for commands that ran longer
+// than the threshold. CI mode never notifies.
+func notifyCompletion(rootDir string, args []string, exitCode int, took time.Duration) {
+	settings := loadNotifySettings(rootDir)
+	if ciMode || (!settings.desktop && !settings.bell) || took < settings.threshold {
+		return
+	}
+	status := "succeeded"
+	if exitCode != 0 {
+		status = fmt.Sprintf("FAILED (exit %d)", exitCode)
+	}
+	command := strings.Join(args, " ")
+	message := fmt.Sprintf("lurelands %s %s after %s", command, status, took.Round(time.Second))
+	if settings.bell {
+		fmt.Print("\a")
+	}
+	if settings.desktop {
+		sendDesktopNotification("lurelands: "+command, message)
+	}
+}
+
+func sendDesktopNotification(title, message string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", message, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux":
+		cmd = exec.Command("notify-send", title, message)
+	case "windows":
+		script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms,System.Drawing; `+
+			`$n = New-Object System.Windows.Forms.NotifyIcon; `+
+			`$n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; `+
+			`$n.ShowBalloonTip(10000, %q, %q, 'Info')`, title, message)
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", script)
+	default:
+		return
+	}
+	cmd.Run() // best effort — see the package comment
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Pipelines chain CLI commands into a DAG-lite: `>` separates stages
+// that run in order, `,` separates steps inside a stage that run in
+// parallel. The first failing stage stops the pipeline, and every step
+// gets a timing line at the end. `ship` is built in; config can
+// override it or add more, and each pipeline shows up as its own
+// top-level command (so the menu picks them up too):
+//
+//	[pipeline.nightly]
+//	summary = "full verification sweep"
+//	steps = "bridge generate > test, licenses > deploy --env staging"
+//
+// Each step is a lurelands command line, run as a child of the CLI
+// itself with prefixed, multiplexed output (same scheme as dev).
+
+type pipeline struct {
+	name    string
+	summary string
+	stages  [][]string
+}
+
+var builtinPipelines = []pipeline{
+	{
+		name:    "ship",
+		summary: "pipeline: bridge generate > deploy > k8s apply",
+		stages:  parsePipelineSteps("bridge generate > deploy > k8s apply"),
+	},
+}
+
+func parsePipelineSteps(s string) [][]string {
+	var stages [][]string
+	for _, stage := range strings.Split(s, ">") {
+		var steps []string
+		for _, step := range strings.Split(stage, ",") {
+			if step = strings.TrimSpace(step); step != "" {
+				steps = append(steps, step)
+			}
+		}
+		if len(steps) > 0 {
+			stages = append(stages, steps)
+		}
+	}
+	return stages
+}
+
+func loadPipelines(rootDir string) []pipeline {
+	pipelines := append([]pipeline(nil), builtinPipelines...)
+	for section, values := range loadConfigSections(rootDir) {
+		name, ok := strings.CutPrefix(section, "pipeline.")
+		if !ok || values["steps"] == "" {
+			continue
+		}
+		p := pipeline{name: name, summary: values["summary"], stages: parsePipelineSteps(values["steps"])}
+		if p.summary == "" {
+			p.summary = "pipeline: " + values["steps"]
+		}
+		replaced := false
+		for i, existing := range pipelines {
+			if existing.name == name {
+				pipelines[i] = p
+				replaced = true
+			}
+		}
+		if !replaced {
+			pipelines = append(pipelines, p)
+		}
+	}
+	return pipelines
+}
+
+type stepResult struct {
+	step     string
+	exitCode int
+	took     time.Duration
+}
+
+func (p pipeline) run(ctx context.Context, rootDir string) int {
+	if dryRun {
+		// The plan is more useful than a table of 0ms "ok" rows: show
+		// each stage and the command lines it would re-exec.
+		for i, stage := range p.stages {
+			fmt.Printf("— stage %d/%d:\n", i+1, len(p.stages))
+			for _, step := range stage {
+				fmt.Printf("  would run: lurelands %s\n", step)
+			}
+		}
+		return 0
+	}
+	self, err := os.Executable()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	var results []stepResult
+	var outMu sync.Mutex
+	failed := false
+	for i, stage := range p.stages {
+		if failed {
+			break
+		}
+		fmt.Printf("— stage %d/%d: %s\n", i+1, len(p.stages), strings.Join(stage, " + "))
+		stageResults := make([]stepResult, len(stage))
+		var wg sync.WaitGroup
+		for j, step := range stage {
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(devPrefixColors[j%len(devPrefixColors)]))
+			prefix := style.Render(strings.Fields(step)[0] + " |")
+			wg.Add(1)
+			go func(j int, step string) {
+				defer wg.Done()
+				start := time.Now()
+				result, _ := runner.RunStreaming(ctx, runner.Spec{
+					Name: self,
+					Args: append(strings.Fields(step), "--root", rootDir),
+				}, func(line string, _ bool) {
+					outMu.Lock()
+					fmt.Println(prefix, line)
+					outMu.Unlock()
+				})
+				stageResults[j] = stepResult{step: step, exitCode: result.ExitCode, took: time.Since(start)}
+			}(j, step)
+		}
+		wg.Wait()
+		for _, result := range stageResults {
+			results = append(results, result)
+			if result.exitCode != 0 {
+				failed = true
+			}
+		}
+	}
+
+	fmt.Printf("\n%s:\n", p.name)
+	exit := 0
+	for _, result := range results {
+		mark := "ok"
+		if result.exitCode != 0 {
+			mark = " !"
+			exit = result.exitCode
+		}
+		fmt.Printf("  %s  %-40s %s\n", mark, result.step, result.took.Round(time.Millisecond))
+	}
+	if failed {
+		fmt.Println("pipeline stopped at first failing stage")
+	}
+	return exit
+}
+
+// mergePipelines registers every pipeline as a top-level command, after
+// custom commands so a [command.*] with the same name wins.
+func mergePipelines(rootDir string) {
+	for _, p := range loadPipelines(rootDir) {
+		if findCommand(commandTree, p.name) != nil {
+			fmt.Fprintf(os.Stderr, "warning: pipeline %q shadows an existing command — skipping\n", p.name)
+			continue
+		}
+		p := p
+		commandTree = append(commandTree, &command{name: p.name, summary: p.summary,
+			run: func(ctx context.Context, rootDir string, args []string) int {
+				return p.run(ctx, rootDir)
+			}})
+	}
+}
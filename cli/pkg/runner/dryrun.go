@@ -0,0 +1,25 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DryRun, when set before any command runs, makes every entry point
+// print what it would execute — resolved argv, working directory, and
+// extra environment — and report success without spawning anything.
+// The CLI flips it from the global --dry-run flag.
+var DryRun bool
+
+// describeSpec prints one would-be invocation. Env values go through
+// the redaction registry, since a dry run of a deploy must not become
+// the easiest way to read secrets.
+func describeSpec(spec Spec) {
+	fmt.Printf("would run: %s %s\n", spec.Name, strings.Join(spec.Args, " "))
+	if spec.Dir != "" {
+		fmt.Printf("       in: %s\n", spec.Dir)
+	}
+	for _, pair := range spec.Env {
+		fmt.Printf("      env: %s\n", redactLine(pair))
+	}
+}
@@ -0,0 +1,64 @@
+package runner
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fake is a scripted Runner for tests. Script entries are keyed by the
+// command line ("name arg1 arg2"); an unscripted command succeeds with
+// no output. Every invocation is recorded in Calls, in order, so tests
+// can assert on what would have been executed.
+type Fake struct {
+	mu     sync.Mutex
+	Script map[string]FakeResult
+	Calls  []Spec
+}
+
+// FakeResult is one scripted outcome.
+type FakeResult struct {
+	Output   string
+	ExitCode int
+	Delay    time.Duration // simulated runtime, still cancellable
+	Err      error
+}
+
+func (f *Fake) lookup(ctx context.Context, spec Spec) (Result, error) {
+	f.mu.Lock()
+	f.Calls = append(f.Calls, spec)
+	scripted := f.Script[strings.Join(append([]string{spec.Name}, spec.Args...), " ")]
+	f.mu.Unlock()
+
+	if scripted.Delay > 0 {
+		select {
+		case <-ctx.Done():
+			return Result{ExitCode: -1}, ctx.Err()
+		case <-time.After(scripted.Delay):
+		}
+	}
+	return Result{Output: scripted.Output, ExitCode: scripted.ExitCode}, scripted.Err
+}
+
+func (f *Fake) Run(ctx context.Context, spec Spec) (Result, error) {
+	return f.lookup(ctx, spec)
+}
+
+func (f *Fake) RunStreaming(ctx context.Context, spec Spec, sinks ...LineSink) (Result, error) {
+	result, err := f.lookup(ctx, spec)
+	for _, line := range strings.Split(strings.TrimRight(result.Output, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		for _, sink := range sinks {
+			sink(line, false)
+		}
+	}
+	return result, err
+}
+
+func (f *Fake) RunInteractive(ctx context.Context, spec Spec) error {
+	_, err := f.lookup(ctx, spec)
+	return err
+}
@@ -0,0 +1,49 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// platform abstracts the OS-specific parts of command resolution so
+// the logic is unit-testable anywhere: the Windows rules run under a
+// fake lookPath on a Linux CI runner just as well as on a real
+// Windows box. Process-tree teardown stays in the proc_*.go files —
+// that part genuinely needs the host OS.
+type platform struct {
+	goos     string
+	lookPath func(name string) (path string, err error)
+}
+
+// hostPlatform is what command() consults; tests build their own.
+var hostPlatform = platform{goos: runtime.GOOS, lookPath: exec.LookPath}
+
+// scriptExts are the extensions CreateProcess cannot launch directly.
+// Node- and bun-style tool shims (bun.cmd, flutter.bat) are scripts,
+// so on Windows they have to go through the command interpreter.
+var scriptExts = map[string]bool{".cmd": true, ".bat": true}
+
+// resolveSpec rewrites a spec for the host platform. On Windows, a
+// name that resolves to a .cmd/.bat shim is relaunched as
+// `cmd /c <path> <args…>`; real executables and unresolvable names
+// pass through untouched so the caller's error reporting still names
+// the tool the user asked for.
+func (p platform) resolveSpec(spec Spec) Spec {
+	if p.goos != "windows" {
+		return spec
+	}
+	path, err := p.lookPath(spec.Name)
+	if err != nil || !scriptExts[strings.ToLower(filepath.Ext(path))] {
+		return spec
+	}
+	interpreter := os.Getenv("COMSPEC")
+	if interpreter == "" {
+		interpreter = "cmd"
+	}
+	spec.Args = append([]string{"/c", path}, spec.Args...)
+	spec.Name = interpreter
+	return spec
+}
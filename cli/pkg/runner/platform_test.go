@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"errors"
+	"testing"
+)
+
+func fakeLookPath(paths map[string]string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		if path, ok := paths[name]; ok {
+			return path, nil
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func TestResolveSpecWrapsWindowsShims(t *testing.T) {
+	p := platform{goos: "windows", lookPath: fakeLookPath(map[string]string{
+		"bun": `C:\Users\dev\.bun\bin\bun.CMD`,
+	})}
+	spec := p.resolveSpec(Spec{Name: "bun", Args: []string{"run", "dev"}})
+	if spec.Name != "cmd" {
+		t.Errorf("Name = %q, want cmd", spec.Name)
+	}
+	want := []string{"/c", `C:\Users\dev\.bun\bin\bun.CMD`, "run", "dev"}
+	if len(spec.Args) != len(want) {
+		t.Fatalf("Args = %v, want %v", spec.Args, want)
+	}
+	for i := range want {
+		if spec.Args[i] != want[i] {
+			t.Errorf("Args[%d] = %q, want %q", i, spec.Args[i], want[i])
+		}
+	}
+}
+
+func TestResolveSpecLeavesExecutablesAlone(t *testing.T) {
+	p := platform{goos: "windows", lookPath: fakeLookPath(map[string]string{
+		"cargo": `C:\Users\dev\.cargo\bin\cargo.exe`,
+	})}
+	spec := p.resolveSpec(Spec{Name: "cargo", Args: []string{"build"}})
+	if spec.Name != "cargo" || len(spec.Args) != 1 {
+		t.Errorf("exe spec was rewritten: %+v", spec)
+	}
+}
+
+func TestResolveSpecLeavesMissingToolsAlone(t *testing.T) {
+	p := platform{goos: "windows", lookPath: fakeLookPath(nil)}
+	spec := p.resolveSpec(Spec{Name: "flutter"})
+	if spec.Name != "flutter" {
+		t.Errorf("unresolvable name was rewritten to %q — error reporting needs the original", spec.Name)
+	}
+}
+
+func TestResolveSpecIsANoOpOffWindows(t *testing.T) {
+	p := platform{goos: "linux", lookPath: fakeLookPath(map[string]string{
+		"bun": "/weird/bun.cmd",
+	})}
+	spec := p.resolveSpec(Spec{Name: "bun", Args: []string{"run", "dev"}})
+	if spec.Name != "bun" {
+		t.Errorf("Name = %q, want bun", spec.Name)
+	}
+}
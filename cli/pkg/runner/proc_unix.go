@@ -0,0 +1,42 @@
+//go:build !windows
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// configureProc puts the child in its own process group and arranges
+// for cancellation to wind the whole group down, so tools that fork
+// (flutter wrapping dart, bun spawning workers) don't leave orphans
+// behind when a command is interrupted. Cancellation is graceful: the
+// group gets SIGTERM first and a GracePeriod to flush and exit, then
+// SIGKILL for whatever is left.
+func configureProc(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		if cmd.Process == nil {
+			return nil
+		}
+		pgid := -cmd.Process.Pid
+		if err := syscall.Kill(pgid, syscall.SIGTERM); err != nil {
+			return syscall.Kill(pgid, syscall.SIGKILL)
+		}
+		go func() {
+			time.Sleep(GracePeriod)
+			// No-op if the group already exited.
+			syscall.Kill(pgid, syscall.SIGKILL)
+		}()
+		return nil
+	}
+}
+
+// adoptProc is a post-Start hook for platforms that need to attach the
+// running process to something (Windows job objects). Process groups
+// are inherited at fork, so Unix has nothing to do. The returned func
+// runs after Wait.
+func adoptProc(cmd *exec.Cmd) func() {
+	return func() {}
+}
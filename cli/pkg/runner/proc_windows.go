@@ -0,0 +1,92 @@
+//go:build windows
+
+package runner
+
+import (
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Windows has no process groups in the Unix sense; a job object is the
+// real equivalent. The child is assigned to a kill-on-close job right
+// after it starts, so everything it spawns from then on (flutter
+// wrapping dart, bun workers) is in the job too and dies with it.
+// Cancellation stays graceful: a plain taskkill asks each process in
+// the tree to close, and a GracePeriod later the job is terminated for
+// whatever ignored it.
+
+// procJobs maps a started cmd to its job object between configureProc
+// (which must set Cancel before Start) and adoptProc (which can only
+// assign after Start).
+var procJobs sync.Map // *exec.Cmd -> windows.Handle
+
+func configureProc(cmd *exec.Cmd) {
+	if job, err := newKillOnCloseJob(); err == nil {
+		procJobs.Store(cmd, job)
+	}
+	cmd.Cancel = func() error {
+		if cmd.Process == nil {
+			return nil
+		}
+		pid := fmt.Sprint(cmd.Process.Pid)
+		exec.Command("taskkill", "/T", "/PID", pid).Run()
+		go func() {
+			time.Sleep(GracePeriod)
+			// No-op if the job already drained.
+			if v, ok := procJobs.Load(cmd); ok {
+				windows.TerminateJobObject(v.(windows.Handle), 1)
+			} else {
+				exec.Command("taskkill", "/T", "/F", "/PID", pid).Run()
+			}
+		}()
+		return nil
+	}
+}
+
+func newKillOnCloseJob() (windows.Handle, error) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+			LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE,
+		},
+	}
+	_, err = windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info)))
+	if err != nil {
+		windows.CloseHandle(job)
+		return 0, err
+	}
+	return job, nil
+}
+
+// adoptProc assigns the just-started process to its job. Children it
+// spawns afterwards inherit the job automatically; the gap before
+// assignment is unavoidable without suspended-start plumbing and in
+// practice shorter than any tool takes to fork. The returned func runs
+// after Wait and closes the job, which (kill-on-close) also reaps any
+// stragglers a finished command left behind.
+func adoptProc(cmd *exec.Cmd) func() {
+	v, ok := procJobs.Load(cmd)
+	if !ok || cmd.Process == nil {
+		return func() {}
+	}
+	job := v.(windows.Handle)
+	proc, err := windows.OpenProcess(
+		windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(cmd.Process.Pid))
+	if err == nil {
+		windows.AssignProcessToJobObject(job, proc)
+		windows.CloseHandle(proc)
+	}
+	return func() {
+		procJobs.Delete(cmd)
+		windows.CloseHandle(job)
+	}
+}
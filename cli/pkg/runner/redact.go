@@ -0,0 +1,38 @@
+package runner
+
+import (
+	"strings"
+	"sync"
+)
+
+// Redaction. Secret values resolved at runtime are registered here and
+// scrubbed from every line before it reaches any sink — tails, log
+// files, and TUI viewports all see the masked form. Registration is
+// global on purpose: a secret is a secret no matter which command run
+// happens to echo it.
+
+var (
+	redactMu     sync.RWMutex
+	redactValues []string
+)
+
+// Redact registers values to be masked in all streamed output. Empty
+// and very short values are ignored — masking "1" would shred output.
+func Redact(values ...string) {
+	redactMu.Lock()
+	defer redactMu.Unlock()
+	for _, value := range values {
+		if len(value) >= 4 {
+			redactValues = append(redactValues, value)
+		}
+	}
+}
+
+func redactLine(line string) string {
+	redactMu.RLock()
+	defer redactMu.RUnlock()
+	for _, value := range redactValues {
+		line = strings.ReplaceAll(line, value, "•••••")
+	}
+	return line
+}
@@ -0,0 +1,38 @@
+package runner
+
+import "strings"
+
+// Remote rewrites a spec to run on another machine over ssh. The command,
+// its working directory, and its environment all move to the remote side;
+// streaming, cancellation, and exit codes keep working unchanged because
+// the ssh client relays all three. BatchMode keeps a missing key from
+// hanging a non-interactive run on a password prompt.
+func Remote(host, dir string, spec Spec) Spec {
+	var remote strings.Builder
+	if dir == "" {
+		dir = spec.Dir
+	}
+	if dir != "" {
+		remote.WriteString("cd " + shellQuote(dir) + " && ")
+	}
+	if len(spec.Env) > 0 {
+		remote.WriteString("env")
+		for _, kv := range spec.Env {
+			remote.WriteString(" " + shellQuote(kv))
+		}
+		remote.WriteString(" ")
+	}
+	remote.WriteString(shellQuote(spec.Name))
+	for _, arg := range spec.Args {
+		remote.WriteString(" " + shellQuote(arg))
+	}
+	return Spec{
+		Name: "ssh",
+		Args: []string{"-o", "BatchMode=yes", host, remote.String()},
+	}
+}
+
+// shellQuote single-quotes s for POSIX sh, the only safe general quoting.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,201 @@
+// Package runner executes external commands for the CLI and anything
+// that reuses its task model. It is a thin, honest wrapper over
+// os/exec — the value is that every caller gets the same Spec/Result
+// shapes, which keeps output capture and error reporting uniform.
+package runner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Spec describes one command invocation.
+type Spec struct {
+	Name string
+	Args []string
+	Dir  string
+	Env  []string // appended to the parent environment
+}
+
+// Result is what happened.
+type Result struct {
+	Output   string // combined stdout+stderr when captured
+	ExitCode int
+}
+
+// GracePeriod is how long a cancelled child's process tree gets to
+// shut down cleanly before it is force-killed. The CLI overrides it
+// from LURELANDS_GRACE_PERIOD at startup.
+var GracePeriod = 5 * time.Second
+
+// LineSink receives one line of process output at a time, as it is
+// produced. stderr is marked so sinks that care (log files, the TUI)
+// can distinguish; sinks that don't can ignore the flag.
+type LineSink func(line string, isStderr bool)
+
+// Runner is the execution seam. Production code uses Exec (real
+// processes); tests hand flows a Fake scripted with outputs, exit
+// codes, and delays so execution paths are unit-testable without
+// spawning anything.
+type Runner interface {
+	Run(ctx context.Context, spec Spec) (Result, error)
+	RunStreaming(ctx context.Context, spec Spec, sinks ...LineSink) (Result, error)
+	RunInteractive(ctx context.Context, spec Spec) error
+}
+
+// Exec runs real processes via os/exec. The zero value is ready to use.
+type Exec struct{}
+
+func (Exec) Run(ctx context.Context, spec Spec) (Result, error) {
+	return Run(ctx, spec)
+}
+
+func (Exec) RunStreaming(ctx context.Context, spec Spec, sinks ...LineSink) (Result, error) {
+	return RunStreaming(ctx, spec, sinks...)
+}
+
+func (Exec) RunInteractive(ctx context.Context, spec Spec) error {
+	return RunInteractive(ctx, spec)
+}
+
+// WriterSink adapts an io.Writer into a LineSink, re-adding the
+// newline the scanner stripped.
+func WriterSink(w io.Writer) LineSink {
+	return func(line string, _ bool) { fmt.Fprintln(w, line) }
+}
+
+// Run executes the spec and captures combined output. Cancelling ctx
+// kills the process. Output is accumulated line by line via the
+// streaming path, so callers that only want the final string pay no
+// extra cost over callers that also attach live sinks.
+func Run(ctx context.Context, spec Spec) (Result, error) {
+	var buf strings.Builder
+	result, err := RunStreaming(ctx, spec, func(line string, _ bool) {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	})
+	result.Output = buf.String()
+	return result, err
+}
+
+// RunStreaming executes the spec, scanning stdout and stderr line by
+// line and fanning each line out to every sink as it arrives. Nothing
+// beyond the current line is held in memory, so a verbose flutter
+// build can stream to the terminal, a log file, and a TUI viewport at
+// once without buffering tens of MB. Sink calls are serialized, so
+// sinks need no locking of their own.
+func RunStreaming(ctx context.Context, spec Spec, sinks ...LineSink) (Result, error) {
+	if DryRun {
+		describeSpec(spec)
+		return Result{}, nil
+	}
+	started := time.Now()
+	cmd := command(ctx, spec)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return Result{ExitCode: -1}, fmt.Errorf("%s: %w", spec.Name, err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return Result{ExitCode: -1}, fmt.Errorf("%s: %w", spec.Name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return Result{ExitCode: -1}, fmt.Errorf("%s: %w", spec.Name, err)
+	}
+	defer adoptProc(cmd)()
+
+	// One goroutine per stream; a mutex serializes fan-out so sinks
+	// never see two half-interleaved lines.
+	var mu sync.Mutex
+	emit := func(line string, isStderr bool) {
+		line = redactLine(line)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, sink := range sinks {
+			sink(line, isStderr)
+		}
+	}
+	var wg sync.WaitGroup
+	scan := func(r io.Reader, isStderr bool) {
+		defer wg.Done()
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			emit(scanner.Text(), isStderr)
+		}
+	}
+	wg.Add(2)
+	go scan(stdout, false)
+	go scan(stderr, true)
+	wg.Wait()
+
+	waitErr := cmd.Wait()
+	result := Result{ExitCode: exitCode(cmd, waitErr)}
+	slog.Info("exited", "cmd", spec.Name, "code", result.ExitCode, "took", time.Since(started).Round(time.Millisecond))
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+	if waitErr != nil {
+		return result, fmt.Errorf("%s: %w", spec.Name, waitErr)
+	}
+	return result, nil
+}
+
+// RunInteractive executes the spec wired to the caller's stdio, for
+// commands whose output should stream straight to the terminal.
+func RunInteractive(ctx context.Context, spec Spec) error {
+	if DryRun {
+		describeSpec(spec)
+		return nil
+	}
+	cmd := command(ctx, spec)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%s: %w", spec.Name, err)
+	}
+	defer adoptProc(cmd)()
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("%s: %w", spec.Name, err)
+	}
+	return nil
+}
+
+func command(ctx context.Context, spec Spec) *exec.Cmd {
+	spec = hostPlatform.resolveSpec(spec)
+	slog.Info("spawning", "cmd", spec.Name, "args", spec.Args, "dir", spec.Dir)
+	cmd := exec.CommandContext(ctx, spec.Name, spec.Args...)
+	cmd.Dir = spec.Dir
+	if len(spec.Env) > 0 {
+		// Values go through redaction — verbose logs must not become the
+		// easiest way to read secrets.
+		pairs := make([]string, len(spec.Env))
+		for i, pair := range spec.Env {
+			pairs[i] = redactLine(pair)
+		}
+		slog.Info("env injected", "cmd", spec.Name, "pairs", pairs)
+		cmd.Env = append(os.Environ(), spec.Env...)
+	}
+	configureProc(cmd)
+	return cmd
+}
+
+func exitCode(cmd *exec.Cmd, err error) int {
+	if cmd.ProcessState != nil {
+		return cmd.ProcessState.ExitCode()
+	}
+	if err != nil {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,114 @@
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunCapturesOutput(t *testing.T) {
+	result, err := Run(context.Background(), Spec{Name: "echo", Args: []string{"hello"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(result.Output) != "hello" {
+		t.Errorf("Output = %q", result.Output)
+	}
+	if result.ExitCode != 0 {
+		t.Errorf("ExitCode = %d", result.ExitCode)
+	}
+}
+
+func TestRunReportsExitCode(t *testing.T) {
+	result, err := Run(context.Background(), Spec{Name: "false"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if result.ExitCode != 1 {
+		t.Errorf("ExitCode = %d, want 1", result.ExitCode)
+	}
+}
+
+func TestRunStreamingFansOutLines(t *testing.T) {
+	var stdout, stderr []string
+	_, err := RunStreaming(context.Background(),
+		Spec{Name: "sh", Args: []string{"-c", "echo one; echo two >&2; echo three"}},
+		func(line string, isStderr bool) {
+			if isStderr {
+				stderr = append(stderr, line)
+			} else {
+				stdout = append(stdout, line)
+			}
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(stdout, ","); got != "one,three" {
+		t.Errorf("stdout lines = %q", got)
+	}
+	if got := strings.Join(stderr, ","); got != "two" {
+		t.Errorf("stderr lines = %q", got)
+	}
+}
+
+func TestFakeIsScriptable(t *testing.T) {
+	fake := &Fake{Script: map[string]FakeResult{
+		"flutter test": {Output: "All tests passed!\n"},
+	}}
+	var runner Runner = fake
+
+	result, err := runner.Run(context.Background(), Spec{Name: "flutter", Args: []string{"test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result.Output, "passed") {
+		t.Errorf("Output = %q", result.Output)
+	}
+	if len(fake.Calls) != 1 || fake.Calls[0].Name != "flutter" {
+		t.Errorf("Calls = %+v", fake.Calls)
+	}
+}
+
+func TestTailEvictsOldestLines(t *testing.T) {
+	tail := NewTail(32)
+	for i := 0; i < 10; i++ {
+		tail.Add(strings.Repeat("x", 9)) // 10 bytes with the newline
+	}
+	if tail.Seen() != 10 {
+		t.Errorf("Seen = %d", tail.Seen())
+	}
+	if got := len(tail.Lines()); got != 3 {
+		t.Errorf("retained %d lines, want 3", got)
+	}
+	if tail.Dropped() != 7 {
+		t.Errorf("Dropped = %d, want 7", tail.Dropped())
+	}
+	if !strings.Contains(tail.String(), "truncated") {
+		t.Errorf("String() missing truncation marker: %q", tail.String())
+	}
+}
+
+func TestRunHonorsCancellation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := Run(ctx, Spec{Name: "sleep", Args: []string{"5"}}); err != context.DeadlineExceeded {
+		t.Errorf("err = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestRedactMasksStreamedOutput(t *testing.T) {
+	Redact("hunter2secret")
+	result, err := Run(context.Background(), Spec{
+		Name: "sh", Args: []string{"-c", "echo token=hunter2secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(result.Output, "hunter2secret") {
+		t.Fatalf("secret leaked into captured output: %q", result.Output)
+	}
+	if !strings.Contains(result.Output, "•••••") {
+		t.Fatalf("expected mask in output, got %q", result.Output)
+	}
+}
@@ -0,0 +1,64 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tail is a LineSink target that keeps only the most recent output,
+// bounded by bytes, so an arbitrarily chatty process (verbose `flutter
+// run` over an overnight soak) cannot grow the CLI's memory without
+// limit. Callers wanting the full output stream it to a file with a
+// second sink; Tail is the in-memory view for status screens and error
+// summaries.
+type Tail struct {
+	limit     int
+	lines     []string
+	bytes     int
+	dropped   int
+	seenLines int
+}
+
+// NewTail returns a Tail holding roughly limitBytes of trailing output.
+func NewTail(limitBytes int) *Tail {
+	return &Tail{limit: limitBytes}
+}
+
+// Add appends a line, evicting from the front once over budget.
+func (t *Tail) Add(line string) {
+	t.lines = append(t.lines, line)
+	t.bytes += len(line) + 1
+	t.seenLines++
+	for t.bytes > t.limit && len(t.lines) > 1 {
+		t.bytes -= len(t.lines[0]) + 1
+		t.dropped++
+		t.lines = t.lines[1:]
+	}
+}
+
+// Sink adapts the Tail for RunStreaming.
+func (t *Tail) Sink() LineSink {
+	return func(line string, _ bool) { t.Add(line) }
+}
+
+// Lines returns the retained lines, oldest first.
+func (t *Tail) Lines() []string { return append([]string(nil), t.lines...) }
+
+// Dropped is how many leading lines were evicted.
+func (t *Tail) Dropped() int { return t.dropped }
+
+// Seen is how many lines were ever added.
+func (t *Tail) Seen() int { return t.seenLines }
+
+// String renders the retained output, with a marker when truncated.
+func (t *Tail) String() string {
+	var b strings.Builder
+	if t.dropped > 0 {
+		fmt.Fprintf(&b, "… (%d earlier lines truncated)\n", t.dropped)
+	}
+	for _, line := range t.lines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+// Package tasks models the runnable units of the monorepo: which
+// services exist, where they live, and how to test or run them. The CLI,
+// watch mode, and CI helpers all derive their service lists from here so
+// adding a service is a one-line change.
+package tasks
+
+import (
+	"path/filepath"
+)
+
+// Service is one independently-built piece of the stack.
+type Service struct {
+	Name    string   // short name used in flags and output
+	Tool    string   // binary that must be on PATH
+	RelDir  string   // workspace-relative directory
+	Ext     string   // source extension that should trigger rebuilds
+	TestCmd []string // command that runs its test suite
+}
+
+// Services is the authoritative list, in display order.
+var Services = []Service{
+	{Name: "flutter", Tool: "flutter", RelDir: filepath.Join("apps", "lurelands"), Ext: ".dart", TestCmd: []string{"flutter", "test"}},
+	{Name: "bridge", Tool: "bun", RelDir: filepath.Join("services", "bridge"), Ext: ".ts", TestCmd: []string{"bun", "test"}},
+	{Name: "module", Tool: "cargo", RelDir: filepath.Join("services", "spacetime-server"), Ext: ".rs", TestCmd: []string{"cargo", "test"}},
+}
+
+// Lookup returns the named service, or nil.
+func Lookup(name string) *Service {
+	for i := range Services {
+		if Services[i].Name == name {
+			return &Services[i]
+		}
+	}
+	return nil
+}
+
+// Dir resolves the service's directory inside the given workspace root.
+func (s Service) Dir(root string) string {
+	return filepath.Join(root, s.RelDir)
+}
@@ -0,0 +1,23 @@
+package tasks
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	if svc := Lookup("bridge"); svc == nil || svc.Tool != "bun" {
+		t.Errorf("Lookup(bridge) = %+v", svc)
+	}
+	if svc := Lookup("nope"); svc != nil {
+		t.Errorf("Lookup(nope) = %+v, want nil", svc)
+	}
+}
+
+func TestDir(t *testing.T) {
+	svc := Lookup("flutter")
+	want := filepath.Join("/repo", "apps", "lurelands")
+	if got := svc.Dir("/repo"); got != want {
+		t.Errorf("Dir = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+// Package tui holds the shared look of the CLI's terminal interfaces:
+// one palette, one set of styles, and the small rendering helpers the
+// dashboards reuse. Keeping it in one place is what keeps `live`,
+// `mod queue`, and the content editor looking like the same tool.
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Palette is the theme's color assignments, one per style role. Values
+// are anything lipgloss.Color accepts — ANSI numbers or hex. An empty
+// value means no foreground color (Stat is bold-only by default).
+type Palette struct {
+	Title    string
+	Stat     string
+	Warn     string
+	Dim      string
+	Selected string
+	Accent   string
+	OK       string
+}
+
+// DarkPalette is the default look, tuned for dark terminals.
+var DarkPalette = Palette{
+	Title:    "45",
+	Warn:     "196",
+	Dim:      "241",
+	Selected: "212",
+	Accent:   "214",
+	OK:       "42",
+}
+
+// LightPalette swaps in darker foregrounds for light terminals.
+var LightPalette = Palette{
+	Title:    "30",
+	Warn:     "124",
+	Dim:      "243",
+	Selected: "127",
+	Accent:   "130",
+	OK:       "28",
+}
+
+var (
+	// Title heads a screen.
+	Title lipgloss.Style
+	// Stat renders a headline number.
+	Stat lipgloss.Style
+	// OK is for healthy/up indicators.
+	OK lipgloss.Style
+	// Warn is for error text and threshold breaches.
+	Warn lipgloss.Style
+	// Dim is for footers, hints, and metadata.
+	Dim lipgloss.Style
+	// Selected marks the cursor row in a list.
+	Selected lipgloss.Style
+	// Accent highlights a secondary field (kinds, tags).
+	Accent lipgloss.Style
+)
+
+func init() { ApplyPalette(DarkPalette) }
+
+func styled(bold bool, color string) lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(bold)
+	if color != "" {
+		style = style.Foreground(lipgloss.Color(color))
+	}
+	return style
+}
+
+// ApplyPalette rebuilds the style set from a palette. Callers that
+// copied a style before this runs keep the old look, so themes must be
+// applied before any rendering.
+func ApplyPalette(p Palette) {
+	Title = styled(true, p.Title)
+	Stat = styled(true, p.Stat)
+	Warn = styled(false, p.Warn)
+	Dim = styled(false, p.Dim)
+	Selected = styled(true, p.Selected)
+	Accent = styled(false, p.Accent)
+	OK = styled(false, p.OK)
+}
+
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// Gauge renders value against max as a fixed-width bar, for metrics
+// where "how close to the ceiling" matters more than the trend.
+func Gauge(value, max float64, width int) string {
+	if max <= 0 {
+		max = 1
+	}
+	filled := int(value / max * float64(width))
+	if filled > width {
+		filled = width
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return "[" + strings.Repeat("▮", filled) + strings.Repeat("▯", width-filled) + "]"
+}
+
+// Sparkline renders values as a tiny fixed-height bar chart, scaled to
+// the series maximum.
+func Sparkline(values []float64) string {
+	if len(values) < 2 {
+		return ""
+	}
+	max := 0.0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	if max == 0 {
+		max = 1
+	}
+	runes := make([]rune, len(values))
+	for i, v := range values {
+		runes[i] = sparkRunes[int(v/max*float64(len(sparkRunes)-1))]
+	}
+	return string(runes)
+}
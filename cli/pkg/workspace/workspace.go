@@ -0,0 +1,107 @@
+// Package workspace locates the Lurelands monorepo root and knows where
+// the pieces of the checkout live, so every tool (the CLI, bots, CI
+// helpers) resolves paths the same way.
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Workspace is a located checkout of the monorepo.
+type Workspace struct {
+	Root string
+}
+
+// markerFiles are what identify a checkout root, checked in order. The
+// explicit marker wins so a partial checkout (no Flutter app yet) still
+// resolves; the layout checks keep old checkouts working unmarked. The
+// .lurelands state dir counts too — any checkout the CLI has run in has
+// one, even from before the marker file existed.
+var markerFiles = []string{".lurelands.toml", ".lurelands", "go.work"}
+
+// Find locates the workspace: the LURELANDS_ROOT environment variable
+// if set, otherwise a walk upward from the working directory looking
+// for a marker file or the known monorepo layout. The executable's own
+// directory is deliberately not consulted — a binary installed to
+// $GOPATH/bin says nothing about where the checkout is.
+func Find() (*Workspace, error) {
+	if root := os.Getenv("LURELANDS_ROOT"); root != "" {
+		return FromRoot(root)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if root := findFrom(cwd); root != "" {
+		return &Workspace{Root: root}, nil
+	}
+	return nil, fmt.Errorf("could not locate the lurelands repo root above %s (no %s or apps/lurelands; set LURELANDS_ROOT or pass --root)", cwd, markerFiles[0])
+}
+
+// FromRoot treats dir as the workspace root after checking it looks
+// like one, for the --root and LURELANDS_ROOT overrides.
+func FromRoot(dir string) (*Workspace, error) {
+	if !looksLikeRoot(dir) {
+		return nil, fmt.Errorf("%s does not look like a lurelands checkout (no %s or apps/lurelands)", dir, markerFiles[0])
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Workspace{Root: abs}, nil
+}
+
+// FindFrom walks upward from dir looking for the repo root, for callers
+// that already know where to start (tests, editor integrations).
+func FindFrom(dir string) (*Workspace, error) {
+	if root := findFrom(dir); root != "" {
+		return &Workspace{Root: root}, nil
+	}
+	return nil, fmt.Errorf("could not locate the lurelands repo root above %s", dir)
+}
+
+func findFrom(dir string) string {
+	// Clean first so a trailing separator (or Windows-style mixed
+	// separators) can't stall the walk one level down from the root.
+	dir = filepath.Clean(dir)
+	for {
+		if looksLikeRoot(dir) {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func looksLikeRoot(dir string) bool {
+	for _, marker := range markerFiles {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	info, err := os.Stat(filepath.Join(dir, "apps", "lurelands"))
+	return err == nil && info.IsDir()
+}
+
+// AppDir is the Flutter app.
+func (w *Workspace) AppDir() string { return filepath.Join(w.Root, "apps", "lurelands") }
+
+// ContentDir holds the content JSON source of truth.
+func (w *Workspace) ContentDir() string { return filepath.Join(w.Root, "content") }
+
+// BridgeDir is the websocket bridge service.
+func (w *Workspace) BridgeDir() string { return filepath.Join(w.Root, "services", "bridge") }
+
+// ModuleDir is the SpacetimeDB module.
+func (w *Workspace) ModuleDir() string { return filepath.Join(w.Root, "services", "spacetime-server") }
+
+// StateDir is the CLI's local state root (.lurelands/), gitignored.
+func (w *Workspace) StateDir() string { return filepath.Join(w.Root, ".lurelands") }
+
+// CacheDir holds derived data that is safe to delete.
+func (w *Workspace) CacheDir() string { return filepath.Join(w.StateDir(), "cache") }
@@ -0,0 +1,110 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindFrom(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "apps", "lurelands"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "services", "bridge", "src")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := FindFrom(nested)
+	if err != nil {
+		t.Fatalf("FindFrom(%q): %v", nested, err)
+	}
+	if ws.Root != root {
+		t.Errorf("Root = %q, want %q", ws.Root, root)
+	}
+	if got := ws.AppDir(); got != filepath.Join(root, "apps", "lurelands") {
+		t.Errorf("AppDir = %q", got)
+	}
+}
+
+func TestFindFromMarkerFile(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".lurelands.toml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "cli", "pkg")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := FindFrom(nested)
+	if err != nil {
+		t.Fatalf("FindFrom(%q): %v", nested, err)
+	}
+	if ws.Root != root {
+		t.Errorf("Root = %q, want %q", ws.Root, root)
+	}
+}
+
+func TestFindFromStateDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".lurelands"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "content")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := FindFrom(nested)
+	if err != nil {
+		t.Fatalf("FindFrom(%q): %v", nested, err)
+	}
+	if ws.Root != root {
+		t.Errorf("Root = %q, want %q", ws.Root, root)
+	}
+}
+
+func TestFindFromToleratesTrailingSeparator(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".lurelands.toml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := FindFrom(root + string(filepath.Separator))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws.Root != root {
+		t.Errorf("Root = %q, want %q", ws.Root, root)
+	}
+}
+
+func TestFromRootRejectsNonWorkspace(t *testing.T) {
+	if _, err := FromRoot(t.TempDir()); err == nil {
+		t.Error("expected an error for a dir with no markers")
+	}
+}
+
+func TestFindHonorsEnvOverride(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".lurelands.toml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("LURELANDS_ROOT", root)
+
+	ws, err := Find()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws.Root != root {
+		t.Errorf("Root = %q, want %q", ws.Root, root)
+	}
+}
+
+func TestFindFromOutsideWorkspace(t *testing.T) {
+	if _, err := FindFrom(t.TempDir()); err == nil {
+		t.Error("expected an error outside a workspace")
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// player is the live-ops admin toolkit. Every action goes through the
+// module's admin reducers (which check the caller's admin identity) and
+// is recorded in a local append-only audit log, replacing the raw SQL
+// sessions live-ops used to run. Bans require an interactive confirmation
+// and a --reason that ends up in both audit trails.
+
+func auditLogPath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "audit.log")
+}
+
+// appendAuditLog records an admin action locally. The module keeps its own
+// audit table; this file is the operator-side trail for incident reviews.
+func appendAuditLog(rootDir string, env environment, action, playerID, detail string) {
+	if err := os.MkdirAll(filepath.Dir(auditLogPath(rootDir)), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(auditLogPath(rootDir), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintf(f, "%s env=%s action=%s player=%s %s\n",
+		time.Now().UTC().Format(time.RFC3339), env.Name, action, playerID, detail)
+}
+
+func callAdminReducer(env environment, reducer string, args ...string) error {
+	cmdArgs := append([]string{"call", "--server", env.Server, env.Module, reducer}, args...)
+	cmd := exec.Command("spacetime", cmdArgs...)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", reducer, err)
+	}
+	return nil
+}
+
+// confirmAction asks the operator to re-type the expected token (a player
+// id, flag key, …). Destructive actions never proceed on a bare enter.
+func confirmAction(prompt, expected string) bool {
+	fmt.Printf("%s\ntype %q to confirm: ", prompt, expected)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(line) == expected
+}
+
+func runPlayer(ctx context.Context, rootDir string, args []string) int {
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands player <id> <info|grant|ban|rename> [flags]")
+		return 1
+	}
+	playerID, sub, rest := args[0], args[1], args[2:]
+
+	flags := flag.NewFlagSet("player "+sub, flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	item := flags.String("item", "", "item id to grant (grant)")
+	count := flags.Int("count", 1, "item count (grant)")
+	coins := flags.Int("coins", 0, "coins to grant (grant)")
+	reason := flags.String("reason", "", "reason, recorded in the audit log (ban)")
+	newName := flags.String("to", "", "new username (rename)")
+	if err := flags.Parse(rest); err != nil {
+		return 1
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	switch sub {
+	case "info":
+		query := fmt.Sprintf("SELECT * FROM player WHERE id = '%s'", playerID)
+		out, err := exec.Command("spacetime", "sql", "--server", env.Server, env.Module, query, "--json").Output()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: querying player on %s: %v\n", env.Name, err)
+			return 1
+		}
+		var rows []map[string]any
+		if err := json.Unmarshal(out, &rows); err != nil || len(rows) == 0 {
+			fmt.Fprintf(os.Stderr, "player %s not found on %s\n", playerID, env.Name)
+			return 1
+		}
+		pretty, _ := json.MarshalIndent(rows[0], "", "  ")
+		fmt.Println(string(pretty))
+		return 0
+
+	case "grant":
+		if *item == "" && *coins == 0 {
+			fmt.Fprintln(os.Stderr, "grant requires --item or --coins")
+			return 1
+		}
+		payload, _ := json.Marshal(map[string]any{
+			"player_id": playerID, "item_id": *item, "count": *count, "coins": *coins,
+		})
+		if err := callAdminReducer(env, "admin_grant", string(payload)); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		detail := fmt.Sprintf("item=%s count=%d coins=%d", *item, *count, *coins)
+		appendAuditLog(rootDir, env, "grant", playerID, detail)
+		fmt.Printf("granted to %s on %s: %s\n", playerID, env.Name, detail)
+		return 0
+
+	case "ban":
+		if *reason == "" {
+			fmt.Fprintln(os.Stderr, "ban requires --reason")
+			return 1
+		}
+		if !confirmAction(fmt.Sprintf("about to BAN %s on %s (%s)", playerID, env.Name, *reason), playerID) {
+			fmt.Fprintln(os.Stderr, "aborted: confirmation did not match")
+			return 1
+		}
+		payload, _ := json.Marshal(map[string]any{"player_id": playerID, "reason": *reason})
+		if err := callAdminReducer(env, "admin_ban", string(payload)); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		appendAuditLog(rootDir, env, "ban", playerID, "reason="+*reason)
+		fmt.Printf("banned %s on %s\n", playerID, env.Name)
+		return 0
+
+	case "rename":
+		if *newName == "" {
+			fmt.Fprintln(os.Stderr, "rename requires --to")
+			return 1
+		}
+		payload, _ := json.Marshal(map[string]any{"player_id": playerID, "username": *newName})
+		if err := callAdminReducer(env, "admin_rename", string(payload)); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		appendAuditLog(rootDir, env, "rename", playerID, "to="+*newName)
+		fmt.Printf("renamed %s to %q on %s\n", playerID, *newName, env.Name)
+		return 0
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown player subcommand %q\n", sub)
+		return 1
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Plugins extend the CLI without forking it, git-style: any
+// `lurelands-<name>` executable on PATH becomes a `<name>` subcommand,
+// as does anything declared explicitly in config:
+//
+//	[plugin.l10n-sync]
+//	path = "tools/l10n-sync/run.sh"   # relative to the repo root
+//	summary = "push ARB files to the translation vendor"
+//
+// Plugins receive the resolved workspace layout and current
+// environment as LURELANDS_* env vars, so an asset-pipeline tool never
+// has to rediscover where the checkout lives. Built-in commands always
+// win a name collision.
+
+type plugin struct {
+	name    string
+	summary string
+	path    string
+}
+
+// pathPlugins scans PATH for lurelands-* executables.
+func pathPlugins() []plugin {
+	seen := map[string]bool{}
+	var plugins []plugin
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), "lurelands-")
+			if !ok || entry.IsDir() || seen[name] {
+				continue
+			}
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, ".exe")
+			}
+			info, err := entry.Info()
+			if err != nil || (runtime.GOOS != "windows" && info.Mode()&0o111 == 0) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, plugin{
+				name:    name,
+				summary: "plugin (" + entry.Name() + ")",
+				path:    filepath.Join(dir, entry.Name()),
+			})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].name < plugins[j].name })
+	return plugins
+}
+
+func configPlugins(rootDir string) []plugin {
+	var plugins []plugin
+	for section, values := range loadConfigSections(rootDir) {
+		name, ok := strings.CutPrefix(section, "plugin.")
+		if !ok || values["path"] == "" {
+			continue
+		}
+		summary := values["summary"]
+		if summary == "" {
+			summary = "plugin (" + values["path"] + ")"
+		}
+		plugins = append(plugins, plugin{
+			name:    name,
+			summary: summary,
+			path:    filepath.Join(rootDir, filepath.FromSlash(values["path"])),
+		})
+	}
+	return plugins
+}
+
+// pluginEnv is the contract plugins run under.
+func pluginEnv(rootDir string) []string {
+	env := []string{
+		"LURELANDS_ROOT=" + rootDir,
+		"LURELANDS_APP_DIR=" + appDir(rootDir),
+		"LURELANDS_BRIDGE_DIR=" + bridgeDir(rootDir),
+		"LURELANDS_MODULE_DIR=" + moduleDir(rootDir),
+		"LURELANDS_CONTENT_DIR=" + contentDir(rootDir),
+	}
+	if currentEnvName != "" {
+		if e, err := resolveEnvironment(currentEnvName); err == nil {
+			env = append(env, "LURELANDS_ENV="+e.Name, "LURELANDS_SERVER="+e.Server, "LURELANDS_MODULE="+e.Module)
+		}
+	}
+	return env
+}
+
+func (p plugin) runFunc() func(context.Context, string, []string) int {
+	return func(ctx context.Context, rootDir string, args []string) int {
+		err := runner.RunInteractive(ctx, runner.Spec{
+			Name: p.path,
+			Args: args,
+			Dir:  rootDir,
+			Env:  pluginEnv(rootDir),
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return exitCancelled
+			}
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		return 0
+	}
+}
+
+// mergePlugins grafts discovered plugins onto the tree; config-declared
+// ones win over PATH discoveries of the same name.
+func mergePlugins(rootDir string) {
+	merged := map[string]plugin{}
+	for _, p := range pathPlugins() {
+		merged[p.name] = p
+	}
+	for _, p := range configPlugins(rootDir) {
+		merged[p.name] = p
+	}
+	names := make([]string, 0, len(merged))
+	for name := range merged {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if findCommand(commandTree, name) != nil {
+			continue
+		}
+		p := merged[name]
+		commandTree = append(commandTree, &command{name: p.name, summary: p.summary, run: p.runFunc()})
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Port conflict detection. The most common dev failure is an
+// EADDRINUSE buried deep in bun output, so anything that binds a
+// well-known port checks first, names the process squatting on it, and
+// — with --kill-port — takes it out (term, grace, kill) before
+// starting. The owner lookup shells out to lsof, which covers the
+// platforms the stack develops on; without it the check still reports
+// the conflict, just anonymously.
+
+const defaultBridgePort = 8080
+
+func portInUse(port int) bool {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), 300*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// portOwner returns the pid and command name listening on port, or
+// (0, "") when it can't tell.
+func portOwner(port int) (int, string) {
+	out, err := exec.Command("lsof", "-t", "-i", fmt.Sprintf(":%d", port), "-sTCP:LISTEN").Output()
+	if err != nil {
+		return 0, ""
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return 0, ""
+	}
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, ""
+	}
+	name := ""
+	if out, err := exec.Command("ps", "-p", fields[0], "-o", "comm=").Output(); err == nil {
+		name = strings.TrimSpace(string(out))
+	}
+	return pid, name
+}
+
+// ensurePortFree checks port before `what` binds it. With kill set the
+// squatter gets the usual term-then-kill treatment; otherwise the
+// conflict is reported with enough detail to act on.
+func ensurePortFree(rootDir string, port int, what string, kill bool) bool {
+	if !portInUse(port) {
+		return true
+	}
+	pid, name := portOwner(port)
+	owner := "an unknown process"
+	if pid != 0 {
+		owner = fmt.Sprintf("%s (pid %d)", name, pid)
+	}
+	if !kill || pid == 0 {
+		fmt.Fprintf(os.Stderr, "error: port %d (%s) is already in use by %s — stop it or pass --kill-port\n",
+			port, what, owner)
+		return false
+	}
+
+	fmt.Printf("port %d is held by %s — terminating it\n", port, owner)
+	process, err := os.FindProcess(pid)
+	if err == nil {
+		if err = process.Signal(syscall.SIGTERM); err == nil {
+			deadline := time.Now().Add(runner.GracePeriod)
+			for time.Now().Before(deadline) && portInUse(port) {
+				time.Sleep(200 * time.Millisecond)
+			}
+		}
+		if portInUse(port) {
+			process.Kill()
+			time.Sleep(200 * time.Millisecond)
+		}
+	}
+	if portInUse(port) {
+		fmt.Fprintf(os.Stderr, "error: port %d is still in use after killing %s\n", port, owner)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// prebuild watches the module and bridge sources and kicks the
+// corresponding build off in the background whenever they change, so
+// that by the time a deploy is requested the artifact is usually
+// already warm. Builds are debounced (editors save in bursts) and the
+// module build takes the same "module" lock as deploy, so a prebuild
+// in flight can never corrupt a publish — deploy just waits a moment.
+
+type prebuildTarget struct {
+	name     string
+	dir      func(string) string
+	ext      string
+	lock     string // resource lock to hold, "" for none
+	buildCmd []string
+}
+
+var prebuildTargets = []prebuildTarget{
+	{name: "module", dir: moduleDir, ext: ".rs", lock: "module",
+		buildCmd: []string{"cargo", "build", "--target", "wasm32-unknown-unknown", "--release"}},
+	{name: "bridge", dir: bridgeDir, ext: ".ts", lock: "",
+		buildCmd: []string{"bun", "run", "build"}},
+}
+
+const prebuildDebounce = 2 * time.Second
+
+func runPrebuild(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("prebuild", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	var targets []prebuildTarget
+	for _, target := range prebuildTargets {
+		if _, err := os.Stat(target.dir(rootDir)); err != nil {
+			continue
+		}
+		if _, ok := lookupTool(rootDir, target.buildCmd[0]); !ok {
+			continue
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		fmt.Fprintln(os.Stderr, "error: no buildable targets (missing dirs or toolchains)")
+		return 1
+	}
+
+	mtimes := map[string]time.Time{}
+	pending := map[string]time.Time{} // target -> when the change landed
+	building := map[string]bool{}
+	done := make(chan string)
+	for _, target := range targets {
+		mtimes[target.name] = latestMtime(target.dir(rootDir), target.ext)
+	}
+
+	fmt.Println("prebuild: watching module and bridge sources — ctrl+c to stop")
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println("\nprebuild stopped")
+			return 0
+		case name := <-done:
+			building[name] = false
+			continue
+		case <-time.After(time.Second):
+		}
+
+		for _, target := range targets {
+			latest := latestMtime(target.dir(rootDir), target.ext)
+			if latest.After(mtimes[target.name]) {
+				mtimes[target.name] = latest
+				pending[target.name] = time.Now()
+			}
+
+			changedAt, isPending := pending[target.name]
+			if !isPending || building[target.name] || time.Since(changedAt) < prebuildDebounce {
+				continue
+			}
+			delete(pending, target.name)
+			building[target.name] = true
+
+			target := target
+			go func() {
+				defer func() { done <- target.name }()
+				if target.lock != "" {
+					release, err := acquireResourceLock(ctx, rootDir, target.lock)
+					if err != nil {
+						return
+					}
+					defer release()
+				}
+				start := time.Now()
+				fmt.Printf("[%s] prebuilding %s…\n", time.Now().Format("15:04:05"), target.name)
+				result, err := runner.Run(ctx, pinnedSpec(rootDir, runner.Spec{
+					Name: target.buildCmd[0],
+					Args: target.buildCmd[1:],
+					Dir:  target.dir(rootDir),
+				}))
+				if err != nil {
+					fmt.Printf("[%s] %s prebuild failed (exit %d) — will retry on next change\n",
+						time.Now().Format("15:04:05"), target.name, result.ExitCode)
+					return
+				}
+				fmt.Printf("[%s] %s warm (%s)\n",
+					time.Now().Format("15:04:05"), target.name, time.Since(start).Round(time.Millisecond))
+			}()
+		}
+	}
+}
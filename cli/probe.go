@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Memoized tool probing. PATH lookups are cheap one at a time but the
+// CLI does a batch of them on nearly every launch (which services are
+// runnable, which collectors exist), and version probes actually execute
+// the tool. Results are memoized in .lurelands/cache/probes.json and
+// invalidated when the resolved binary's mtime changes — a toolchain
+// upgrade swaps the file, so stale entries can't survive one.
+
+type toolProbe struct {
+	Path  string    `json:"path"` // "" means "known missing"
+	Mtime time.Time `json:"mtime"`
+}
+
+type probeCache struct {
+	mu      sync.Mutex
+	rootDir string
+	loaded  bool
+	dirty   bool
+	Tools   map[string]toolProbe `json:"tools"`
+}
+
+var toolProbes = &probeCache{Tools: map[string]toolProbe{}}
+
+func probeCachePath(rootDir string) string {
+	return filepath.Join(cacheDir(rootDir), "probes.json")
+}
+
+func (c *probeCache) load(rootDir string) {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	c.rootDir = rootDir
+	data, err := os.ReadFile(probeCachePath(rootDir))
+	if err == nil {
+		json.Unmarshal(data, c)
+	}
+	if c.Tools == nil {
+		c.Tools = map[string]toolProbe{}
+	}
+}
+
+func (c *probeCache) save() {
+	if !c.dirty {
+		return
+	}
+	os.MkdirAll(cacheDir(c.rootDir), 0o755)
+	data, _ := json.MarshalIndent(c, "", "  ")
+	os.WriteFile(probeCachePath(c.rootDir), append(data, '\n'), 0o644)
+	c.dirty = false
+}
+
+// lookupTool is exec.LookPath memoized across launches. The bool is
+// false when the tool is not installed (also memoized — repeatedly
+// stat-ing a long PATH for a missing tool is the slowest case).
+func lookupTool(rootDir, name string) (string, bool) {
+	c := toolProbes
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load(rootDir)
+
+	if probe, ok := c.Tools[name]; ok {
+		if probe.Path == "" {
+			return "", false
+		}
+		if info, err := os.Stat(probe.Path); err == nil && info.ModTime().Equal(probe.Mtime) {
+			return probe.Path, true
+		}
+	}
+
+	path, err := exec.LookPath(name)
+	probe := toolProbe{}
+	if err == nil {
+		probe.Path = path
+		if info, statErr := os.Stat(path); statErr == nil {
+			probe.Mtime = info.ModTime()
+		}
+	}
+	c.Tools[name] = probe
+	c.dirty = true
+	c.save()
+	return probe.Path, err == nil
+}
@@ -0,0 +1,50 @@
+//go:build !windows
+
+package main
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Platform seams for chaos and anything else that pokes at processes
+// the CLI didn't spawn. Unix gets the full set; Windows (see
+// proc_windows.go) can kill but not stall.
+
+// canStallProcesses reports whether stall/resume (SIGSTOP/SIGCONT) is
+// available on this platform.
+const canStallProcesses = true
+
+// findPids returns pids whose command line matches pattern.
+func findPids(pattern string) []int {
+	out, err := exec.Command("pgrep", "-f", pattern).Output()
+	if err != nil {
+		return nil
+	}
+	var pids []int
+	for _, field := range strings.Fields(string(out)) {
+		if pid, err := strconv.Atoi(field); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+func killProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGKILL)
+}
+
+func stallProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGSTOP)
+}
+
+func resumeProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGCONT)
+}
+
+// processAlive reports whether pid exists (signal 0 probe).
+func processAlive(pid int) bool {
+	return syscall.Kill(pid, 0) == nil
+}
@@ -0,0 +1,54 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Windows half of the process seams in proc_unix.go. Killing works
+// (taskkill takes the tree down); stalling does not — there is no
+// SIGSTOP equivalent short of NtSuspendProcess, which isn't worth a
+// debug-API dependency for a chaos tool.
+
+const canStallProcesses = false
+
+// findPids matches against full command lines via PowerShell/CIM,
+// since tasklist can't filter on arguments.
+func findPids(pattern string) []int {
+	script := fmt.Sprintf(
+		`Get-CimInstance Win32_Process | Where-Object { $_.CommandLine -match %q } | ForEach-Object { $_.ProcessId }`,
+		pattern)
+	out, err := exec.Command("powershell", "-NoProfile", "-Command", script).Output()
+	if err != nil {
+		return nil
+	}
+	var pids []int
+	for _, field := range strings.Fields(string(out)) {
+		if pid, err := strconv.Atoi(field); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+func killProcess(pid int) error {
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(pid)).Run()
+}
+
+// processAlive reports whether pid exists, via tasklist's PID filter.
+func processAlive(pid int) bool {
+	out, err := exec.Command("tasklist", "/FI", fmt.Sprintf("PID eq %d", pid), "/NH").Output()
+	return err == nil && strings.Contains(string(out), strconv.Itoa(pid))
+}
+
+func stallProcess(pid int) error {
+	return fmt.Errorf("process stalling is not supported on windows")
+}
+
+func resumeProcess(pid int) error {
+	return fmt.Errorf("process stalling is not supported on windows")
+}
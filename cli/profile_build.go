@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// profile:build wraps any CLI task in instrumentation: every output
+// line is timestamped and bucketed into a phase by marker patterns
+// (cargo's "Compiling", our own "bindings"/"smoke:" lines, and so on),
+// cache hit/miss messages are counted, and CPU utilization is sampled
+// from /proc/stat where available. The report is a flame-style bar
+// breakdown showing where the wall clock actually went — usually the
+// answer to "why is deploy slow today" is one bar.
+
+type phaseMarker struct {
+	pattern *regexp.Regexp
+	name    string
+}
+
+var phaseMarkers = []phaseMarker{
+	{regexp.MustCompile(`^\s*(Compiling|Checking|Building|Finished)`), "rust compile"},
+	{regexp.MustCompile(`bindings`), "binding generation"},
+	{regexp.MustCompile(`(?i)publish`), "publish"},
+	{regexp.MustCompile(`smoke:`), "smoke suite"},
+	{regexp.MustCompile(`(packed atlas|assets:)`), "asset pipeline"},
+	{regexp.MustCompile(`(?i)(test|expect)`), "tests"},
+}
+
+var cacheHitPattern = regexp.MustCompile(`(pulled from remote cache|up to date|unchanged since|skipped)`)
+var cacheMissPattern = regexp.MustCompile(`(prebuilding|regenerated|Compiling)`)
+
+type buildProfile struct {
+	phaseOrder []string
+	phaseTime  map[string]time.Duration
+	cacheHits  int
+	cacheMiss  int
+}
+
+func (p *buildProfile) addTime(phase string, d time.Duration) {
+	if _, seen := p.phaseTime[phase]; !seen {
+		p.phaseOrder = append(p.phaseOrder, phase)
+	}
+	p.phaseTime[phase] += d
+}
+
+// observe charges the time since the previous line to the phase that was
+// running, then switches phase if this line marks a new one.
+func (p *buildProfile) observe(line string, sincePrev time.Duration, current *string) {
+	p.addTime(*current, sincePrev)
+	for _, marker := range phaseMarkers {
+		if marker.pattern.MatchString(line) {
+			*current = marker.name
+			break
+		}
+	}
+	if cacheHitPattern.MatchString(line) {
+		p.cacheHits++
+	} else if cacheMissPattern.MatchString(line) {
+		p.cacheMiss++
+	}
+}
+
+// cpuBusySample reads aggregate busy/total jiffies from /proc/stat;
+// ok is false on platforms without it.
+func cpuBusySample() (busy, total uint64, ok bool) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return 0, 0, false
+	}
+	fields := strings.Fields(strings.SplitN(string(data), "\n", 2)[0])
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return 0, 0, false
+	}
+	var values []uint64
+	for _, field := range fields[1:] {
+		var v uint64
+		fmt.Sscan(field, &v)
+		values = append(values, v)
+	}
+	for i, v := range values {
+		total += v
+		if i != 3 && i != 4 { // idle, iowait
+			busy += v
+		}
+	}
+	return busy, total, true
+}
+
+func runProfileBuild(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands profile build <task> [args…]")
+		return exitUsage
+	}
+	self, err := os.Executable()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	profile := &buildProfile{phaseTime: map[string]time.Duration{}}
+	current := "startup"
+	start := time.Now()
+	prev := start
+
+	busy0, total0, haveCPU := cpuBusySample()
+
+	fmt.Printf("profiling: lurelands %s\n", strings.Join(args, " "))
+	result, runErr := runner.RunStreaming(ctx, runner.Spec{Name: self, Args: args},
+		func(line string, _ bool) {
+			now := time.Now()
+			profile.observe(line, now.Sub(prev), &current)
+			prev = now
+			fmt.Println(line)
+		})
+	elapsed := time.Since(start)
+	profile.addTime(current, time.Since(prev))
+
+	fmt.Printf("\n── build profile (%s total, exit %d) ──\n", elapsed.Round(time.Millisecond), result.ExitCode)
+	for _, phase := range profile.phaseOrder {
+		share := float64(profile.phaseTime[phase]) / float64(elapsed)
+		bar := strings.Repeat("█", int(share*40+0.5))
+		fmt.Printf("  %-20s %8s %5.1f%% %s\n",
+			phase, profile.phaseTime[phase].Round(time.Millisecond), share*100, tui.Accent.Render(bar))
+	}
+	fmt.Printf("  cache: %d hit(s), %d miss(es)\n", profile.cacheHits, profile.cacheMiss)
+	if haveCPU {
+		if busy1, total1, ok := cpuBusySample(); ok && total1 > total0 {
+			fmt.Printf("  cpu: %.0f%% average utilization (all cores)\n",
+				100*float64(busy1-busy0)/float64(total1-total0))
+		}
+	}
+	if runErr != nil {
+		return result.ExitCode
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// Long builds used to either dump raw tool output or hide behind a
+// silent capture. runWithProgress streams instead: known output
+// patterns from flutter, bun, cargo, and spacetime advance a stage
+// table, and one in-place line shows a progress bar with the current
+// stage label ("Compiling WASM", "Uploading module"). Lines between
+// stage boundaries trickle the bar forward so a long quiet stage never
+// looks frozen. CI mode gets a plain capture — \r animation belongs on
+// a terminal.
+
+type progressStage struct {
+	pattern *regexp.Regexp
+	label   string
+}
+
+// progressStageTables is keyed by the underlying tool (before toolchain
+// pinning rewrites the spec to run through mise). Stages are matched in
+// order and only forward, so a tool re-printing an earlier pattern
+// can't walk the bar backwards.
+var progressStageTables = map[string][]progressStage{
+	"flutter": {
+		{regexp.MustCompile(`Resolving dependencies|Downloading packages`), "Resolving dependencies"},
+		{regexp.MustCompile(`Running Gradle task|Building .* Xcode|Compiling .+ for the Web`), "Compiling"},
+		{regexp.MustCompile(`(?i)^\s*.?\s*Built `), "Built"},
+	},
+	"bun": {
+		{regexp.MustCompile(`(?i)resolv|install`), "Resolving dependencies"},
+		{regexp.MustCompile(`(?i)bundl|transpil|\.ts`), "Bundling"},
+		{regexp.MustCompile(`(?i)done|bytes|saved`), "Done"},
+	},
+	"cargo": {
+		{regexp.MustCompile(`^\s*(Updating|Downloading|Downloaded)`), "Resolving dependencies"},
+		{regexp.MustCompile(`^\s*Compiling`), "Compiling WASM"},
+		{regexp.MustCompile(`^\s*Finished`), "Finished"},
+	},
+	"docker": {
+		{regexp.MustCompile(`(?i)load build context|transferring|FROM `), "Preparing context"},
+		{regexp.MustCompile(`(?i)RUN |extracting|pull`), "Building layers"},
+		{regexp.MustCompile(`(?i)exporting|naming to`), "Exporting image"},
+	},
+	"spacetime": {
+		{regexp.MustCompile(`(?i)compil|build`), "Compiling WASM"},
+		{regexp.MustCompile(`(?i)publish|upload`), "Uploading module"},
+		{regexp.MustCompile(`(?i)(created|updated) database`), "Database updated"},
+	},
+}
+
+// runWithProgress runs spec like runner.Run (captured output, same
+// Result), rendering a live progress line keyed on tool's stage table.
+// Tools without a table, and CI mode, fall back to the plain capture.
+func runWithProgress(ctx context.Context, label, tool string, spec runner.Spec) (runner.Result, error) {
+	stages := progressStageTables[tool]
+	if ciMode || len(stages) == 0 {
+		return runner.Run(ctx, spec)
+	}
+
+	bar := progress.New(progress.WithDefaultGradient(), progress.WithWidth(24), progress.WithoutPercentage())
+	var buf strings.Builder
+	stage := -1
+	stageLabel := "starting"
+	fraction := 0.0
+	draw := func() {
+		fmt.Printf("\r\x1b[2K  %s %s %s", label, bar.ViewAs(fraction), tui.Dim.Render(stageLabel))
+	}
+	draw()
+
+	result, err := runner.RunStreaming(ctx, spec, func(line string, _ bool) {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+		for i := stage + 1; i < len(stages); i++ {
+			if stages[i].pattern.MatchString(line) {
+				stage = i
+				stageLabel = stages[i].label
+				fraction = float64(i+1) / float64(len(stages)+1)
+				break
+			}
+		}
+		// Trickle toward the next boundary on every line in between.
+		ceiling := float64(stage+2) / float64(len(stages)+1)
+		fraction += (ceiling - fraction) * 0.03
+		draw()
+	})
+	result.Output = buf.String()
+	fmt.Print("\r\x1b[2K")
+	return result, err
+}
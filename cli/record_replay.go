@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// record / replay capture real client sessions for bug reproduction.
+// `record` sits between the app and the bridge as a byte-level proxy
+// (point the app at the proxy port) and logs every chunk with direction
+// and timing; `replay` opens a fresh connection to a local bridge and
+// plays the client side back with the original pacing. Working at the
+// byte level means the websocket handshake and framing are captured
+// verbatim — no protocol knowledge to drift out of date.
+
+type trafficChunk struct {
+	// Dir is "c2s" (client to server) or "s2c".
+	Dir      string `json:"dir"`
+	OffsetMs int64  `json:"offset_ms"`
+	Data     string `json:"data"` // base64
+}
+
+func writeChunk(mu *sync.Mutex, w *json.Encoder, start time.Time, dir string, data []byte) {
+	mu.Lock()
+	defer mu.Unlock()
+	w.Encode(trafficChunk{
+		Dir:      dir,
+		OffsetMs: time.Since(start).Milliseconds(),
+		Data:     base64.StdEncoding.EncodeToString(data),
+	})
+}
+
+// proxyConn pipes src->dst, logging every chunk.
+func proxyConn(src, dst net.Conn, dir string, mu *sync.Mutex, enc *json.Encoder, start time.Time, done chan<- struct{}) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			writeChunk(mu, enc, start, dir, buf[:n])
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	done <- struct{}{}
+}
+
+func runRecord(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("record", flag.ContinueOnError)
+	listen := flags.String("listen", "localhost:8081", "address the app should connect to")
+	upstream := flags.String("upstream", "localhost:8080", "real bridge address")
+	out := flags.String("out", "", "output file (default .lurelands/recordings/<timestamp>.jsonl)")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	path := *out
+	if path == "" {
+		dir := fmt.Sprintf("%s/.lurelands/recordings", rootDir)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		path = fmt.Sprintf("%s/%s.jsonl", dir, time.Now().Format("20060102-150405"))
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	defer f.Close()
+
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	defer listener.Close()
+	fmt.Printf("recording: point the app at ws://%s (proxying to %s)\n", *listen, *upstream)
+	fmt.Println("recording to", path, "— first session only, ctrl+c to abort")
+
+	client, err := listener.Accept()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	defer client.Close()
+
+	server, err := net.Dial("tcp", *upstream)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error: connecting to bridge:", err)
+		return 1
+	}
+	defer server.Close()
+
+	var mu sync.Mutex
+	enc := json.NewEncoder(f)
+	start := time.Now()
+	done := make(chan struct{}, 2)
+	go proxyConn(client, server, "c2s", &mu, enc, start, done)
+	go proxyConn(server, client, "s2c", &mu, enc, start, done)
+	<-done // either side closing ends the session
+
+	fmt.Printf("session ended after %s; recording saved to %s\n", time.Since(start).Round(time.Second), path)
+	return 0
+}
+
+func runReplay(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	target := flags.String("target", "localhost:8080", "bridge address to replay against")
+	speed := flags.Float64("speed", 1.0, "playback speed multiplier")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: lurelands replay [flags] <recording.jsonl>")
+		return 1
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	defer f.Close()
+
+	conn, err := net.Dial("tcp", *target)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error: connecting to bridge:", err)
+		return 1
+	}
+	defer conn.Close()
+	go io.Copy(io.Discard, conn) // drain server responses; fresh session won't match the recording
+
+	start := time.Now()
+	sent := 0
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var chunk trafficChunk
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			fmt.Fprintln(os.Stderr, "error: bad recording line:", err)
+			return 1
+		}
+		if chunk.Dir != "c2s" {
+			continue
+		}
+		due := time.Duration(float64(chunk.OffsetMs)/(*speed)) * time.Millisecond
+		if wait := due - time.Since(start); wait > 0 {
+			time.Sleep(wait)
+		}
+		data, err := base64.StdEncoding.DecodeString(chunk.Data)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error: bad chunk data:", err)
+			return 1
+		}
+		if _, err := conn.Write(data); err != nil {
+			fmt.Fprintf(os.Stderr, "error: connection closed after %d chunk(s): %v\n", sent, err)
+			return 1
+		}
+		sent++
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Printf("replayed %d client chunk(s) over %s\n", sent, time.Since(start).Round(time.Millisecond))
+	return 0
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// release (the bare command, as opposed to release cli) cuts a game
+// release: bump the version in pubspec.yaml and the bridge
+// package.json, tag the commit, and build every shippable artifact —
+// app bundle, ipa where the host can, web build, and the bridge
+// production build. --dry-run prints the plan without touching
+// anything, which is also how you sanity-check what a bump would do.
+
+var (
+	pubspecVersionPattern = regexp.MustCompile(`(?m)^version:\s*(\d+)\.(\d+)\.(\d+)\+(\d+)\s*$`)
+	packageVersionPattern = regexp.MustCompile(`"version":\s*"(\d+\.\d+\.\d+)"`)
+)
+
+// bumpSemver returns version with the named part incremented and the
+// lower parts zeroed.
+func bumpSemver(major, minor, patch int, part string) (int, int, int) {
+	switch part {
+	case "major":
+		return major + 1, 0, 0
+	case "minor":
+		return major, minor + 1, 0
+	default:
+		return major, minor, patch + 1
+	}
+}
+
+// readAppVersion pulls version and build number out of pubspec.yaml.
+func readAppVersion(rootDir string) (major, minor, patch, build int, err error) {
+	data, err := os.ReadFile(pubspecPath(rootDir))
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	m := pubspecVersionPattern.FindSubmatch(data)
+	if m == nil {
+		return 0, 0, 0, 0, fmt.Errorf("no `version: x.y.z+n` line in %s", pubspecPath(rootDir))
+	}
+	major, _ = strconv.Atoi(string(m[1]))
+	minor, _ = strconv.Atoi(string(m[2]))
+	patch, _ = strconv.Atoi(string(m[3]))
+	build, _ = strconv.Atoi(string(m[4]))
+	return major, minor, patch, build, nil
+}
+
+func pubspecPath(rootDir string) string {
+	return filepath.Join(appDir(rootDir), "pubspec.yaml")
+}
+
+func bridgePackageJSONPath(rootDir string) string {
+	return filepath.Join(bridgeDir(rootDir), "package.json")
+}
+
+// writeVersions rewrites the version lines in place; everything else in
+// both files is untouched.
+func writeVersions(rootDir, version string, build int) error {
+	data, err := os.ReadFile(pubspecPath(rootDir))
+	if err != nil {
+		return err
+	}
+	data = pubspecVersionPattern.ReplaceAll(data, []byte(fmt.Sprintf("version: %s+%d", version, build)))
+	if err := os.WriteFile(pubspecPath(rootDir), data, 0o644); err != nil {
+		return err
+	}
+	// The bridge is versioned in lockstep when it is checked out; a
+	// CLI-only or app-only checkout just skips it.
+	if data, err = os.ReadFile(bridgePackageJSONPath(rootDir)); err == nil {
+		data = packageVersionPattern.ReplaceAll(data, []byte(`"version": "`+version+`"`))
+		if err := os.WriteFile(bridgePackageJSONPath(rootDir), data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type releaseStep struct {
+	name string
+	spec runner.Spec
+	skip string // non-empty: why this step will not run on this host
+}
+
+func releaseBuildSteps(rootDir string) []releaseStep {
+	steps := []releaseStep{
+		{name: "flutter build appbundle", spec: runner.Spec{
+			Name: "flutter", Args: []string{"build", "appbundle", "--release"}, Dir: appDir(rootDir)}},
+		{name: "flutter build ipa", spec: runner.Spec{
+			Name: "flutter", Args: []string{"build", "ipa", "--release"}, Dir: appDir(rootDir)}},
+		{name: "flutter build web", spec: runner.Spec{
+			Name: "flutter", Args: []string{"build", "web", "--release"}, Dir: appDir(rootDir)}},
+		{name: "bridge production build", spec: runner.Spec{
+			Name: "bun", Args: []string{"run", "build"}, Dir: bridgeDir(rootDir)}},
+	}
+	if runtime.GOOS != "darwin" {
+		steps[1].skip = "ipa builds need macOS"
+	}
+	if !fileExists(bridgeDir(rootDir)) {
+		steps[3].skip = "bridge not checked out"
+	}
+	return steps
+}
+
+func runRelease(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("release", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "print the plan without bumping, tagging, or building")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	part := flags.Arg(0)
+	switch part {
+	case "major", "minor", "patch":
+	case "":
+		fmt.Fprintln(os.Stderr, "usage: lurelands release <major|minor|patch> [--dry-run]")
+		return exitUsage
+	default:
+		fmt.Fprintf(os.Stderr, "unknown release subcommand %q (major, minor, patch, or cli)\n", part)
+		return exitUsage
+	}
+
+	major, minor, patch, build, err := readAppVersion(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	oldVersion := fmt.Sprintf("%d.%d.%d", major, minor, patch)
+	major, minor, patch = bumpSemver(major, minor, patch, part)
+	version := fmt.Sprintf("%d.%d.%d", major, minor, patch)
+	build++
+
+	steps := releaseBuildSteps(rootDir)
+	if *dryRun {
+		fmt.Printf("release %s (dry run): %s -> %s (build %d)\n", part, oldVersion, version, build)
+		fmt.Printf("  would update %s and %s\n", pubspecPath(rootDir), bridgePackageJSONPath(rootDir))
+		fmt.Printf("  would tag v%s\n", version)
+		for _, step := range steps {
+			if step.skip != "" {
+				fmt.Printf("  would skip %s (%s)\n", step.name, step.skip)
+			} else {
+				fmt.Printf("  would run %s\n", step.name)
+			}
+		}
+		return 0
+	}
+
+	for _, warning := range gitSaysRisky(rootDir) {
+		fmt.Fprintln(os.Stderr, "warning:", warning)
+	}
+
+	fmt.Printf("release %s: %s -> %s (build %d)\n", part, oldVersion, version, build)
+	if err := writeVersions(rootDir, version, build); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Println("  bumped version files")
+
+	if _, err := git(rootDir, "tag", "-a", "v"+version, "-m", "Release v"+version); err != nil {
+		fmt.Fprintf(os.Stderr, "error: tagging v%s: %v\n", version, err)
+		return 1
+	}
+	fmt.Printf("  tagged v%s (the bump itself is left uncommitted — review and commit it)\n", version)
+
+	ok, failed := 0, 0
+	for _, step := range steps {
+		if step.skip != "" {
+			fmt.Printf("  skip %s (%s)\n", step.name, step.skip)
+			continue
+		}
+		if _, found := lookupTool(rootDir, step.spec.Name); !found {
+			fmt.Printf("  skip %s (%s not installed)\n", step.name, step.spec.Name)
+			continue
+		}
+		result, err := runWithProgress(ctx, step.name, step.spec.Name, pinnedSpec(rootDir, step.spec))
+		if err != nil {
+			fmt.Printf("  %s FAIL\n", step.name)
+			fmt.Fprintln(os.Stderr, summarizeFailure(result.Output, 10))
+			failed++
+			continue
+		}
+		ok++
+		fmt.Printf("  %s ok\n", step.name)
+	}
+
+	if ok > 0 {
+		reportArtifactSizes(rootDir)
+	}
+
+	fmt.Printf("\nrelease v%s: %d built, %d failed, %d skipped\n", version, ok, failed, len(steps)-ok-failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// lastOutputLines keeps error output readable when a build dumps pages.
+func lastOutputLines(output string, n int) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
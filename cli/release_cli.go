@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// release:cli turns "build from source" into "brew install". It
+// cross-compiles the CLI for every platform teammates actually use,
+// writes a checksums file, creates a GitHub release with `gh`, and
+// rewrites the Homebrew tap formula with the new version and sha256s.
+// The tap lives in hipsterreed/homebrew-lurelands and is assumed to be
+// checked out as a sibling of the monorepo (or pointed at via --tap).
+
+var releasePlatforms = []struct {
+	goos, goarch string
+}{
+	{"darwin", "amd64"},
+	{"darwin", "arm64"},
+	{"linux", "amd64"},
+	{"linux", "arm64"},
+	{"windows", "amd64"},
+	{"windows", "arm64"},
+}
+
+func releaseDir(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "release")
+}
+
+func releaseArtifactName(goos, goarch string) string {
+	name := "lurelands-" + goos + "-" + goarch
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// buildReleaseArtifacts cross-compiles into the release dir and returns
+// artifact path → sha256.
+func buildReleaseArtifacts(ctx context.Context, rootDir, version string) (map[string]string, error) {
+	dir := releaseDir(rootDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	digests := map[string]string{}
+	for _, platform := range releasePlatforms {
+		out := filepath.Join(dir, releaseArtifactName(platform.goos, platform.goarch))
+		fmt.Printf("  building %s/%s… ", platform.goos, platform.goarch)
+		result, err := runner.Run(ctx, runner.Spec{
+			Name: "go",
+			Args: []string{"build", "-trimpath",
+				"-ldflags", "-s -w -X main.cliVersion=" + version,
+				"-o", out, "."},
+			Dir: filepath.Join(rootDir, "cli"),
+			Env: append(os.Environ(), "GOOS="+platform.goos, "GOARCH="+platform.goarch, "CGO_ENABLED=0"),
+		})
+		if err != nil {
+			fmt.Println("FAIL")
+			return nil, fmt.Errorf("%s/%s: %w\n%s", platform.goos, platform.goarch, err, result.Output)
+		}
+		hash, err := hashFile(out)
+		if err != nil {
+			return nil, err
+		}
+		digests[out] = hash
+		fmt.Println("ok")
+	}
+	return digests, nil
+}
+
+func writeChecksums(dir string, digests map[string]string) (string, error) {
+	var lines []string
+	for path, hash := range digests {
+		lines = append(lines, hash+"  "+filepath.Base(path))
+	}
+	path := filepath.Join(dir, "checksums.txt")
+	return path, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+}
+
+// brewFormula renders the tap formula; Homebrew only serves macOS and
+// Linux so the windows artifacts are not referenced.
+func brewFormula(version string, digests map[string]string) string {
+	digestFor := func(goos, goarch string) string {
+		for path, hash := range digests {
+			if filepath.Base(path) == releaseArtifactName(goos, goarch) {
+				return hash
+			}
+		}
+		return ""
+	}
+	base := "https://github.com/hipsterreed/lurelands/releases/download/cli-v" + version
+	return fmt.Sprintf(`# Generated by lurelands release:cli — do not edit by hand.
+class Lurelands < Formula
+  desc "Dev CLI for the Lurelands monorepo"
+  homepage "https://github.com/hipsterreed/lurelands"
+  version %[1]q
+
+  on_macos do
+    on_intel do
+      url "%[2]s/lurelands-darwin-amd64"
+      sha256 %[3]q
+    end
+    on_arm do
+      url "%[2]s/lurelands-darwin-arm64"
+      sha256 %[4]q
+    end
+  end
+
+  on_linux do
+    on_intel do
+      url "%[2]s/lurelands-linux-amd64"
+      sha256 %[5]q
+    end
+    on_arm do
+      url "%[2]s/lurelands-linux-arm64"
+      sha256 %[6]q
+    end
+  end
+
+  def install
+    bin.install Dir["lurelands-*"].first => "lurelands"
+  end
+
+  test do
+    system bin/"lurelands", "help"
+  end
+end
+`, version, base,
+		digestFor("darwin", "amd64"), digestFor("darwin", "arm64"),
+		digestFor("linux", "amd64"), digestFor("linux", "arm64"))
+}
+
+func runReleaseCLI(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("release cli", flag.ContinueOnError)
+	version := flags.String("version", "", "release version (e.g. 0.4.0)")
+	tapDir := flags.String("tap", filepath.Join(rootDir, "..", "homebrew-lurelands"), "Homebrew tap checkout")
+	skipPublish := flags.Bool("skip-publish", false, "build artifacts and formula only")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *version == "" {
+		fmt.Fprintln(os.Stderr, "error: --version is required")
+		return exitUsage
+	}
+
+	digests, err := buildReleaseArtifacts(ctx, rootDir, *version)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	checksums, err := writeChecksums(releaseDir(rootDir), digests)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	if _, err := os.Stat(*tapDir); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: tap checkout %s not found — formula not updated\n", *tapDir)
+	} else {
+		formulaPath := filepath.Join(*tapDir, "Formula", "lurelands.rb")
+		if err := os.MkdirAll(filepath.Dir(formulaPath), 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: could not update tap formula:", err)
+		} else if err := os.WriteFile(formulaPath, []byte(brewFormula(*version, digests)), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: could not update tap formula:", err)
+		} else {
+			fmt.Println("  updated", formulaPath)
+		}
+	}
+
+	if *skipPublish {
+		fmt.Printf("built %d artifact(s) in %s (publish skipped)\n", len(digests), releaseDir(rootDir))
+		return 0
+	}
+
+	if _, ok := lookupTool(rootDir, "gh"); !ok {
+		return reportError(errToolNotFound("gh", "creating the GitHub release"))
+	}
+	ghArgs := []string{"release", "create", "cli-v" + *version,
+		"--title", "CLI v" + *version, "--generate-notes", checksums}
+	for path := range digests {
+		ghArgs = append(ghArgs, path)
+	}
+	result, err := runner.Run(ctx, runner.Spec{Name: "gh", Args: ghArgs, Dir: rootDir})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error: gh release create:", err)
+		fmt.Fprintln(os.Stderr, result.Output)
+		return 1
+	}
+	fmt.Printf("released cli-v%s with %d artifact(s); remember to push the tap\n", *version, len(digests))
+	return 0
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Remote execution targets. A task that accepts --target runs its
+// command over ssh on the named machine instead of locally, with the
+// same streamed output — so `lurelands bridge start --target
+// staging-host` tails the real staging bridge from your terminal. The
+// registry is small and static like environments; hosts that need keys
+// are expected to be in ~/.ssh/config.
+
+type remoteTarget struct {
+	Host string // ssh destination (user@host or a ~/.ssh/config alias)
+	Dir  string // checkout root on the remote machine
+}
+
+var remoteTargets = map[string]remoteTarget{
+	"staging-host": {Host: "deploy@bridge-staging.lurelands.com", Dir: "/srv/lurelands"},
+	"prod-host":    {Host: "deploy@bridge.lurelands.com", Dir: "/srv/lurelands"},
+}
+
+// applyRemoteTarget rewrites spec for the named target; "" means local
+// and returns the spec untouched. spec.Dir must be relative to the
+// workspace root so it can be rebased onto the remote checkout.
+func applyRemoteTarget(targetName, rootDir string, spec runner.Spec) (runner.Spec, error) {
+	if targetName == "" {
+		return spec, nil
+	}
+	target, ok := remoteTargets[targetName]
+	if !ok {
+		names := make([]string, 0, len(remoteTargets))
+		for name := range remoteTargets {
+			names = append(names, name)
+		}
+		return runner.Spec{}, fmt.Errorf("unknown target %q (have: %s)", targetName, strings.Join(names, ", "))
+	}
+	dir := target.Dir
+	if rel, ok := strings.CutPrefix(spec.Dir, rootDir); ok && rel != "" {
+		dir += rel // same layout on both sides of the ssh hop
+	}
+	return runner.Remote(target.Host, dir, spec), nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Remote artifact cache. When LURELANDS_REMOTE_CACHE is set to a base
+// URL, build steps try to pull artifacts keyed by their input hash
+// before building, and push what they build afterwards — so developers
+// download the wasm CI already produced instead of rebuilding it. The
+// protocol is deliberately plain HTTP (GET/PUT of /<key> plus a
+// /<key>.sha256 digest sidecar): it works against S3/GCS bucket
+// endpoints, nginx with dav, or a five-line file server, with no cloud
+// SDK in the dependency tree. Every download is verified against the
+// digest before use.
+
+func remoteCacheBase() string {
+	return os.Getenv("LURELANDS_REMOTE_CACHE")
+}
+
+var remoteCacheClient = &http.Client{Timeout: 2 * time.Minute}
+
+// pullArtifact fetches key into dest, returning false (not an error)
+// on a cache miss. A digest mismatch is an error — a corrupt or
+// tampered artifact must never be used silently.
+func pullArtifact(ctx context.Context, base, key, dest string) (bool, error) {
+	digest, ok, err := fetchRemote(ctx, base+"/"+key+".sha256")
+	if err != nil || !ok {
+		return false, err
+	}
+	body, ok, err := fetchRemote(ctx, base+"/"+key)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	sum := sha256.Sum256(body)
+	if got, want := hex.EncodeToString(sum[:]), string(bytes.TrimSpace(digest)); got != want {
+		return false, fmt.Errorf("remote cache %s: digest mismatch (got %s, want %s)", key, got, want)
+	}
+	return true, os.WriteFile(dest, body, 0o644)
+}
+
+// pushArtifact uploads src under key, digest first so a reader never
+// sees an artifact without its digest. Push failures are the caller's
+// to ignore — a broken cache should not fail a build that succeeded.
+func pushArtifact(ctx context.Context, base, key, src string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	if err := putRemote(ctx, base+"/"+key+".sha256", []byte(hex.EncodeToString(sum[:]))); err != nil {
+		return err
+	}
+	return putRemote(ctx, base+"/"+key, data)
+}
+
+func fetchRemote(ctx context.Context, url string) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := remoteCacheClient.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		io.Copy(io.Discard, resp.Body)
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("remote cache GET %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	return body, err == nil, err
+}
+
+func putRemote(ctx context.Context, url string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp, err := remoteCacheClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("remote cache PUT %s: %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Retry policies for commands that talk to the network. A transient
+// maincloud hiccup should cost a few seconds, not a re-run of the whole
+// deploy. Policies are per command name, overridable from config:
+//
+//	[retry.publish]
+//	attempts = "5"
+//	backoff = "3s"
+//	on = "connection reset|gateway timeout"
+//
+// The `on` pattern is matched against the failed attempt's output;
+// an error that doesn't match is treated as real and not retried.
+
+type retryPolicy struct {
+	attempts int
+	backoff  time.Duration // doubled after each failed attempt
+	on       *regexp.Regexp
+}
+
+var transientNetworkPattern = regexp.MustCompile(
+	`(?i)connection (reset|refused|closed)|timed? ?out|temporarily unavailable|TLS handshake|EOF|503|502|network is unreachable`)
+
+// defaultRetryPolicies covers the commands that fail transiently in
+// practice. Everything else runs once unless config says otherwise.
+var defaultRetryPolicies = map[string]retryPolicy{
+	"publish": {attempts: 3, backoff: 2 * time.Second, on: transientNetworkPattern},
+}
+
+// retryPolicyFor resolves the policy for a command name: the built-in
+// default overlaid with any [retry.<name>] config section. A command
+// with neither gets a single attempt.
+func retryPolicyFor(rootDir, name string) retryPolicy {
+	policy, ok := defaultRetryPolicies[name]
+	if !ok {
+		policy = retryPolicy{attempts: 1, on: transientNetworkPattern}
+	}
+	values, ok := loadConfigSections(rootDir)["retry."+name]
+	if !ok {
+		return policy
+	}
+	if raw := values["attempts"]; raw != "" {
+		if attempts, err := strconv.Atoi(raw); err == nil {
+			policy.attempts = attempts
+		}
+	}
+	if raw := values["backoff"]; raw != "" {
+		if backoff, err := time.ParseDuration(raw); err == nil {
+			policy.backoff = backoff
+		}
+	}
+	if raw := values["on"]; raw != "" {
+		if pattern, err := regexp.Compile(raw); err == nil {
+			policy.on = pattern
+		} else {
+			fmt.Printf("warning: [retry.%s] on pattern does not compile — keeping default\n", name)
+		}
+	}
+	if policy.attempts < 1 {
+		policy.attempts = 1
+	}
+	return policy
+}
+
+// runWithRetry wraps runWithProgress in the command's retry policy.
+// Re-attempts show up in the progress label ("attempt 2/3") so a retry
+// never looks like a hang.
+func runWithRetry(ctx context.Context, rootDir, policyName, label, tool string, spec runner.Spec) (runner.Result, error) {
+	policy := retryPolicyFor(rootDir, policyName)
+	backoff := policy.backoff
+	var result runner.Result
+	var err error
+	for attempt := 1; attempt <= policy.attempts; attempt++ {
+		attemptLabel := label
+		if attempt > 1 {
+			attemptLabel = fmt.Sprintf("%s (attempt %d/%d)", label, attempt, policy.attempts)
+		}
+		result, err = runWithProgress(ctx, attemptLabel, tool, spec)
+		if err == nil || ctx.Err() != nil {
+			return result, err
+		}
+		if attempt == policy.attempts || (policy.on != nil && !policy.on.MatchString(result.Output)) {
+			return result, err
+		}
+		fmt.Printf("  %s failed on a transient error — retrying in %s (attempt %d/%d)\n",
+			label, backoff, attempt+1, policy.attempts)
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return result, err
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyForDefaults(t *testing.T) {
+	policy := retryPolicyFor(t.TempDir(), "publish")
+	if policy.attempts != 3 || policy.backoff != 2*time.Second {
+		t.Errorf("unexpected default publish policy: %+v", policy)
+	}
+	if policy.on == nil || !policy.on.MatchString("error: connection reset by peer") {
+		t.Error("default pattern should match a connection reset")
+	}
+	if retryPolicyFor(t.TempDir(), "doctor").attempts != 1 {
+		t.Error("commands without a policy should run once")
+	}
+}
+
+func TestRetryPolicyForConfigOverride(t *testing.T) {
+	dir := t.TempDir()
+	config := "[retry.publish]\nattempts = \"5\"\nbackoff = \"500ms\"\non = \"gateway timeout\"\n"
+	if err := os.WriteFile(filepath.Join(dir, ".lurelands.toml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	policy := retryPolicyFor(dir, "publish")
+	if policy.attempts != 5 || policy.backoff != 500*time.Millisecond {
+		t.Errorf("config override not applied: %+v", policy)
+	}
+	if !policy.on.MatchString("gateway timeout") || policy.on.MatchString("connection reset") {
+		t.Error("on pattern should be replaced, not merged")
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// `lurelands run` launches the app on a picked device instead of a
+// guessed platform name. With no --device it opens a picker over the
+// device cache — the cached list appears instantly with a refreshing
+// indicator while the background probe runs — pre-selecting the
+// last-used device from state.json. --device <id> skips the picker for
+// scripts; the selection is remembered either way.
+
+type devicesRefreshedMsg []device
+
+type devicePickerModel struct {
+	devices    []device
+	fresh      <-chan []device
+	refreshing bool
+	cursor     int
+	lastUsed   string
+	chosen     string
+}
+
+func newDevicePicker(devices []device, fresh <-chan []device, lastUsed string) devicePickerModel {
+	m := devicePickerModel{devices: devices, fresh: fresh, refreshing: fresh != nil, lastUsed: lastUsed}
+	m.seekLastUsed()
+	return m
+}
+
+func (m *devicePickerModel) seekLastUsed() {
+	for i, d := range m.devices {
+		if d.ID == m.lastUsed {
+			m.cursor = i
+		}
+	}
+}
+
+func (m devicePickerModel) Init() tea.Cmd {
+	if m.fresh == nil {
+		return nil
+	}
+	fresh := m.fresh
+	return func() tea.Msg { return devicesRefreshedMsg(<-fresh) }
+}
+
+func (m devicePickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case devicesRefreshedMsg:
+		m.devices = msg
+		m.refreshing = false
+		if m.cursor >= len(m.devices) {
+			m.cursor = 0
+		}
+		m.seekLastUsed()
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.devices)-1 {
+				m.cursor++
+			}
+		case "enter":
+			if len(m.devices) > 0 {
+				m.chosen = m.devices[m.cursor].ID
+				return m, tea.Quit
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m devicePickerModel) View() string {
+	title := "pick a device"
+	if m.refreshing {
+		title += " (refreshing…)"
+	}
+	s := liveTitleStyle.Render(title) + "\n\n"
+	if len(m.devices) == 0 {
+		s += liveDimStyle.Render("no devices yet — waiting for flutter…") + "\n"
+	}
+	for i, d := range m.devices {
+		kind := d.Platform
+		if d.Emulator {
+			kind += ", emulator"
+		}
+		line := fmt.Sprintf("%-28s %-24s %s", d.Name, tui.Accent.Render(d.ID), liveDimStyle.Render(kind))
+		if i == m.cursor {
+			line = tui.Selected.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		s += line + "\n"
+	}
+	return s + "\n" + liveDimStyle.Render("↑/↓ select · enter run · esc cancel")
+}
+
+// pickDevice resolves the device to run on: the flag wins, a single
+// device is taken as-is, otherwise the picker decides.
+func pickDevice(ctx context.Context, rootDir, flagDevice string) (string, error) {
+	if flagDevice != "" {
+		return flagDevice, nil
+	}
+	if ciMode {
+		return "", fmt.Errorf("the device picker is unavailable with --ci — pass --device <id>")
+	}
+	devices, fresh := listDevices(ctx, rootDir)
+	if fresh == nil && len(devices) == 1 {
+		return devices[0].ID, nil
+	}
+	picker := newDevicePicker(devices, fresh, loadUserState(rootDir).LastDevice)
+	final, err := tea.NewProgram(picker, tea.WithAltScreen()).Run()
+	if err != nil {
+		return "", err
+	}
+	chosen := final.(devicePickerModel).chosen
+	if chosen == "" {
+		return "", fmt.Errorf("no device selected (is a simulator running? `lurelands devices` to check)")
+	}
+	return chosen, nil
+}
+
+func runApp(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	deviceID := flags.String("device", "", "device id (skips the picker)")
+	profile := flags.Bool("profile", false, "run in profile mode")
+	release := flags.Bool("release", false, "run in release mode")
+	flavor := flags.String("flavor", "", "build flavor to pass to flutter")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *profile && *release {
+		fmt.Fprintln(os.Stderr, "error: --profile and --release are mutually exclusive")
+		return exitUsage
+	}
+	flagMode := ""
+	if *profile {
+		flagMode = "profile"
+	}
+	if *release {
+		flagMode = "release"
+	}
+	if _, ok := lookupTool(rootDir, "flutter"); !ok {
+		return reportError(errToolNotFound("flutter", "running the app"))
+	}
+
+	chosen, err := pickDevice(ctx, rootDir, *deviceID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	mode, err := pickRunMode(rootDir, flagMode)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	state := loadUserState(rootDir)
+	state.LastDevice = chosen
+	state.LastRunMode = mode
+	saveUserState(rootDir, state)
+
+	// The app's .env (plus the current environment's overlay) rides along
+	// like it does under `lurelands dev`, and the environment itself goes
+	// in as dart-defines so the app connects to the right module.
+	env, _ := resolveEnvironment(envOrCurrent("", "local"))
+
+	runArgs := append([]string{"run", "-d", chosen}, modeFlutterArgs(mode, *flavor)...)
+	runArgs = append(runArgs, dartDefines(env)...)
+	fmt.Printf("flutter %s\n", strings.Join(runArgs, " "))
+	if err := runner.RunInteractive(ctx, pinnedSpec(rootDir, runner.Spec{
+		Name: "flutter",
+		Args: append(runArgs, flags.Args()...),
+		Dir:  appDir(rootDir),
+		Env:  append(os.Environ(), dotEnvPairs(appDir(rootDir), env)...),
+	})); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
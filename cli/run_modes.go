@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// Build mode and flavor selection for `lurelands run`. Flags win
+// (--profile, --release, --flavor beta); with no mode flag in an
+// interactive session, a second prompt follows the device picker so
+// "run in profile mode" never requires remembering flutter's spelling.
+// The chosen mode is remembered like the device is.
+
+var runModes = []struct {
+	name string
+	desc string
+}{
+	{"debug", "hot reload, assertions on"},
+	{"profile", "performance tracing, debug overhead off"},
+	{"release", "full optimization, no tooling"},
+}
+
+// modeFlutterArgs translates mode and flavor into flutter arguments.
+// Debug is flutter's default and needs no flag.
+func modeFlutterArgs(mode, flavor string) []string {
+	var args []string
+	if mode == "profile" || mode == "release" {
+		args = append(args, "--"+mode)
+	}
+	if flavor != "" {
+		args = append(args, "--flavor", flavor)
+	}
+	return args
+}
+
+// dartDefines exposes the active environment to the app at build time.
+// Process env vars don't reach Dart code; --dart-define does.
+func dartDefines(env environment) []string {
+	return []string{
+		"--dart-define=LURELANDS_ENV=" + env.Name,
+		"--dart-define=SPACETIME_SERVER=" + env.Server,
+		"--dart-define=SPACETIME_MODULE=" + env.Module,
+	}
+}
+
+type modePickerModel struct {
+	cursor int
+	chosen string
+}
+
+func (m modePickerModel) Init() tea.Cmd { return nil }
+
+func (m modePickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(runModes)-1 {
+				m.cursor++
+			}
+		case "enter":
+			m.chosen = runModes[m.cursor].name
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m modePickerModel) View() string {
+	s := liveTitleStyle.Render("build mode") + "\n\n"
+	for i, mode := range runModes {
+		line := fmt.Sprintf("%-10s %s", mode.name, tui.Accent.Render(mode.desc))
+		if i == m.cursor {
+			line = tui.Selected.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		s += line + "\n"
+	}
+	return s + "\n" + liveDimStyle.Render("↑/↓ select · enter run · esc cancel")
+}
+
+// pickRunMode resolves the build mode: the flag wins, CI gets debug,
+// otherwise the prompt decides, pre-selecting the last-used mode.
+func pickRunMode(rootDir, flagMode string) (string, error) {
+	if flagMode != "" {
+		return flagMode, nil
+	}
+	if ciMode {
+		return "debug", nil
+	}
+	picker := modePickerModel{}
+	for i, mode := range runModes {
+		if mode.name == loadUserState(rootDir).LastRunMode {
+			picker.cursor = i
+		}
+	}
+	final, err := tea.NewProgram(picker).Run()
+	if err != nil {
+		return "", err
+	}
+	chosen := final.(modePickerModel).chosen
+	if chosen == "" {
+		return "", fmt.Errorf("no build mode selected")
+	}
+	return chosen, nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Production safety gates. A short list of commands is considered
+// dangerous when aimed at prod — they require typing the target module
+// name before they run, and they warn when the working tree is dirty
+// or the branch isn't main (a prod deploy should come from reviewed,
+// committed code). `--yes` skips the typed confirmation for CI but
+// keeps the warnings. The gate sits in front of dispatch, so it holds
+// for direct commands, the menu, and pipelines alike; config can
+// extend the list:
+//
+//	[safety]
+//	commands = "deploy, k8s apply, player ban"
+
+var dangerousDefaults = []string{"deploy", "k8s apply", "infra apply", "db restore", "bridge deploy"}
+
+func dangerousCommands(rootDir string) []string {
+	commands := append([]string(nil), dangerousDefaults...)
+	if extra := loadConfigSections(rootDir)["safety"]["commands"]; extra != "" {
+		for _, name := range strings.Split(extra, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				commands = append(commands, name)
+			}
+		}
+	}
+	return commands
+}
+
+// extractYesFlag pulls a global --yes out of args, wherever it sits;
+// individual commands never see it.
+func extractYesFlag(args []string) ([]string, bool) {
+	yes := false
+	kept := args[:0]
+	for _, arg := range args {
+		if arg == "--yes" || arg == "-y" {
+			yes = true
+			continue
+		}
+		kept = append(kept, arg)
+	}
+	return kept, yes
+}
+
+// targetEnvName is the environment a command line is aimed at: an
+// explicit --env wins, then the sticky current environment.
+func targetEnvName(args []string) string {
+	for i, arg := range args {
+		if arg == "--env" && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(arg, "--env=") {
+			return strings.TrimPrefix(arg, "--env=")
+		}
+	}
+	return currentEnvName
+}
+
+func gitSaysRisky(rootDir string) []string {
+	var warnings []string
+	if out, err := exec.Command("git", "-C", rootDir, "status", "--porcelain").Output(); err == nil && len(out) > 0 {
+		warnings = append(warnings, "the working tree has uncommitted changes")
+	}
+	if out, err := exec.Command("git", "-C", rootDir, "rev-parse", "--abbrev-ref", "HEAD").Output(); err == nil {
+		branch := strings.TrimSpace(string(out))
+		if branch != "main" && branch != "master" {
+			warnings = append(warnings, "you are on branch "+branch+", not main")
+		}
+	}
+	return warnings
+}
+
+// confirmDangerous gates one command line. It returns the args with
+// --yes stripped and whether dispatch should proceed.
+func confirmDangerous(ctx context.Context, rootDir string, args []string) ([]string, bool) {
+	args, yes := extractYesFlag(args)
+	if targetEnvName(args) != "prod" {
+		return args, true
+	}
+	path := strings.Join(args, " ")
+	dangerous := false
+	for _, name := range dangerousCommands(rootDir) {
+		if path == name || strings.HasPrefix(path, name+" ") {
+			dangerous = true
+		}
+	}
+	if !dangerous {
+		return args, true
+	}
+
+	for _, warning := range gitSaysRisky(rootDir) {
+		fmt.Fprintln(os.Stderr, "warning:", warning)
+	}
+	if yes {
+		return args, true
+	}
+
+	module := environments["prod"].Module
+	fmt.Fprintf(os.Stderr, "this will run against PROD — type the module name (%s) to continue: ", module)
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != module {
+		fmt.Fprintln(os.Stderr, "aborted (pass --yes to skip confirmation in CI)")
+		return args, false
+	}
+	return args, true
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// jsonSchema is the (deliberately small) subset of JSON Schema our content
+// schemas use: type, required, properties, items, enum, minimum/maximum and
+// minItems/maxItems. Anything fancier belongs in Go validation code instead.
+type jsonSchema struct {
+	Type       string                 `json:"type"`
+	Required   []string               `json:"required"`
+	Properties map[string]*jsonSchema `json:"properties"`
+	Items      *jsonSchema            `json:"items"`
+	Enum       []string               `json:"enum"`
+	Minimum    *float64               `json:"minimum"`
+	Maximum    *float64               `json:"maximum"`
+	MinItems   *int                   `json:"minItems"`
+	MaxItems   *int                   `json:"maxItems"`
+}
+
+func loadSchema(path string) (*jsonSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s jsonSchema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// validateSchema checks a decoded JSON value against a schema, appending one
+// message per violation. path is a JSON-pointer-ish location like
+// "fish[3].sprite.row" used in the report.
+func validateSchema(s *jsonSchema, value any, path string, violations *[]string) {
+	report := func(format string, args ...any) {
+		*violations = append(*violations, fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...)))
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			report("expected object, got %T", value)
+			return
+		}
+		for _, key := range s.Required {
+			if _, present := obj[key]; !present {
+				report("missing required field %q", key)
+			}
+		}
+		for key, sub := range s.Properties {
+			if v, present := obj[key]; present {
+				validateSchema(sub, v, path+"."+key, violations)
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			report("expected array, got %T", value)
+			return
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			report("expected at least %d items, got %d", *s.MinItems, len(arr))
+		}
+		if s.MaxItems != nil && len(arr) > *s.MaxItems {
+			report("expected at most %d items, got %d", *s.MaxItems, len(arr))
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				validateSchema(s.Items, item, fmt.Sprintf("%s[%d]", path, i), violations)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			report("expected string, got %T", value)
+			return
+		}
+		if str == "" {
+			report("must not be empty")
+		}
+		if len(s.Enum) > 0 && !contains(s.Enum, str) {
+			report("%q is not one of %v", str, s.Enum)
+		}
+	case "number", "integer":
+		num, ok := value.(float64)
+		if !ok {
+			report("expected %s, got %T", s.Type, value)
+			return
+		}
+		if s.Type == "integer" && num != math.Trunc(num) {
+			report("expected integer, got %v", num)
+		}
+		if s.Minimum != nil && num < *s.Minimum {
+			report("%v is below minimum %v", num, *s.Minimum)
+		}
+		if s.Maximum != nil && num > *s.Maximum {
+			report("%v is above maximum %v", num, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			report("expected boolean, got %T", value)
+		}
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
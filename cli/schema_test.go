@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSchemaReportsViolations(t *testing.T) {
+	min := 1.0
+	schema := &jsonSchema{
+		Type:     "object",
+		Required: []string{"id", "tier"},
+		Properties: map[string]*jsonSchema{
+			"id":         {Type: "string"},
+			"tier":       {Type: "integer", Minimum: &min},
+			"water_type": {Type: "string", Enum: []string{"pond", "river"}},
+		},
+	}
+
+	value := map[string]any{
+		"tier":       0.0,
+		"water_type": "lava",
+	}
+
+	var violations []string
+	validateSchema(schema, value, "fish[0]", &violations)
+
+	if len(violations) != 3 {
+		t.Fatalf("expected 3 violations, got %d: %v", len(violations), violations)
+	}
+	wantFragments := []string{"missing required field \"id\"", "below minimum", "not one of"}
+	for _, fragment := range wantFragments {
+		found := false
+		for _, v := range violations {
+			if strings.Contains(v, fragment) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no violation containing %q in %v", fragment, violations)
+		}
+	}
+}
+
+func TestValidateSchemaAcceptsValidValue(t *testing.T) {
+	schema := &jsonSchema{
+		Type:  "array",
+		Items: &jsonSchema{Type: "number"},
+	}
+	var violations []string
+	validateSchema(schema, []any{1.0, 2.5}, "weights", &violations)
+	if len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+}
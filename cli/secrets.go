@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Secrets. Task arguments and env values may reference secrets as
+// {{secret:MAINCLOUD_TOKEN}}; the reference is resolved the moment the
+// command runs and the plaintext never touches the config, shell
+// history, or disk. Resolution tries the managers people here actually
+// use, in order: 1Password CLI (op), then Doppler, then a SOPS-encrypted
+// .lurelands/secrets.yaml. Every resolved value is registered with the
+// runner's redaction list, so captured logs and TUI output show •••••
+// where the value would be.
+
+var secretRefPattern = regexp.MustCompile(`\{\{secret:([A-Za-z0-9_]+)\}\}`)
+
+// secretCache avoids prompting the manager repeatedly for the same key
+// within one CLI invocation.
+var (
+	secretCacheMu sync.Mutex
+	secretCache   = map[string]string{}
+)
+
+func resolveSecret(ctx context.Context, rootDir, name string) (string, error) {
+	secretCacheMu.Lock()
+	if value, ok := secretCache[name]; ok {
+		secretCacheMu.Unlock()
+		return value, nil
+	}
+	secretCacheMu.Unlock()
+
+	attempts := []struct {
+		tool string
+		spec runner.Spec
+	}{
+		{"op", runner.Spec{Name: "op", Args: []string{"read", "op://Lurelands/" + name + "/password"}}},
+		{"doppler", runner.Spec{Name: "doppler", Args: []string{"secrets", "get", name, "--plain"}}},
+		{"sops", runner.Spec{Name: "sops", Args: []string{"--decrypt", "--extract", `["` + name + `"]`,
+			filepath.Join(rootDir, ".lurelands", "secrets.yaml")}}},
+	}
+	for _, attempt := range attempts {
+		if _, ok := lookupTool(rootDir, attempt.tool); !ok {
+			continue
+		}
+		result, err := runner.Run(ctx, attempt.spec)
+		if err != nil {
+			continue // fall through: the key may live in the next manager
+		}
+		value := strings.TrimSpace(result.Output)
+		if value == "" {
+			continue
+		}
+		secretCacheMu.Lock()
+		secretCache[name] = value
+		secretCacheMu.Unlock()
+		runner.Redact(value)
+		return value, nil
+	}
+	return "", fmt.Errorf("secret %s not found in any configured manager (op, doppler, sops)", name)
+}
+
+// expandSecretRefs replaces {{secret:NAME}} references in s.
+func expandSecretRefs(ctx context.Context, rootDir, s string) (string, error) {
+	var firstErr error
+	expanded := secretRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := secretRefPattern.FindStringSubmatch(ref)[1]
+		value, err := resolveSecret(ctx, rootDir, name)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return ref
+		}
+		return value
+	})
+	return expanded, firstErr
+}
+
+// expandSpecSecrets resolves secret references in a spec's args and env
+// just before it runs.
+func expandSpecSecrets(ctx context.Context, rootDir string, spec runner.Spec) (runner.Spec, error) {
+	for i, arg := range spec.Args {
+		expanded, err := expandSecretRefs(ctx, rootDir, arg)
+		if err != nil {
+			return spec, err
+		}
+		spec.Args[i] = expanded
+	}
+	for i, kv := range spec.Env {
+		expanded, err := expandSecretRefs(ctx, rootDir, kv)
+		if err != nil {
+			return spec, err
+		}
+		spec.Env[i] = expanded
+	}
+	return spec, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Service registry. Each service under services/ gets a section in
+// .lurelands.toml declaring how to work on it:
+//
+//	[service.bridge]
+//	dev = "bun run start"
+//	build = "bun run build"
+//	test = "bun test"
+//
+// new:service writes these sections; the dev orchestrator and doctor
+// read them so a freshly scaffolded service shows up everywhere without
+// further wiring. Parsing follows the same flat key = "value" subset
+// as the [toolchain] section.
+
+type serviceTasks struct {
+	Name  string
+	Dev   string
+	Build string
+	Test  string
+}
+
+func registeredServices(rootDir string) []serviceTasks {
+	var services []serviceTasks
+	for section, values := range loadConfigSections(rootDir) {
+		name, ok := strings.CutPrefix(section, "service.")
+		if !ok {
+			continue
+		}
+		services = append(services, serviceTasks{
+			Name:  name,
+			Dev:   values["dev"],
+			Build: values["build"],
+			Test:  values["test"],
+		})
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+	return services
+}
+
+// serviceDir is where a registered service's checkout lives.
+func serviceDir(rootDir, name string) string {
+	return filepath.Join(rootDir, "services", name)
+}
+
+// appendServiceSection adds a [service.<name>] section to
+// .lurelands.toml, creating the file if this is a bare checkout.
+func appendServiceSection(rootDir string, tasks serviceTasks) error {
+	path := filepath.Join(rootDir, ".lurelands.toml")
+	existing, _ := os.ReadFile(path)
+	section := "\n[service." + tasks.Name + "]\n"
+	for _, kv := range []struct{ key, value string }{
+		{"dev", tasks.Dev}, {"build", tasks.Build}, {"test", tasks.Test},
+	} {
+		if kv.value != "" {
+			section += kv.key + " = \"" + kv.value + "\"\n"
+		}
+	}
+	return os.WriteFile(path, append(existing, []byte(section)...), 0o644)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// Session logs: the full stdout/stderr of every command lands in
+// .lurelands/logs/<timestamp>-<command>.log, so "what did that deploy
+// actually print" has an answer after the terminal scrolled away.
+// Implemented as a tee — os.Stdout/os.Stderr are swapped for pipes that
+// copy to both the real terminal and the file, which also catches
+// children that inherit our stdio. Full-screen TUIs are skipped (their
+// escape-sequence soup is not worth keeping), and the directory is
+// pruned by age and total size on every start.
+
+const (
+	sessionLogMaxAge   = 7 * 24 * time.Hour
+	sessionLogMaxTotal = 50 << 20 // bytes
+)
+
+// sessionLogSkip lists commands whose output is a live screen, not a
+// transcript.
+var sessionLogSkip = map[string]bool{
+	"menu": true, "sql": true, "live": true, "stats": true, "mod": true,
+	"content": true, "shell": true, "crashes": true, "errors": true,
+}
+
+var sessionLogSanitize = regexp.MustCompile(`[^a-z0-9-]+`)
+
+func sessionLogsDir(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "logs")
+}
+
+func sessionLogName(args []string, at time.Time) string {
+	// Up to two path segments name the file: "db-start", not just "db".
+	var parts []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		parts = append(parts, sessionLogSanitize.ReplaceAllString(strings.ToLower(arg), "-"))
+		if len(parts) == 2 {
+			break
+		}
+	}
+	if len(parts) == 0 {
+		parts = []string{"none"}
+	}
+	return at.Format("20060102-150405") + "-" + strings.Join(parts, "-") + ".log"
+}
+
+// startSessionLog begins teeing; the returned stop restores stdio and
+// finalizes the file. Any setup failure just means no log — the
+// command itself must not care.
+func startSessionLog(rootDir string, args []string) func(exitCode int) {
+	noop := func(int) {}
+	if len(args) == 0 || sessionLogSkip[args[0]] {
+		return noop
+	}
+	dir := sessionLogsDir(rootDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return noop
+	}
+	pruneSessionLogs(dir)
+	file, err := os.Create(filepath.Join(dir, sessionLogName(args, time.Now())))
+	if err != nil {
+		return noop
+	}
+	fmt.Fprintf(file, "$ lurelands %s\n", strings.Join(args, " "))
+
+	origOut, origErr := os.Stdout, os.Stderr
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		file.Close()
+		return noop
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		outR.Close()
+		outW.Close()
+		file.Close()
+		return noop
+	}
+	os.Stdout, os.Stderr = outW, errW
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); io.Copy(io.MultiWriter(origOut, file), outR) }()
+	go func() { defer wg.Done(); io.Copy(io.MultiWriter(origErr, file), errR) }()
+
+	return func(exitCode int) {
+		outW.Close()
+		errW.Close()
+		wg.Wait()
+		os.Stdout, os.Stderr = origOut, origErr
+		fmt.Fprintf(file, "— exit %d —\n", exitCode)
+		file.Close()
+	}
+}
+
+// pruneSessionLogs deletes logs past the age cap, then the oldest ones
+// until the directory fits the size budget.
+func pruneSessionLogs(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	type logFile struct {
+		path string
+		mod  time.Time
+		size int64
+	}
+	var logs []logFile
+	var total int64
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		if time.Since(info.ModTime()) > sessionLogMaxAge {
+			os.Remove(filepath.Join(dir, entry.Name()))
+			continue
+		}
+		logs = append(logs, logFile{filepath.Join(dir, entry.Name()), info.ModTime(), info.Size()})
+		total += info.Size()
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].mod.Before(logs[j].mod) })
+	for _, log := range logs {
+		if total <= sessionLogMaxTotal {
+			break
+		}
+		os.Remove(log.path)
+		total -= log.size
+	}
+}
+
+// runLogsLast prints the most recent session log.
+func runLogsLast(ctx context.Context, rootDir string, args []string) int {
+	entries, err := os.ReadDir(sessionLogsDir(rootDir))
+	if err != nil || len(entries) == 0 {
+		fmt.Println("no session logs yet")
+		return 1
+	}
+	// Timestamped names sort chronologically; the last non-dir entry is
+	// the newest — which is this very command's own log, so take the one
+	// before it when there is one.
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	if len(names) >= 2 {
+		names = names[:len(names)-1]
+	}
+	path := filepath.Join(sessionLogsDir(rootDir), names[len(names)-1])
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Println(tui.Dim.Render("— " + path + " —"))
+	os.Stdout.Write(data)
+	return 0
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// shell is a persistent prompt over the same command tree, for heavy
+// users who run dozens of tasks a session and don't want per-invocation
+// process startup. It is deliberately line-based (works over ssh and in
+// any terminal): completion is unambiguous-prefix expansion rather than
+// tab — `cont val` runs `content validate` — plus history with `!N`
+// recall, persisted across sessions in .lurelands/shell_history.
+
+// Registered in init because runShell walks commandTree itself
+// (prefix expansion, re-dispatch), which would otherwise be an
+// initialization cycle.
+func init() {
+	commandTree = append(commandTree, &command{
+		name: "shell", summary: "interactive prompt with history and prefix completion", run: runShell,
+	})
+}
+
+// envAwareShellCommands are the top-level commands that accept --env;
+// the shell's `env <name>` default is injected only for these. Keep in
+// sync when a command grows or loses the flag.
+var envAwareShellCommands = map[string]bool{
+	"bots": true, "content": true, "deploy": true, "events": true,
+	"lb": true, "live": true, "loadtest": true, "mod": true, "player": true,
+}
+
+func shellHistoryPath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "shell_history")
+}
+
+func loadShellHistory(rootDir string) []string {
+	data, err := os.ReadFile(shellHistoryPath(rootDir))
+	if err != nil {
+		return nil
+	}
+	var history []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line != "" {
+			history = append(history, line)
+		}
+	}
+	return history
+}
+
+func appendShellHistory(rootDir, line string) {
+	path := shellHistoryPath(rootDir)
+	os.MkdirAll(filepath.Dir(path), 0o755)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, line)
+}
+
+// expandShellPrefixes rewrites leading command segments to their full
+// names when the typed prefix matches exactly one command at that level
+// of the tree. Ambiguous or unknown segments pass through untouched and
+// dispatch reports them as usual.
+func expandShellPrefixes(argv []string) []string {
+	level := commandTree
+	for i, arg := range argv {
+		var match *command
+		for _, cand := range level {
+			if cand.name == arg {
+				match = cand
+				break
+			}
+			if strings.HasPrefix(cand.name, arg) {
+				if match != nil {
+					match = nil // ambiguous
+					break
+				}
+				match = cand
+			}
+		}
+		if match == nil {
+			break
+		}
+		argv[i] = match.name
+		level = match.children
+		if len(level) == 0 {
+			break
+		}
+	}
+	return argv
+}
+
+func runShell(ctx context.Context, rootDir string, args []string) int {
+	history := loadShellHistory(rootDir)
+	// Pre-select the environment the user last targeted, if any.
+	sessionEnv := loadUserState(rootDir).LastEnv
+
+	fmt.Println("lurelands shell — prefix-complete commands, `env <name>` to set a default, `exit` to leave")
+	scanner := bufio.NewScanner(os.Stdin)
+	activeWorkspace := ""
+	if registry, err := loadWorkspaceRegistry(); err == nil {
+		activeWorkspace = registry.Active
+	}
+
+	for {
+		prompt := "lurelands"
+		if activeWorkspace != "" {
+			prompt += "[" + activeWorkspace + "]"
+		}
+		if sessionEnv != "" {
+			prompt += "(" + sessionEnv + ")"
+		}
+		fmt.Printf("%s> ", prompt)
+		if !scanner.Scan() {
+			fmt.Println()
+			return 0
+		}
+		if ctx.Err() != nil {
+			return 0
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		// `!N` / `!!` recall before anything else, so the expanded line
+		// is what lands in history.
+		if strings.HasPrefix(line, "!") {
+			ref := line[1:]
+			index := len(history)
+			if ref != "!" {
+				n, err := strconv.Atoi(ref)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "bad history reference %q\n", line)
+					continue
+				}
+				index = n
+			}
+			if index < 1 || index > len(history) {
+				fmt.Fprintf(os.Stderr, "no history entry %d\n", index)
+				continue
+			}
+			line = history[index-1]
+			fmt.Println(line)
+		}
+
+		argv := strings.Fields(line)
+		switch argv[0] {
+		case "exit", "quit":
+			return 0
+		case "history":
+			for i, entry := range history {
+				fmt.Printf("%4d  %s\n", i+1, entry)
+			}
+			continue
+		case "env":
+			if len(argv) < 2 {
+				fmt.Printf("env is %q\n", sessionEnv)
+				continue
+			}
+			if _, err := resolveEnvironment(argv[1]); err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				continue
+			}
+			sessionEnv = argv[1]
+			continue
+		case "help", "?":
+			usage()
+			continue
+		}
+
+		history = append(history, line)
+		appendShellHistory(rootDir, line)
+
+		argv = expandShellPrefixes(argv)
+		hasEnv := false
+		for _, arg := range argv {
+			if arg == "--env" || strings.HasPrefix(arg, "--env=") {
+				hasEnv = true
+			}
+		}
+		if sessionEnv != "" && envAwareShellCommands[argv[0]] && !hasEnv {
+			argv = append(argv, "--env", sessionEnv)
+		}
+		recordCommand(rootDir, argv)
+		if code := dispatch(ctx, commandTree, rootDir, argv); code != 0 {
+			fmt.Fprintf(os.Stderr, "(exit %d)\n", code)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+)
+
+// Spawn simulation mirrors the server's catch roll: each cast picks a fish
+// from the biome's spawn table by weight, with the equipped lure scaling
+// weights per tier. Keep this in sync with the roll_catch reducer.
+
+// effectiveWeights returns the per-fish spawn weights for a biome, applying
+// the lure's tier multipliers when a lure is given. Fish the lure can't be
+// used on (wrong water type) are unaffected rather than excluded — a lure
+// never makes a biome's fish unreachable.
+func effectiveWeights(set *ContentSet, biome *BiomeDef, lure *LureDef) map[string]float64 {
+	fishByID := map[string]*FishDef{}
+	for i := range set.Fish {
+		fishByID[set.Fish[i].ID] = &set.Fish[i]
+	}
+
+	weights := map[string]float64{}
+	for _, entry := range biome.SpawnTable {
+		weight := entry.Weight
+		fish := fishByID[entry.FishID]
+		if lure != nil && fish != nil && contains(lure.WaterTypes, fish.WaterType) {
+			if fish.Tier >= 1 && fish.Tier <= len(lure.TierMultipliers) {
+				weight *= lure.TierMultipliers[fish.Tier-1]
+			}
+		}
+		weights[entry.FishID] = weight
+	}
+	return weights
+}
+
+func findBiome(set *ContentSet, id string) *BiomeDef {
+	for i := range set.Biomes {
+		if set.Biomes[i].ID == id {
+			return &set.Biomes[i]
+		}
+	}
+	return nil
+}
+
+func findLure(set *ContentSet, id string) *LureDef {
+	for i := range set.Lures {
+		if set.Lures[i].ID == id {
+			return &set.Lures[i]
+		}
+	}
+	return nil
+}
+
+func findFish(set *ContentSet, id string) *FishDef {
+	for i := range set.Fish {
+		if set.Fish[i].ID == id {
+			return &set.Fish[i]
+		}
+	}
+	return nil
+}
+
+func biomeIDs(set *ContentSet) []string {
+	ids := make([]string, len(set.Biomes))
+	for i, biome := range set.Biomes {
+		ids[i] = biome.ID
+	}
+	return ids
+}
+
+// simulateSpawns runs casts against the weighted table and returns realized
+// catch counts per fish id.
+func simulateSpawns(weights map[string]float64, casts int, rng *rand.Rand) map[string]int {
+	ids := make([]string, 0, len(weights))
+	for id := range weights {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	total := 0.0
+	for _, id := range ids {
+		total += weights[id]
+	}
+
+	counts := map[string]int{}
+	if total <= 0 {
+		return counts
+	}
+	for i := 0; i < casts; i++ {
+		roll := rng.Float64() * total
+		for _, id := range ids {
+			roll -= weights[id]
+			if roll < 0 {
+				counts[id]++
+				break
+			}
+		}
+	}
+	return counts
+}
+
+func runSimSpawns(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("sim:spawns", flag.ContinueOnError)
+	biomeID := flags.String("biome", "", "biome id to simulate (see content/biomes.json)")
+	lureID := flags.String("lure", "", "optional lure id to equip")
+	casts := flags.Int("casts", 100000, "number of simulated casts")
+	seed := flags.Int64("seed", 0, "random seed (0 = time-based)")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	set, err := loadContent(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	biome := findBiome(set, *biomeID)
+	if biome == nil {
+		fmt.Fprintf(os.Stderr, "unknown biome %q (available: %v)\n", *biomeID, biomeIDs(set))
+		return 1
+	}
+	var lure *LureDef
+	if *lureID != "" {
+		if lure = findLure(set, *lureID); lure == nil {
+			fmt.Fprintf(os.Stderr, "unknown lure %q\n", *lureID)
+			return 1
+		}
+	}
+
+	rng := rand.New(rand.NewSource(*seed))
+	if *seed == 0 {
+		rng = rand.New(rand.NewSource(rand.Int63()))
+	}
+
+	weights := effectiveWeights(set, biome, lure)
+	counts := simulateSpawns(weights, *casts, rng)
+
+	totalWeight := 0.0
+	for _, w := range weights {
+		totalWeight += w
+	}
+
+	fmt.Printf("Spawn simulation: %s, %d casts", biome.Name, *casts)
+	if lure != nil {
+		fmt.Printf(", lure %s", lure.Name)
+	}
+	fmt.Println()
+	fmt.Println()
+	fmt.Printf("%-24s %10s %10s %10s\n", "FISH", "CAUGHT", "REALIZED", "EXPECTED")
+
+	ids := make([]string, 0, len(weights))
+	for id := range weights {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return weights[ids[i]] > weights[ids[j]] })
+
+	for _, id := range ids {
+		name := id
+		if fish := findFish(set, id); fish != nil {
+			name = fish.Name
+		}
+		realized := float64(counts[id]) / float64(*casts) * 100
+		expected := weights[id] / totalWeight * 100
+		fmt.Printf("%-24s %10d %9.2f%% %9.2f%%\n", name, counts[id], realized, expected)
+	}
+	return 0
+}
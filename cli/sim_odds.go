@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// sim:odds evaluates the catch formula for one fish/biome/lure/pole
+// combination and prints every step of the probability, for designer
+// tuning questions and community "what are the odds" threads.
+
+func runSimOdds(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("sim:odds", flag.ContinueOnError)
+	fishID := flags.String("fish", "", "fish id (see content/fish.json)")
+	biomeID := flags.String("biome", "", "biome id (see content/biomes.json)")
+	lureID := flags.String("lure", "", "optional lure id")
+	poleTier := flags.Int("pole-tier", 1, "pole tier (1-4)")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	set, err := loadContent(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	fish := findFish(set, *fishID)
+	if fish == nil {
+		fmt.Fprintf(os.Stderr, "unknown fish %q\n", *fishID)
+		return 1
+	}
+	biome := findBiome(set, *biomeID)
+	if biome == nil {
+		fmt.Fprintf(os.Stderr, "unknown biome %q (available: %v)\n", *biomeID, biomeIDs(set))
+		return 1
+	}
+	var lure *LureDef
+	if *lureID != "" {
+		if lure = findLure(set, *lureID); lure == nil {
+			fmt.Fprintf(os.Stderr, "unknown lure %q\n", *lureID)
+			return 1
+		}
+	}
+
+	inTable := false
+	var baseWeight float64
+	for _, entry := range biome.SpawnTable {
+		if entry.FishID == fish.ID {
+			inTable = true
+			baseWeight = entry.Weight
+		}
+	}
+	if !inTable {
+		fmt.Printf("%s does not spawn in %s — probability is 0\n", fish.Name, biome.Name)
+		return 0
+	}
+
+	weights := effectiveWeights(set, biome, lure)
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	pSpawn := weights[fish.ID] / total
+	pLand := landRate(fish.Tier, *poleTier)
+	pCatch := pSpawn * pLand
+
+	fmt.Printf("Catch odds: %s in %s (pole tier %d", fish.Name, biome.Name, *poleTier)
+	if lure != nil {
+		fmt.Printf(", %s", lure.Name)
+	}
+	fmt.Println(")")
+	fmt.Println()
+	fmt.Printf("  base spawn weight          %8.1f\n", baseWeight)
+	if lure != nil {
+		lureMultiplier := weights[fish.ID] / baseWeight
+		fmt.Printf("  lure tier multiplier       %8.2fx (%s on tier %d)\n", lureMultiplier, lure.Name, fish.Tier)
+	}
+	fmt.Printf("  effective weight           %8.1f of %.1f total\n", weights[fish.ID], total)
+	fmt.Printf("  spawn probability          %8.2f%%\n", pSpawn*100)
+	fmt.Printf("  landing rate               %8.2f%% (tier %d fish, tier %d pole)\n", pLand*100, fish.Tier, *poleTier)
+	fmt.Printf("  catch probability per cast %8.2f%%\n", pCatch*100)
+	fmt.Println()
+	expectedCasts := 1 / pCatch
+	fmt.Printf("  expected casts to catch    %8.1f (~%s)\n", expectedCasts, formatDuration(expectedCasts*castCycleSeconds))
+	if lure != nil && lure.BiteSpeedMultiplier != 1.0 {
+		fmt.Printf("  bite speed                 %8.2fx (affects wait time, not odds)\n", lure.BiteSpeedMultiplier)
+	}
+	return 0
+}
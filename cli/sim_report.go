@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Rough wall-clock seconds for one full cast cycle (charge, cast, bite wait,
+// minigame, reel). Derived from the timing constants in
+// apps/lurelands/lib/utils/constants.dart; used for time-to-first-catch
+// estimates only, not gameplay.
+const castCycleSeconds = 22.0
+
+// rarityReport renders drop-rate bars and expected time-to-first-catch for a
+// biome, optionally as Markdown.
+func rarityReport(set *ContentSet, biome *BiomeDef, markdown bool) string {
+	weights := effectiveWeights(set, biome, nil)
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+
+	ids := sortedByWeight(weights)
+
+	var b strings.Builder
+	if markdown {
+		fmt.Fprintf(&b, "## %s\n\n", biome.Name)
+		b.WriteString("| Fish | Tier | Drop rate | Expected casts | Time to first catch |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+	} else {
+		fmt.Fprintf(&b, "%s\n\n", biome.Name)
+	}
+
+	for _, id := range ids {
+		fish := findFish(set, id)
+		name, tier := id, 0
+		if fish != nil {
+			name, tier = fish.Name, fish.Tier
+		}
+		p := weights[id] / total
+		expectedCasts := 1.0 / p
+		timeToCatch := formatDuration(expectedCasts * castCycleSeconds)
+
+		if markdown {
+			fmt.Fprintf(&b, "| %s | %d | %.2f%% | %.0f | %s |\n", name, tier, p*100, expectedCasts, timeToCatch)
+		} else {
+			bar := strings.Repeat("█", int(p*50+0.5))
+			fmt.Fprintf(&b, "  %-24s %6.2f%% %-25s ~%s to first catch\n", name, p*100, bar, timeToCatch)
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// compareReport shows per-fish drop-rate deltas between the working content
+// and another content checkout (e.g. a worktree of the deployed version).
+func compareReport(current, other *ContentSet, markdown bool) string {
+	var b strings.Builder
+	if markdown {
+		b.WriteString("## Comparison\n\n| Biome | Fish | Before | After | Delta |\n| --- | --- | --- | --- | --- |\n")
+	} else {
+		b.WriteString("Comparison vs other content version\n\n")
+	}
+
+	for i := range current.Biomes {
+		biome := &current.Biomes[i]
+		otherBiome := findBiome(other, biome.ID)
+		if otherBiome == nil {
+			fmt.Fprintf(&b, "  %s: new biome\n", biome.ID)
+			continue
+		}
+		cur := normalizedRates(effectiveWeights(current, biome, nil))
+		old := normalizedRates(effectiveWeights(other, otherBiome, nil))
+
+		for _, id := range sortedByWeight(cur) {
+			delta := (cur[id] - old[id]) * 100
+			if delta == 0 {
+				continue
+			}
+			name := id
+			if fish := findFish(current, id); fish != nil {
+				name = fish.Name
+			}
+			if markdown {
+				fmt.Fprintf(&b, "| %s | %s | %.2f%% | %.2f%% | %+.2f%% |\n", biome.Name, name, old[id]*100, cur[id]*100, delta)
+			} else {
+				fmt.Fprintf(&b, "  %-20s %-24s %6.2f%% -> %6.2f%% (%+.2f%%)\n", biome.Name, name, old[id]*100, cur[id]*100, delta)
+			}
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func normalizedRates(weights map[string]float64) map[string]float64 {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	rates := map[string]float64{}
+	if total == 0 {
+		return rates
+	}
+	for id, w := range weights {
+		rates[id] = w / total
+	}
+	return rates
+}
+
+func sortedByWeight(weights map[string]float64) []string {
+	ids := make([]string, 0, len(weights))
+	for id := range weights {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if weights[ids[i]] != weights[ids[j]] {
+			return weights[ids[i]] > weights[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+func formatDuration(seconds float64) string {
+	switch {
+	case seconds < 90:
+		return fmt.Sprintf("%.0fs", seconds)
+	case seconds < 5400:
+		return fmt.Sprintf("%.0fm", seconds/60)
+	default:
+		return fmt.Sprintf("%.1fh", seconds/3600)
+	}
+}
+
+func runSimReport(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("sim:report", flag.ContinueOnError)
+	biomeID := flags.String("biome", "", "limit the report to one biome")
+	compareDir := flags.String("compare", "", "repo root of another content version to diff against")
+	format := flags.String("format", "text", "output format: text or md")
+	out := flags.String("out", "", "write the report to a file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	markdown := *format == "md"
+
+	set, err := loadContent(rootDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	var b strings.Builder
+	if markdown {
+		b.WriteString("# Rarity & Loot Distribution Report\n\n")
+	} else {
+		b.WriteString("Rarity & loot distribution report\n")
+		b.WriteString(strings.Repeat("=", 40) + "\n\n")
+	}
+
+	for i := range set.Biomes {
+		biome := &set.Biomes[i]
+		if *biomeID != "" && biome.ID != *biomeID {
+			continue
+		}
+		b.WriteString(rarityReport(set, biome, markdown))
+	}
+
+	if *compareDir != "" {
+		other, err := loadContent(*compareDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error loading comparison content:", err)
+			return 1
+		}
+		b.WriteString(compareReport(set, other, markdown))
+	}
+
+	if *out != "" {
+		if err := os.WriteFile(*out, []byte(b.String()), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Println("report written to", *out)
+		return 0
+	}
+	fmt.Print(b.String())
+	return 0
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// sql opens an interactive console against the module: a textinput
+// prompt over `spacetime sql --json`, with per-workspace query history
+// (↑/↓ recalls), paged results, and ctrl+e / ctrl+j to export the last
+// result set as CSV or JSON. Rows come back the same shape as
+// analytics queries, so the rendering reuses that machinery.
+
+const sqlHistoryLimit = 100
+const sqlPageSize = 18
+
+func sqlHistoryPath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "sql_history.json")
+}
+
+func loadSQLHistory(rootDir string) []string {
+	var history []string
+	data, err := os.ReadFile(sqlHistoryPath(rootDir))
+	if err == nil {
+		json.Unmarshal(data, &history)
+	}
+	return history
+}
+
+func saveSQLHistory(rootDir string, history []string) {
+	if len(history) > sqlHistoryLimit {
+		history = history[len(history)-sqlHistoryLimit:]
+	}
+	os.MkdirAll(filepath.Dir(sqlHistoryPath(rootDir)), 0o755)
+	data, _ := json.MarshalIndent(history, "", "  ")
+	os.WriteFile(sqlHistoryPath(rootDir), append(data, '\n'), 0o644)
+}
+
+// renderSQLTable is printAnalyticsTable rendered to a string, one page
+// at a time.
+func renderSQLTable(columns []string, rows []map[string]any, page int) string {
+	start := page * sqlPageSize
+	end := start + sqlPageSize
+	if end > len(rows) {
+		end = len(rows)
+	}
+	if start >= len(rows) {
+		return ""
+	}
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = len(column)
+		for _, row := range rows[start:end] {
+			if n := len(formatCell(row[column])); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	var b strings.Builder
+	for i, column := range columns {
+		fmt.Fprintf(&b, "%-*s  ", widths[i], column)
+	}
+	b.WriteByte('\n')
+	for i := range columns {
+		b.WriteString(strings.Repeat("-", widths[i]) + "  ")
+	}
+	b.WriteByte('\n')
+	for _, row := range rows[start:end] {
+		for i, column := range columns {
+			fmt.Fprintf(&b, "%-*s  ", widths[i], formatCell(row[column]))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+type sqlResultMsg struct {
+	query   string
+	columns []string
+	rows    []map[string]any
+	took    time.Duration
+	err     error
+}
+
+type sqlModel struct {
+	rootDir string
+	env     environment
+	input   textinput.Model
+	history []string
+	histPos int // index into history while cycling; len(history) = live input
+
+	columns []string
+	rows    []map[string]any
+	page    int
+	took    time.Duration
+	status  string
+	running bool
+}
+
+func newSQLModel(rootDir string, env environment) sqlModel {
+	input := textinput.New()
+	input.Prompt = "sql> "
+	input.Placeholder = "SELECT * FROM player LIMIT 10"
+	input.Focus()
+	history := loadSQLHistory(rootDir)
+	return sqlModel{rootDir: rootDir, env: env, input: input, history: history, histPos: len(history)}
+}
+
+func (m sqlModel) Init() tea.Cmd { return textinput.Blink }
+
+func (m sqlModel) runQuery(query string) tea.Cmd {
+	env := m.env
+	return func() tea.Msg {
+		start := time.Now()
+		columns, rows, err := fetchAnalyticsRows(env, query)
+		return sqlResultMsg{query: query, columns: columns, rows: rows, took: time.Since(start), err: err}
+	}
+}
+
+func (m sqlModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case sqlResultMsg:
+		m.running = false
+		if msg.err != nil {
+			m.status = "error: " + msg.err.Error()
+			return m, nil
+		}
+		m.columns, m.rows, m.page, m.took = msg.columns, msg.rows, 0, msg.took
+		m.status = fmt.Sprintf("%d row(s) in %s", len(msg.rows), msg.took.Round(time.Millisecond))
+		m.history = append(m.history, msg.query)
+		m.histPos = len(m.history)
+		saveSQLHistory(m.rootDir, m.history)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		case "enter":
+			query := strings.TrimSpace(m.input.Value())
+			if query == "" || m.running {
+				return m, nil
+			}
+			m.running = true
+			m.status = "running…"
+			m.input.SetValue("")
+			return m, m.runQuery(query)
+		case "up":
+			if m.histPos > 0 {
+				m.histPos--
+				m.input.SetValue(m.history[m.histPos])
+				m.input.CursorEnd()
+			}
+			return m, nil
+		case "down":
+			if m.histPos < len(m.history) {
+				m.histPos++
+				if m.histPos == len(m.history) {
+					m.input.SetValue("")
+				} else {
+					m.input.SetValue(m.history[m.histPos])
+					m.input.CursorEnd()
+				}
+			}
+			return m, nil
+		case "pgdown":
+			if (m.page+1)*sqlPageSize < len(m.rows) {
+				m.page++
+			}
+			return m, nil
+		case "pgup":
+			if m.page > 0 {
+				m.page--
+			}
+			return m, nil
+		case "ctrl+e":
+			m.status = m.export("csv")
+			return m, nil
+		case "ctrl+j":
+			m.status = m.export("json")
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// export writes the last result set next to the other workspace
+// artifacts and reports where it went.
+func (m sqlModel) export(format string) string {
+	if len(m.rows) == 0 {
+		return "nothing to export yet"
+	}
+	dir := filepath.Join(m.rootDir, ".lurelands", "exports")
+	os.MkdirAll(dir, 0o755)
+	path := filepath.Join(dir, "sql-"+time.Now().Format("20060102-150405")+"."+format)
+	f, err := os.Create(path)
+	if err != nil {
+		return "export failed: " + err.Error()
+	}
+	defer f.Close()
+	switch format {
+	case "csv":
+		w := csv.NewWriter(f)
+		w.Write(m.columns)
+		for _, row := range m.rows {
+			record := make([]string, len(m.columns))
+			for i, column := range m.columns {
+				record[i] = formatCell(row[column])
+			}
+			w.Write(record)
+		}
+		w.Flush()
+	case "json":
+		data, _ := json.MarshalIndent(m.rows, "", "  ")
+		f.Write(append(data, '\n'))
+	}
+	return "exported " + path
+}
+
+func (m sqlModel) View() string {
+	s := liveTitleStyle.Render(fmt.Sprintf("sql console · %s (%s)", m.env.Module, m.env.Name)) + "\n\n"
+	s += m.input.View() + "\n\n"
+	if len(m.rows) > 0 {
+		s += renderSQLTable(m.columns, m.rows, m.page)
+		pages := (len(m.rows) + sqlPageSize - 1) / sqlPageSize
+		if pages > 1 {
+			s += liveDimStyle.Render(fmt.Sprintf("page %d/%d (pgup/pgdn)", m.page+1, pages)) + "\n"
+		}
+	}
+	if m.status != "" {
+		s += "\n" + tui.Accent.Render(m.status) + "\n"
+	}
+	return s + "\n" + liveDimStyle.Render("enter run · ↑/↓ history · ctrl+e csv · ctrl+j json · esc quit")
+}
+
+func runSQL(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("sql", flag.ContinueOnError)
+	envName := flags.String("env", "", "target environment")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	if _, ok := lookupTool(rootDir, "spacetime"); !ok {
+		return reportError(errToolNotFound("spacetime", "querying the module"))
+	}
+	if code := requireInteractive("sql", "use `spacetime sql` directly"); code >= 0 {
+		return code
+	}
+	if _, err := tea.NewProgram(newSQLModel(rootDir, env), tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Per-workspace user state, under .lurelands/state.json. This is the
+// CLI's memory between invocations: the last command run (for
+// `lurelands again`), the last environment targeted (pre-selected by
+// the shell), and the last device used (reserved for the device picker).
+// Losing the file loses nothing but convenience.
+
+// Registered in init: runAgain re-dispatches against commandTree,
+// which would be an initialization cycle as a literal tree entry.
+func init() {
+	commandTree = append(commandTree, &command{
+		name: "again", summary: "re-run the last command", run: runAgain,
+	})
+}
+
+type userState struct {
+	LastCommand []string `json:"last_command,omitempty"`
+	LastEnv     string   `json:"last_env,omitempty"`
+	LastDevice  string   `json:"last_device,omitempty"`
+	LastRunMode string   `json:"last_run_mode,omitempty"`
+	CurrentEnv  string   `json:"current_env,omitempty"`
+
+	// Sizes from the last release build, keyed by artifact name, so the
+	// next build can show a diff (see artifact_sizes.go).
+	ArtifactSizes map[string]int64 `json:"artifact_sizes,omitempty"`
+}
+
+func userStatePath(rootDir string) string {
+	return filepath.Join(rootDir, ".lurelands", "state.json")
+}
+
+func loadUserState(rootDir string) *userState {
+	state := &userState{}
+	data, err := os.ReadFile(userStatePath(rootDir))
+	if err != nil {
+		return state
+	}
+	json.Unmarshal(data, state)
+	return state
+}
+
+func saveUserState(rootDir string, state *userState) {
+	path := userStatePath(rootDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	data, _ := json.MarshalIndent(state, "", "  ")
+	os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// recordCommand notes a just-dispatched command line in the state
+// store. Meta commands that re-run or wrap other commands are skipped
+// so `again` never loops on itself.
+func recordCommand(rootDir string, args []string) {
+	if rootDir == "" || len(args) == 0 {
+		return
+	}
+	switch args[0] {
+	case "again", "shell", "workspace", "api":
+		return
+	}
+	state := loadUserState(rootDir)
+	state.LastCommand = append([]string(nil), args...)
+	for i, arg := range args {
+		if arg == "--env" && i+1 < len(args) {
+			state.LastEnv = args[i+1]
+		} else if strings.HasPrefix(arg, "--env=") {
+			state.LastEnv = strings.TrimPrefix(arg, "--env=")
+		}
+	}
+	saveUserState(rootDir, state)
+}
+
+func runAgain(ctx context.Context, rootDir string, args []string) int {
+	state := loadUserState(rootDir)
+	if len(state.LastCommand) == 0 {
+		fmt.Fprintln(os.Stderr, "nothing to re-run yet")
+		return 1
+	}
+	fmt.Printf("re-running: lurelands %s\n", strings.Join(state.LastCommand, " "))
+	return dispatch(ctx, commandTree, rootDir, state.LastCommand)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// stats is the playtest companion to `live`: the same polled metrics,
+// but every series keeps its history so each one gets a sparkline, plus
+// gauges scaled to the session peak. `live` answers "is prod healthy
+// right now"; stats answers "what did the last twenty minutes of this
+// playtest look like", so it defaults to local.
+
+const statsRefreshInterval = 5 * time.Second
+
+// statsHistoryLen is sized so a full screen covers ~5 minutes of samples.
+const statsHistoryLen = 60
+
+type statsTickMsg time.Time
+
+type statsModel struct {
+	env     environment
+	sample  liveSample
+	history []liveMetrics
+	started time.Time
+}
+
+func (m statsModel) Init() tea.Cmd {
+	return tea.Batch(m.poll(), statsTick())
+}
+
+func (m statsModel) poll() tea.Cmd {
+	env := m.env
+	return func() tea.Msg { return fetchLiveMetrics(env) }
+}
+
+func statsTick() tea.Cmd {
+	return tea.Tick(statsRefreshInterval, func(t time.Time) tea.Msg { return statsTickMsg(t) })
+}
+
+func (m statsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case tuiKeys.Quit, "ctrl+c", "esc":
+			return m, tea.Quit
+		case tuiKeys.Refresh:
+			return m, m.poll()
+		}
+	case statsTickMsg:
+		return m, tea.Batch(m.poll(), statsTick())
+	case liveSample:
+		m.sample = msg
+		if msg.err == nil {
+			m.history = append(m.history, msg.metrics)
+			if len(m.history) > statsHistoryLen {
+				m.history = m.history[1:]
+			}
+		}
+	}
+	return m, nil
+}
+
+// statsSeries extracts one metric's history for its sparkline and gauge.
+func statsSeries(history []liveMetrics, pick func(liveMetrics) float64) []float64 {
+	values := make([]float64, len(history))
+	for i, m := range history {
+		values[i] = pick(m)
+	}
+	return values
+}
+
+func seriesPeak(values []float64) float64 {
+	peak := 0.0
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+	return peak
+}
+
+// statsRow renders one metric line: current value, session-peak gauge,
+// and the sparkline of recent samples.
+func statsRow(label, current string, values []float64, warn bool) string {
+	stat := liveStatStyle.Render(current)
+	if warn {
+		stat = liveWarnStyle.Render(current + " ⚠")
+	}
+	gauge := tui.Gauge(values[len(values)-1], seriesPeak(values), 10)
+	return fmt.Sprintf("  %-26s%-12s%s  %s\n", label, stat, gauge, tui.Sparkline(values))
+}
+
+func (m statsModel) View() string {
+	s := liveTitleStyle.Render(fmt.Sprintf("lurelands stats — %s (%s/%s)", m.env.Name, m.env.Server, m.env.Module)) + "\n\n"
+
+	if m.sample.err != nil {
+		s += liveWarnStyle.Render("error: "+m.sample.err.Error()) + "\n\n"
+		s += liveDimStyle.Render("retrying every " + statsRefreshInterval.String())
+		return s + "\n\n" + liveDimStyle.Render("q quit · r refresh")
+	}
+	if len(m.history) == 0 {
+		return s + "connecting…\n"
+	}
+
+	metrics := m.sample.metrics
+	s += statsRow("players online", fmt.Sprintf("%d", metrics.PlayersOnline),
+		statsSeries(m.history, func(m liveMetrics) float64 { return float64(m.PlayersOnline) }), false)
+	s += statsRow("active fishing sessions", fmt.Sprintf("%d", metrics.ActiveSessions),
+		statsSeries(m.history, func(m liveMetrics) float64 { return float64(m.ActiveSessions) }), false)
+	s += statsRow("catches / minute", fmt.Sprintf("%.1f", metrics.CatchesPerMin),
+		statsSeries(m.history, func(m liveMetrics) float64 { return m.CatchesPerMin }), false)
+	s += statsRow("errors / minute", fmt.Sprintf("%.2f", metrics.ErrorsPerMin),
+		statsSeries(m.history, func(m liveMetrics) float64 { return m.ErrorsPerMin }), metrics.ErrorsPerMin > 1.0)
+	s += statsRow("reducer p95 (ms)", fmt.Sprintf("%.1f", metrics.ReducerP95ms),
+		statsSeries(m.history, func(m liveMetrics) float64 { return m.ReducerP95ms }), false)
+
+	s += "\n" + liveDimStyle.Render(fmt.Sprintf("gauges scaled to session peak · %d samples · watching for %s",
+		len(m.history), time.Since(m.started).Round(time.Second)))
+	return s + "\n" + liveDimStyle.Render("q quit · r refresh")
+}
+
+func runStats(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	envName := flags.String("env", "", "environment to watch")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	env, err := resolveEnvironment(envOrCurrent(*envName, "local"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	if code := requireInteractive("stats", "use `lurelands analytics` for point-in-time numbers"); code >= 0 {
+		return code
+	}
+	model := statsModel{env: env, started: time.Now()}
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// stats:tasks aggregates command history into a slow-step report:
+// average/min/max duration and a recent trend per command, so a bridge
+// build that quietly regressed from 8s to 45s shows up as a red ▲
+// instead of a vague feeling that deploys got slower.
+
+type taskStats struct {
+	key       string
+	durations []time.Duration // in run order, oldest first
+	failures  int
+}
+
+// taskKey groups history entries by command, ignoring flags: `deploy
+// --env staging` and `deploy --env prod` are one task.
+func taskKey(args []string) string {
+	var parts []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		// History stores args as typed; fold db:seed into db seed.
+		parts = append(parts, strings.Split(arg, ":")...)
+		if len(parts) >= 2 {
+			parts = parts[:2]
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func aggregateTaskStats(history []historyEntry) []taskStats {
+	byKey := map[string]*taskStats{}
+	var order []string
+	for _, entry := range history {
+		key := taskKey(entry.Args)
+		if key == "" {
+			continue
+		}
+		stats, ok := byKey[key]
+		if !ok {
+			stats = &taskStats{key: key}
+			byKey[key] = stats
+			order = append(order, key)
+		}
+		stats.durations = append(stats.durations, entry.Duration)
+		if entry.ExitCode != 0 {
+			stats.failures++
+		}
+	}
+	aggregated := make([]taskStats, 0, len(order))
+	for _, key := range order {
+		aggregated = append(aggregated, *byKey[key])
+	}
+	// Slowest on top — that's what the report is for.
+	sort.SliceStable(aggregated, func(i, j int) bool {
+		return taskAvg(aggregated[i].durations) > taskAvg(aggregated[j].durations)
+	})
+	return aggregated
+}
+
+func taskAvg(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
+
+// taskTrend compares the most recent runs against the older ones and
+// reports the relative change; below ±15% reads as steady.
+func taskTrend(durations []time.Duration) string {
+	if len(durations) < 4 {
+		return ""
+	}
+	split := len(durations) - len(durations)/3
+	older, recent := taskAvg(durations[:split]), taskAvg(durations[split:])
+	if older == 0 {
+		return ""
+	}
+	change := float64(recent-older) / float64(older)
+	switch {
+	case change > 0.15:
+		return tui.Warn.Render(fmt.Sprintf("▲ %+.0f%%", change*100))
+	case change < -0.15:
+		return tui.OK.Render(fmt.Sprintf("▼ %.0f%%", change*100))
+	default:
+		return tui.Dim.Render("steady")
+	}
+}
+
+func runStatsTasks(ctx context.Context, rootDir string, args []string) int {
+	aggregated := aggregateTaskStats(loadHistory(rootDir))
+	if len(aggregated) == 0 {
+		fmt.Println("no history yet — run a few commands first")
+		return 0
+	}
+
+	fmt.Printf("  %-28s %5s %5s %9s %9s %9s  %s\n", "command", "runs", "fail", "avg", "min", "max", "trend")
+	for _, stats := range aggregated {
+		min, max := stats.durations[0], stats.durations[0]
+		values := make([]float64, len(stats.durations))
+		for i, d := range stats.durations {
+			if d < min {
+				min = d
+			}
+			if d > max {
+				max = d
+			}
+			values[i] = float64(d)
+		}
+		trend := taskTrend(stats.durations)
+		if spark := tui.Sparkline(values); spark != "" {
+			trend = spark + "  " + trend
+		}
+		fmt.Printf("  %-28s %5d %5d %9s %9s %9s  %s\n",
+			stats.key, len(stats.durations), stats.failures,
+			taskAvg(stats.durations).Round(time.Millisecond), min.Round(time.Millisecond),
+			max.Round(time.Millisecond), trend)
+	}
+	fmt.Println(tui.Dim.Render(fmt.Sprintf("\nfrom the last %d recorded runs (.lurelands/history.json)", historyLimit)))
+	return 0
+}
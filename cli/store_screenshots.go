@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// store:screenshots captures the App Store / Play Store screenshot set:
+// boot a simulator per required resolution, run the scripted screenshot
+// driver against it, and drop the results where fastlane expects them
+// (fastlane/screenshots/<locale>/). Requires the iOS simulator tooling
+// (xcrun) for the Apple devices; Android devices use a running emulator.
+
+type storeDevice struct {
+	Name     string // simulator / emulator name
+	Platform string // "ios" or "android"
+	Label    string // store listing slot, used in file names
+}
+
+// The device matrix mirrors the store listing requirements: 6.7" and 5.5"
+// iPhones, 12.9" iPad, plus one Android phone and tablet.
+var storeDevices = []storeDevice{
+	{Name: "iPhone 15 Pro Max", Platform: "ios", Label: "iphone-6.7"},
+	{Name: "iPhone 8 Plus", Platform: "ios", Label: "iphone-5.5"},
+	{Name: "iPad Pro (12.9-inch) (6th generation)", Platform: "ios", Label: "ipad-12.9"},
+	{Name: "Pixel_7", Platform: "android", Label: "android-phone"},
+	{Name: "Pixel_Tablet", Platform: "android", Label: "android-tablet"},
+}
+
+// Scripted screens: route deep-links the driver walks through, in listing
+// order. The integration test driver reads LURELANDS_SCREENSHOT_ROUTES.
+var screenshotRoutes = []string{
+	"main_menu",
+	"game",
+	"fishing_minigame",
+	"inventory",
+	"shop",
+}
+
+func screenshotsOutDir(rootDir, locale string) string {
+	return filepath.Join(appDir(rootDir), "fastlane", "screenshots", locale)
+}
+
+func captureDevice(rootDir string, device storeDevice, locale string) error {
+	outDir := screenshotsOutDir(rootDir, locale)
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+
+	if device.Platform == "ios" {
+		if out, err := exec.Command("xcrun", "simctl", "boot", device.Name).CombinedOutput(); err != nil {
+			// "Unable to boot device in current state: Booted" is fine.
+			if !strings.Contains(string(out), "Booted") {
+				return fmt.Errorf("booting %s: %v\n%s", device.Name, err, out)
+			}
+		}
+	}
+
+	driver := exec.Command("flutter", "drive",
+		"--driver=test_driver/screenshots_test.dart",
+		"--target=integration_test/screenshots.dart",
+		"-d", device.Name,
+	)
+	driver.Dir = appDir(rootDir)
+	driver.Env = append(os.Environ(),
+		"LURELANDS_SCREENSHOT_ROUTES="+strings.Join(screenshotRoutes, ","),
+		"LURELANDS_SCREENSHOT_DIR="+outDir,
+		"LURELANDS_SCREENSHOT_LABEL="+device.Label,
+	)
+	driver.Stdout = os.Stdout
+	driver.Stderr = os.Stderr
+	return driver.Run()
+}
+
+func runStoreScreenshots(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("store:screenshots", flag.ContinueOnError)
+	locale := flags.String("locale", "en-US", "store locale directory to write into")
+	only := flags.String("device", "", "capture a single device label (e.g. iphone-6.7)")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if _, err := exec.LookPath("flutter"); err != nil {
+		fmt.Fprintln(os.Stderr, "error: flutter is required to capture screenshots")
+		return 1
+	}
+
+	captured := 0
+	for _, device := range storeDevices {
+		if *only != "" && device.Label != *only {
+			continue
+		}
+		if device.Platform == "ios" {
+			if _, err := exec.LookPath("xcrun"); err != nil {
+				fmt.Printf("skipping %s: iOS simulator tooling not available\n", device.Label)
+				continue
+			}
+		}
+		fmt.Printf("capturing %s (%s)…\n", device.Label, device.Name)
+		if err := captureDevice(rootDir, device, *locale); err != nil {
+			fmt.Fprintf(os.Stderr, "error capturing %s: %v\n", device.Label, err)
+			return 1
+		}
+		captured++
+	}
+
+	if captured == 0 {
+		fmt.Fprintln(os.Stderr, "error: no devices captured")
+		return 1
+	}
+	fmt.Printf("screenshots: %d device(s) captured -> %s\n", captured, screenshotsOutDir(rootDir, *locale))
+	return 0
+}
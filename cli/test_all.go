@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// The default `test` path runs every workspace's suite at once —
+// flutter, bun, and cargo tests don't contend for anything — with
+// prefixed multiplexed output (same scheme as dev) and a per-workspace
+// summary at the end. Pass/fail counts are scraped from each tool's
+// own summary line, best effort: a suite whose counts we can't parse
+// still reports its exit status.
+
+// suiteCountPatterns match the summary lines of each test tool:
+// flutter "+12 -1: Some tests failed.", bun "12 pass / 1 fail",
+// cargo "test result: ok. 12 passed; 1 failed".
+var suiteCountPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\+(\d+)(?: ~\d+)?(?: -(\d+))?:`),
+	regexp.MustCompile(`(\d+) pass(?:ed)?[^\d]+(\d+) fail`),
+	regexp.MustCompile(`(\d+) passed; (\d+) failed`),
+}
+
+type suiteResult struct {
+	name     string
+	exitCode int
+	passed   int
+	failed   int
+	counted  bool
+	took     time.Duration
+}
+
+func scrapeSuiteCounts(result *suiteResult, line string) {
+	for _, pattern := range suiteCountPatterns {
+		match := pattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		passed, _ := strconv.Atoi(match[1])
+		failed := 0
+		if len(match) > 2 && match[2] != "" {
+			failed, _ = strconv.Atoi(match[2])
+		}
+		result.passed, result.failed, result.counted = passed, failed, true
+		return
+	}
+}
+
+func runSuitesParallel(ctx context.Context, rootDir string, services []watchedService) int {
+	width := 0
+	for _, service := range services {
+		if len(service.name) > width {
+			width = len(service.name)
+		}
+	}
+
+	results := make([]suiteResult, len(services))
+	var outMu sync.Mutex
+	var wg sync.WaitGroup
+	for i, service := range services {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(devPrefixColors[i%len(devPrefixColors)]))
+		prefix := style.Render(fmt.Sprintf("%-*s |", width, service.name))
+		wg.Add(1)
+		go func(i int, service watchedService) {
+			defer wg.Done()
+			start := time.Now()
+			results[i].name = service.name
+			result, _ := runner.RunStreaming(ctx, pinnedSpec(rootDir, runner.Spec{
+				Name: service.test[0],
+				Args: service.test[1:],
+				Dir:  service.dir(rootDir),
+			}), func(line string, _ bool) {
+				scrapeSuiteCounts(&results[i], line)
+				outMu.Lock()
+				fmt.Println(prefix, line)
+				outMu.Unlock()
+			})
+			results[i].exitCode = result.ExitCode
+			results[i].took = time.Since(start)
+		}(i, service)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return exitCancelled
+	}
+
+	fmt.Println()
+	exit := 0
+	for _, result := range results {
+		mark := "ok"
+		if result.exitCode != 0 {
+			mark = " !"
+			exit = 1
+		}
+		counts := fmt.Sprintf("exit %d", result.exitCode)
+		if result.counted {
+			counts = fmt.Sprintf("%d passed, %d failed", result.passed, result.failed)
+		}
+		fmt.Printf("  %s  %-*s %-24s %s\n", mark, width, result.name, counts, result.took.Round(time.Millisecond))
+	}
+	return exit
+}
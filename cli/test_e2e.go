@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/workspace"
+)
+
+// test:e2e runs the whole stack end to end: clean local SpacetimeDB,
+// freshly published module, seeded content, bridge, then the Flutter
+// integration suite against all of it. Everything is torn down afterwards
+// no matter how the suite exits, and on failure every service log is
+// folded into the report directory so CI failures are debuggable without
+// a rerun.
+
+func bridgeDir(rootDir string) string {
+	return (&workspace.Workspace{Root: rootDir}).BridgeDir()
+}
+
+func moduleDir(rootDir string) string {
+	return (&workspace.Workspace{Root: rootDir}).ModuleDir()
+}
+
+func e2eReportDir(rootDir string) string {
+	return filepath.Join(rootDir, "build", "e2e", time.Now().Format("20060102-150405"))
+}
+
+// startLogged starts a long-running service with stdout/stderr captured to
+// a log file in the report dir, returning the process and a closer.
+func startLogged(reportDir, name string, cmd *exec.Cmd) (*exec.Cmd, func(), error) {
+	logFile, err := os.Create(filepath.Join(reportDir, name+".log"))
+	if err != nil {
+		return nil, nil, err
+	}
+	cmd.Stdout, cmd.Stderr = logFile, logFile
+	if err := cmd.Start(); err != nil {
+		logFile.Close()
+		return nil, nil, fmt.Errorf("starting %s: %w", name, err)
+	}
+	stop := func() {
+		if cmd.Process != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+		}
+		logFile.Close()
+	}
+	return cmd, stop, nil
+}
+
+func runStep(reportDir, name string, cmd *exec.Cmd) error {
+	logFile, err := os.Create(filepath.Join(reportDir, name+".log"))
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+	cmd.Stdout, cmd.Stderr = logFile, logFile
+	fmt.Printf("  %s…\n", name)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed (see %s.log): %w", name, name, err)
+	}
+	return nil
+}
+
+func runTestE2E(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("test:e2e", flag.ContinueOnError)
+	keep := flags.Bool("keep", false, "leave the stack running after the suite")
+	target := flags.String("target", "integration_test", "Flutter test target to run")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	for _, tool := range []string{"spacetime", "flutter"} {
+		if _, ok := lookupTool(rootDir, tool); !ok {
+			fmt.Fprintf(os.Stderr, "error: %s is required for test:e2e\n", tool)
+			return 1
+		}
+	}
+
+	reportDir := e2eReportDir(rootDir)
+	if err := os.MkdirAll(reportDir, 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	fmt.Println("e2e report:", reportDir)
+
+	env := environments["local"]
+	failed := false
+	fail := func(err error) int {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		fmt.Fprintln(os.Stderr, "service logs captured in", reportDir)
+		return 1
+	}
+
+	// 1. Clean local instance. `spacetime start` owns its data dir; delete
+	// the module first so we never test against stale state.
+	exec.Command("spacetime", "delete", "--server", env.Server, env.Module).Run()
+	_, stopDB, err := startLogged(reportDir, "spacetimedb", exec.Command("spacetime", "start"))
+	if err != nil {
+		return fail(err)
+	}
+	defer func() {
+		if !*keep || failed {
+			stopDB()
+		}
+	}()
+	time.Sleep(2 * time.Second) // give the instance a moment to listen
+
+	// 2. Publish the module.
+	publish := exec.Command("spacetime", "publish", "--server", env.Server, env.Module)
+	publish.Dir = moduleDir(rootDir)
+	if err := runStep(reportDir, "publish", publish); err != nil {
+		failed = true
+		return fail(err)
+	}
+
+	// 3. Seed content.
+	if code := runContentSeedDev(ctx, rootDir, nil); code != 0 {
+		failed = true
+		return fail(fmt.Errorf("content seeding failed"))
+	}
+
+	// 4. Bridge.
+	bridge := exec.Command("bun", "run", "start")
+	bridge.Dir = bridgeDir(rootDir)
+	_, stopBridge, err := startLogged(reportDir, "bridge", bridge)
+	if err != nil {
+		failed = true
+		return fail(err)
+	}
+	defer func() {
+		if !*keep || failed {
+			stopBridge()
+		}
+	}()
+	time.Sleep(2 * time.Second)
+
+	// 5. The suite itself.
+	suite := exec.Command("flutter", "test", *target)
+	suite.Dir = appDir(rootDir)
+	if err := runStep(reportDir, "flutter-tests", suite); err != nil {
+		failed = true
+		return fail(err)
+	}
+
+	fmt.Println("e2e suite passed")
+	if *keep {
+		fmt.Println("stack left running (--keep)")
+	}
+	return 0
+}
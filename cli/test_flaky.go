@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// test:flaky hunts intermittent failures. Every invocation re-runs the
+// selected suites N times, records per-test outcomes into local state
+// (.lurelands/cache/test_history.json — CI jobs can stash it as an
+// artifact to accumulate across builds), and reports flake rates: tests
+// that both passed and failed within the window.
+
+type testOutcome struct {
+	Runs  int `json:"runs"`
+	Fails int `json:"fails"`
+}
+
+func testHistoryPath(rootDir string) string {
+	return filepath.Join(cacheDir(rootDir), "test_history.json")
+}
+
+func loadTestHistory(rootDir string) map[string]*testOutcome {
+	history := map[string]*testOutcome{}
+	data, err := os.ReadFile(testHistoryPath(rootDir))
+	if err == nil {
+		json.Unmarshal(data, &history)
+	}
+	return history
+}
+
+func saveTestHistory(rootDir string, history map[string]*testOutcome) error {
+	if err := os.MkdirAll(cacheDir(rootDir), 0o755); err != nil {
+		return err
+	}
+	data, _ := json.MarshalIndent(history, "", "  ")
+	return os.WriteFile(testHistoryPath(rootDir), data, 0o644)
+}
+
+// runFlutterSuite runs the Flutter suite once with the JSON reporter and
+// returns pass/fail per test name.
+func runFlutterSuite(rootDir string) (map[string]bool, error) {
+	cmd := exec.Command("flutter", "test", "--reporter", "json")
+	cmd.Dir = appDir(rootDir)
+	out, _ := cmd.Output() // non-zero exit just means failures; we parse anyway
+
+	names := map[int]string{}
+	results := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var event struct {
+			Type   string `json:"type"`
+			Result string `json:"result"`
+			TestID int    `json:"testID"`
+			Test   struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			} `json:"test"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		switch event.Type {
+		case "testStart":
+			names[event.Test.ID] = event.Test.Name
+		case "testDone":
+			if name := names[event.TestID]; name != "" {
+				results["flutter: "+name] = event.Result == "success"
+			}
+		}
+	}
+	return results, nil
+}
+
+var bunTestLine = regexp.MustCompile(`^\((pass|fail)\) (.+)$`)
+
+// runBridgeSuite runs `bun test` in the bridge and parses its per-test
+// (pass)/(fail) lines.
+func runBridgeSuite(rootDir string) (map[string]bool, error) {
+	cmd := exec.Command("bun", "test")
+	cmd.Dir = bridgeDir(rootDir)
+	out, _ := cmd.CombinedOutput()
+
+	results := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if m := bunTestLine.FindStringSubmatch(scanner.Text()); m != nil {
+			results["bridge: "+m[2]] = m[1] == "pass"
+		}
+	}
+	return results, nil
+}
+
+func runTestFlaky(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("test:flaky", flag.ContinueOnError)
+	runs := flags.Int("runs", 5, "times to run each suite")
+	suite := flags.String("suite", "all", "suite to check: flutter, bridge, or all")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	type suiteRunner struct {
+		name string
+		tool string
+		run  func(string) (map[string]bool, error)
+	}
+	runners := []suiteRunner{}
+	if *suite == "all" || *suite == "flutter" {
+		runners = append(runners, suiteRunner{"flutter", "flutter", runFlutterSuite})
+	}
+	if *suite == "all" || *suite == "bridge" {
+		runners = append(runners, suiteRunner{"bridge", "bun", runBridgeSuite})
+	}
+	if len(runners) == 0 {
+		fmt.Fprintf(os.Stderr, "unknown suite %q (expected flutter, bridge, or all)\n", *suite)
+		return 1
+	}
+
+	history := loadTestHistory(rootDir)
+	window := map[string]*testOutcome{} // just this invocation's runs
+
+	for _, runner := range runners {
+		if _, ok := lookupTool(rootDir, runner.tool); !ok {
+			fmt.Printf("skipping %s suite: %s not available\n", runner.name, runner.tool)
+			continue
+		}
+		for i := 0; i < *runs; i++ {
+			fmt.Printf("%s suite: run %d/%d…\n", runner.name, i+1, *runs)
+			results, err := runner.run(rootDir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %s run failed: %v\n", runner.name, err)
+				return 1
+			}
+			for name, passed := range results {
+				for _, m := range []map[string]*testOutcome{history, window} {
+					if m[name] == nil {
+						m[name] = &testOutcome{}
+					}
+					m[name].Runs++
+					if !passed {
+						m[name].Fails++
+					}
+				}
+			}
+		}
+	}
+
+	if err := saveTestHistory(rootDir, history); err != nil {
+		fmt.Fprintln(os.Stderr, "error: saving test history:", err)
+		return 1
+	}
+
+	// Flaky = failed at least once but not every time, within this window.
+	type flake struct {
+		name string
+		rate float64
+		out  *testOutcome
+	}
+	var flakes []flake
+	for name, outcome := range window {
+		if outcome.Fails > 0 && outcome.Fails < outcome.Runs {
+			flakes = append(flakes, flake{name, float64(outcome.Fails) / float64(outcome.Runs), outcome})
+		}
+	}
+	sort.Slice(flakes, func(i, j int) bool { return flakes[i].rate > flakes[j].rate })
+
+	if len(flakes) == 0 {
+		fmt.Printf("no flaky tests found across %d run(s)\n", *runs)
+		return 0
+	}
+	fmt.Printf("\n%d flaky test(s):\n\n", len(flakes))
+	for _, f := range flakes {
+		total := history[f.name]
+		fmt.Printf("  %5.0f%%  %s  (%d/%d this window, %d/%d all time)\n",
+			f.rate*100, f.name, f.out.Fails, f.out.Runs, total.Fails, total.Runs)
+	}
+	return 1
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// test:screenshots is the visual regression gate: capture the key screens
+// on whatever device is attached, compare each pixel-wise against the
+// committed baseline, and fail when more than the tolerated fraction of
+// pixels moved. Failures get a side-by-side image (baseline | actual |
+// diff mask) so the change is reviewable at a glance. Baselines are
+// updated with --update after an intentional UI change.
+
+const screenshotTolerance = 0.002 // fraction of pixels allowed to differ
+
+func screenshotBaselineDir(rootDir string) string {
+	return filepath.Join(appDir(rootDir), "test", "screenshot_baselines")
+}
+
+func screenshotDiffDir(rootDir string) string {
+	return filepath.Join(rootDir, "build", "screenshot_diffs")
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return png.Encode(f, img)
+}
+
+// diffImages returns the fraction of differing pixels and a mask image
+// with differing pixels in red.
+func diffImages(baseline, actual image.Image) (float64, image.Image) {
+	bounds := baseline.Bounds()
+	mask := image.NewRGBA(bounds)
+	draw.Draw(mask, bounds, image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	if actual.Bounds() != bounds {
+		// Dimension change: everything differs.
+		draw.Draw(mask, bounds, image.NewUniform(color.RGBA{255, 0, 0, 255}), image.Point{}, draw.Src)
+		return 1, mask
+	}
+
+	differing := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			br, bg, bb, _ := baseline.At(x, y).RGBA()
+			ar, ag, ab, _ := actual.At(x, y).RGBA()
+			if br != ar || bg != ag || bb != ab {
+				differing++
+				mask.Set(x, y, color.RGBA{255, 0, 0, 255})
+			}
+		}
+	}
+	total := bounds.Dx() * bounds.Dy()
+	return float64(differing) / float64(total), mask
+}
+
+// sideBySide lays baseline, actual, and the diff mask out horizontally.
+func sideBySide(baseline, actual, mask image.Image) image.Image {
+	b := baseline.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, b.Dx()*3+20, b.Dy()))
+	draw.Draw(out, out.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+	draw.Draw(out, image.Rect(0, 0, b.Dx(), b.Dy()), baseline, b.Min, draw.Src)
+	draw.Draw(out, image.Rect(b.Dx()+10, 0, b.Dx()*2+10, b.Dy()), actual, actual.Bounds().Min, draw.Src)
+	draw.Draw(out, image.Rect(b.Dx()*2+20, 0, b.Dx()*3+20, b.Dy()), mask, mask.Bounds().Min, draw.Src)
+	return out
+}
+
+func captureScreens(rootDir, outDir string) error {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+	driver := exec.Command("flutter", "drive",
+		"--driver=test_driver/screenshots_test.dart",
+		"--target=integration_test/screenshots.dart",
+	)
+	driver.Dir = appDir(rootDir)
+	driver.Env = append(os.Environ(),
+		"LURELANDS_SCREENSHOT_ROUTES="+strings.Join(screenshotRoutes, ","),
+		"LURELANDS_SCREENSHOT_DIR="+outDir,
+		"LURELANDS_SCREENSHOT_LABEL=regression",
+	)
+	driver.Stdout, driver.Stderr = os.Stdout, os.Stderr
+	return driver.Run()
+}
+
+func runTestScreenshots(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("test:screenshots", flag.ContinueOnError)
+	update := flags.Bool("update", false, "replace baselines with this run's captures")
+	tolerance := flags.Float64("tolerance", screenshotTolerance, "allowed fraction of differing pixels")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if _, err := exec.LookPath("flutter"); err != nil {
+		fmt.Fprintln(os.Stderr, "error: flutter is required to capture screenshots")
+		return 1
+	}
+
+	captureDir := filepath.Join(rootDir, "build", "screenshot_captures")
+	if err := captureScreens(rootDir, captureDir); err != nil {
+		fmt.Fprintln(os.Stderr, "error: capture failed:", err)
+		return 1
+	}
+
+	baselineDir := screenshotBaselineDir(rootDir)
+	if *update {
+		if err := os.MkdirAll(baselineDir, 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		captures, err := listPNGs(captureDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		for _, path := range captures {
+			img, err := readPNG(path)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				return 1
+			}
+			if err := writePNG(filepath.Join(baselineDir, filepath.Base(path)), img); err != nil {
+				fmt.Fprintln(os.Stderr, "error:", err)
+				return 1
+			}
+		}
+		fmt.Println("baselines updated — review and commit", baselineDir)
+		return 0
+	}
+
+	captures, err := listPNGs(captureDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	failures := 0
+	compared := 0
+	for _, path := range captures {
+		name := filepath.Base(path)
+		baselinePath := filepath.Join(baselineDir, name)
+		baseline, err := readPNG(baselinePath)
+		if err != nil {
+			fmt.Printf("  %-30s no baseline (run with --update to create)\n", name)
+			failures++
+			continue
+		}
+		actual, err := readPNG(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fraction, mask := diffImages(baseline, actual)
+		compared++
+		if fraction <= *tolerance {
+			fmt.Printf("  %-30s ok (%.3f%% changed)\n", name, fraction*100)
+			continue
+		}
+		failures++
+		diffPath := filepath.Join(screenshotDiffDir(rootDir), name)
+		if err := writePNG(diffPath, sideBySide(baseline, actual, mask)); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Printf("  %-30s FAIL (%.3f%% changed) -> %s\n", name, fraction*100, diffPath)
+	}
+
+	if failures > 0 {
+		fmt.Fprintf(os.Stderr, "test:screenshots: %d failure(s) across %d screen(s)\n", failures, compared)
+		return 1
+	}
+	fmt.Printf("test:screenshots: %d screen(s) match\n", compared)
+	return 0
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Test sharding. In CI each job runs one shard (--shards N --shard-index
+// i); locally --parallel splits the same shards across CPU cores and
+// merges the outcomes into one summary. Flutter shards natively via
+// --total-shards; the bridge suite is sharded by test file, which is
+// stable because the file list is sorted before slicing.
+
+// shardSlice returns the index-th of n interleaved slices, so adding one
+// file only moves one file between shards.
+func shardSlice(items []string, shards, index int) []string {
+	var out []string
+	for i, item := range items {
+		if i%shards == index {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func bridgeTestFiles(rootDir string) []string {
+	var files []string
+	filepath.Walk(bridgeDir(rootDir), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() && (info.Name() == "node_modules" || strings.HasPrefix(info.Name(), ".")) {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() && strings.HasSuffix(path, ".test.ts") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+// runServiceShard runs one shard of one service's suite, returning the
+// combined output rather than streaming, so parallel shards don't
+// interleave.
+func runServiceShard(rootDir string, service watchedService, shards, index int) (string, error) {
+	var cmd *exec.Cmd
+	switch service.name {
+	case "flutter":
+		cmd = exec.Command("flutter", "test",
+			"--total-shards", fmt.Sprint(shards), "--shard-index", fmt.Sprint(index))
+	case "bridge":
+		files := shardSlice(bridgeTestFiles(rootDir), shards, index)
+		if len(files) == 0 {
+			return "(no test files in shard)", nil
+		}
+		cmd = exec.Command("bun", append([]string{"test"}, files...)...)
+	default:
+		// Services without shard support run whole on shard 0 only.
+		if index != 0 {
+			return "(suite runs on shard 0 only)", nil
+		}
+		cmd = exec.Command(service.test[0], service.test[1:]...)
+	}
+	cmd.Dir = service.dir(rootDir)
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+type shardResult struct {
+	service string
+	index   int
+	output  string
+	err     error
+}
+
+// runParallelShards fans every service's shards out across the machine
+// and prints a merged summary.
+func runParallelShards(rootDir string, services []watchedService, shards int) int {
+	if shards <= 0 {
+		shards = runtime.NumCPU()
+	}
+	sem := make(chan struct{}, runtime.NumCPU())
+	results := make(chan shardResult)
+	var wg sync.WaitGroup
+
+	for _, service := range services {
+		for index := 0; index < shards; index++ {
+			wg.Add(1)
+			go func(service watchedService, index int) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				output, err := runServiceShard(rootDir, service, shards, index)
+				results <- shardResult{service.name, index, output, err}
+			}(service, index)
+		}
+	}
+	go func() { wg.Wait(); close(results) }()
+
+	failed := 0
+	total := 0
+	for result := range results {
+		total++
+		if result.err != nil {
+			failed++
+			fmt.Printf("── %s shard %d/%d FAILED ──\n%s\n", result.service, result.index+1, shards, result.output)
+		} else {
+			fmt.Printf("%s shard %d/%d ok\n", result.service, result.index+1, shards)
+		}
+	}
+	fmt.Printf("\n%d/%d shard(s) passed\n", total-failed, total)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+	"github.com/hipsterreed/lurelands/cli/pkg/tasks"
+)
+
+// test runs one or all service test suites; with --watch it polls source
+// mtimes and re-runs only the service whose files changed, keeping a
+// combined status line per service. Polling is deliberate — it works the
+// same on every platform and the tree is small enough that a 1s scan is
+// free.
+
+const watchPollInterval = time.Second
+
+type watchedService struct {
+	name string
+	tool string
+	dir  func(string) string
+	ext  string // source extension that triggers a re-run
+	test []string
+}
+
+// watchedServices mirrors tasks.Services so the watch loop and sharding
+// share the CLI-internal shape while the model lives in pkg/tasks.
+var watchedServices = func() []watchedService {
+	services := make([]watchedService, len(tasks.Services))
+	for i, svc := range tasks.Services {
+		svc := svc
+		services[i] = watchedService{
+			name: svc.Name,
+			tool: svc.Tool,
+			dir:  func(root string) string { return svc.Dir(root) },
+			ext:  svc.Ext,
+			test: svc.TestCmd,
+		}
+	}
+	return services
+}()
+
+// latestMtime walks the service dir for its source extension, skipping
+// build output and dot dirs.
+func latestMtime(dir, ext string) time.Time {
+	var latest time.Time
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if strings.HasPrefix(name, ".") || name == "build" || name == "node_modules" || name == "target" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ext && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest
+}
+
+func runServiceTests(ctx context.Context, rootDir string, service watchedService) error {
+	return runner.RunInteractive(ctx, runner.Spec{
+		Name: service.test[0],
+		Args: service.test[1:],
+		Dir:  service.dir(rootDir),
+	})
+}
+
+func availableServices(rootDir string) []watchedService {
+	var services []watchedService
+	for _, service := range watchedServices {
+		if _, err := os.Stat(service.dir(rootDir)); err != nil {
+			continue
+		}
+		if _, ok := lookupTool(rootDir, service.tool); !ok {
+			continue
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+func runTest(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	watch := flags.Bool("watch", false, "re-run affected suites when sources change")
+	only := flags.String("service", "", "limit to one service (flutter, bridge, module)")
+	workspace := flags.String("workspace", "", "alias for --service")
+	shards := flags.Int("shards", 0, "total shards (CI: run one with --shard-index)")
+	shardIndex := flags.Int("shard-index", -1, "which shard this invocation runs")
+	parallel := flags.Bool("parallel", false, "run all shards locally across CPU cores")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if *only == "" {
+		*only = *workspace
+	}
+	services := availableServices(rootDir)
+	if *only != "" {
+		filtered := services[:0]
+		for _, service := range services {
+			if service.name == *only {
+				filtered = append(filtered, service)
+			}
+		}
+		services = filtered
+	}
+	if len(services) == 0 {
+		fmt.Fprintln(os.Stderr, "error: no matching service with its toolchain available")
+		return 1
+	}
+
+	if *parallel {
+		return runParallelShards(rootDir, services, *shards)
+	}
+	if *shards > 0 {
+		if *shardIndex < 0 || *shardIndex >= *shards {
+			fmt.Fprintln(os.Stderr, "error: --shards requires --shard-index in [0, shards)")
+			return 1
+		}
+		code := 0
+		for _, service := range services {
+			output, err := runServiceShard(rootDir, service, *shards, *shardIndex)
+			fmt.Printf("── %s shard %d/%d ──\n%s\n", service.name, *shardIndex+1, *shards, output)
+			if err != nil {
+				code = 1
+			}
+		}
+		return code
+	}
+
+	if !*watch {
+		return runSuitesParallel(ctx, rootDir, services)
+	}
+
+	// Watch mode: status is "pass", "FAIL", or "…" while running.
+	status := map[string]string{}
+	mtimes := map[string]time.Time{}
+	for _, service := range services {
+		mtimes[service.name] = latestMtime(service.dir(rootDir), service.ext)
+		status[service.name] = "–"
+	}
+
+	printStatus := func() {
+		parts := make([]string, 0, len(services))
+		for _, service := range services {
+			parts = append(parts, fmt.Sprintf("%s: %s", service.name, status[service.name]))
+		}
+		fmt.Printf("\r\033[K[%s] %s", time.Now().Format("15:04:05"), strings.Join(parts, " · "))
+	}
+
+	fmt.Println("watching for changes — ctrl+c to stop")
+	printStatus()
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return 0
+		case <-time.After(watchPollInterval):
+		}
+		for _, service := range services {
+			latest := latestMtime(service.dir(rootDir), service.ext)
+			if !latest.After(mtimes[service.name]) {
+				continue
+			}
+			mtimes[service.name] = latest
+			status[service.name] = "…"
+			printStatus()
+			fmt.Printf("\n── %s (%s changed) ──\n", service.name, service.ext)
+			if err := runServiceTests(ctx, rootDir, service); err != nil {
+				status[service.name] = "FAIL"
+			} else {
+				status[service.name] = "pass"
+			}
+			// Re-running may touch generated files; reset the watermark so
+			// the run itself doesn't trigger another run.
+			mtimes[service.name] = latestMtime(service.dir(rootDir), service.ext)
+		}
+		printStatus()
+	}
+}
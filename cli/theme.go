@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/tui"
+)
+
+// Theme and keybinding config, loaded once per invocation before any
+// TUI renders. The palette starts from the dark or light defaults
+// depending on the terminal background, then [theme] keys override
+// individual roles with ANSI numbers or hex:
+//
+//	[theme]
+//	title = "#00CED1"
+//	warn = "160"
+//
+// NO_COLOR strips color entirely, same as --ci does. [keys] remaps the
+// common TUI actions:
+//
+//	[keys]
+//	quit = "x"
+//	filter = "f"
+
+// tuiKeys holds the active keybindings; navigation keys (arrows, j/k,
+// enter-in-pickers) stay fixed.
+var tuiKeys = struct {
+	Quit    string
+	Run     string
+	Filter  string
+	Logs    string
+	Refresh string
+}{
+	Quit:    "q",
+	Run:     "enter",
+	Filter:  "/",
+	Logs:    "l",
+	Refresh: "r",
+}
+
+func loadTheme(rootDir string) {
+	if os.Getenv("NO_COLOR") != "" {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
+	palette := tui.DarkPalette
+	if !lipgloss.HasDarkBackground() {
+		palette = tui.LightPalette
+	}
+	sections := loadConfigSections(rootDir)
+	for key, value := range sections["theme"] {
+		switch key {
+		case "title":
+			palette.Title = value
+		case "stat":
+			palette.Stat = value
+		case "warn":
+			palette.Warn = value
+		case "dim":
+			palette.Dim = value
+		case "selected":
+			palette.Selected = value
+		case "accent":
+			palette.Accent = value
+		case "ok":
+			palette.OK = value
+		}
+	}
+	tui.ApplyPalette(palette)
+	// The live-dashboard aliases copied the styles at init; refresh them.
+	liveTitleStyle, liveStatStyle, liveWarnStyle, liveDimStyle = tui.Title, tui.Stat, tui.Warn, tui.Dim
+
+	for key, value := range sections["keys"] {
+		if value == "" {
+			continue
+		}
+		switch key {
+		case "quit":
+			tuiKeys.Quit = value
+		case "run":
+			tuiKeys.Run = value
+		case "filter":
+			tuiKeys.Filter = value
+		case "logs":
+			tuiKeys.Logs = value
+		case "refresh":
+			tuiKeys.Refresh = value
+		}
+	}
+}
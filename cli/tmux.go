@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// `dev --panes` puts each service in its own tmux pane instead of
+// multiplexing output, for the developers who want native scrollback
+// and per-service resizing. Only tmux is supported — iTerm and Windows
+// Terminal splits need per-terminal escape protocols that aren't worth
+// maintaining when tmux runs everywhere we do. The pane layout comes
+// from config:
+//
+//	[dev]
+//	layout = "main-vertical"
+
+const devWindowName = "lurelands-dev"
+
+func insideTmux() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+// shellQuote single-quotes s for use inside a tmux pane command.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// paneShellCommand renders a devProcess as one shell line: extra env
+// vars via env(1), then the tool invocation. The pane keeps the shell
+// alive after exit so a crashing service leaves its output readable.
+func paneShellCommand(process devProcess) string {
+	var parts []string
+	if len(process.spec.Env) > 0 {
+		parts = append(parts, "env")
+		for _, pair := range process.spec.Env {
+			parts = append(parts, shellQuote(pair))
+		}
+	}
+	parts = append(parts, shellQuote(process.spec.Name))
+	for _, arg := range process.spec.Args {
+		parts = append(parts, shellQuote(arg))
+	}
+	line := strings.Join(parts, " ")
+	return fmt.Sprintf("%s; echo; echo '— %s exited — enter closes pane —'; read", line, process.name)
+}
+
+// runDevPanes launches the lineup as tmux panes in a new window and
+// returns immediately — the panes live in tmux, not under the CLI, so
+// stopping is per-pane (ctrl+c) or `tmux kill-window`.
+func runDevPanes(rootDir string, processes []devProcess, layout string) int {
+	if !insideTmux() {
+		fmt.Fprintln(os.Stderr, "error: --panes needs a tmux session (start tmux first, or drop --panes for multiplexed output)")
+		return 1
+	}
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return reportError(errToolNotFound("tmux", "splitting dev into panes"))
+	}
+	if layout == "" {
+		layout = "tiled"
+	}
+
+	for i, process := range processes {
+		dir := process.spec.Dir
+		if dir == "" {
+			dir = rootDir
+		}
+		tmuxArgs := []string{"new-window", "-n", devWindowName}
+		if i > 0 {
+			tmuxArgs = []string{"split-window", "-t", devWindowName}
+		}
+		tmuxArgs = append(tmuxArgs, "-c", dir, paneShellCommand(process))
+		if out, err := exec.Command("tmux", tmuxArgs...).CombinedOutput(); err != nil {
+			fmt.Fprintf(os.Stderr, "error: tmux %s: %v\n%s", tmuxArgs[0], err, out)
+			return 1
+		}
+	}
+	exec.Command("tmux", "select-layout", "-t", devWindowName, layout).Run()
+	fmt.Printf("dev: %d panes in tmux window %q (layout %s)\n", len(processes), devWindowName, layout)
+	return 0
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// Toolchain pinning. A [toolchain] section in .lurelands.toml pins the
+// versions the stack is known to work with:
+//
+//	[toolchain]
+//	flutter = "3.19.6"
+//	bun = "1.1.8"
+//	rust = "1.77.2"
+//	spacetime = "0.12.0"
+//
+// `toolchain sync` installs exactly those via mise (or asdf), and
+// pinnedSpec rewrites a runner spec to execute through `mise x
+// tool@version --` so a command always gets the pinned tool even when
+// the shell default differs. The parser below handles only the flat
+// key = "value" shape above — we do not want a TOML dependency for one
+// section.
+
+func toolchainPins(rootDir string) map[string]string {
+	pins := loadConfigSections(rootDir)["toolchain"]
+	if len(pins) == 0 {
+		return nil
+	}
+	return pins
+}
+
+// versionManager returns mise or asdf, whichever is installed; the two
+// take the same tool@version arguments for everything we use.
+func versionManager(rootDir string) (string, bool) {
+	for _, name := range []string{"mise", "asdf"} {
+		if _, ok := lookupTool(rootDir, name); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// pinAliases maps command names to the pin that provides them: cargo
+// ships with the rust toolchain, flutter brings dart.
+var pinAliases = map[string]string{"cargo": "rust", "dart": "flutter"}
+
+// pinnedSpec routes spec through the version manager when its tool is
+// pinned. Without a pin or a manager the spec runs as-is — pinning is
+// an upgrade, never a requirement.
+func pinnedSpec(rootDir string, spec runner.Spec) runner.Spec {
+	pin := spec.Name
+	if alias, ok := pinAliases[pin]; ok {
+		pin = alias
+	}
+	version, pinned := toolchainPins(rootDir)[pin]
+	if !pinned {
+		return spec
+	}
+	manager, ok := versionManager(rootDir)
+	if !ok || manager != "mise" { // asdf activates via shims, not exec
+		return spec
+	}
+	spec.Args = append([]string{"x", pin + "@" + version, "--", spec.Name}, spec.Args...)
+	spec.Name = manager
+	return spec
+}
+
+func runToolchainSync(ctx context.Context, rootDir string, args []string) int {
+	pins := toolchainPins(rootDir)
+	if pins == nil {
+		fmt.Println("no [toolchain] section in .lurelands.toml — nothing to sync")
+		return 0
+	}
+	manager, ok := versionManager(rootDir)
+	if !ok {
+		return reportError(errToolNotFound("mise (or asdf)", "installing pinned toolchain versions"))
+	}
+	tools := make([]string, 0, len(pins))
+	for tool := range pins {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	for _, tool := range tools {
+		fmt.Printf("  %s@%s… ", tool, pins[tool])
+		result, err := runner.Run(ctx, runner.Spec{
+			Name: manager,
+			Args: []string{"install", tool + "@" + pins[tool]},
+		})
+		if err != nil {
+			fmt.Println("FAIL")
+			fmt.Fprintln(os.Stderr, result.Output)
+			return 1
+		}
+		fmt.Println("ok")
+	}
+	fmt.Printf("toolchain: %d tool(s) in sync via %s\n", len(tools), manager)
+	return 0
+}
+
+func runToolchainShow(ctx context.Context, rootDir string, args []string) int {
+	pins := toolchainPins(rootDir)
+	if pins == nil {
+		fmt.Println("no [toolchain] section in .lurelands.toml")
+		return 0
+	}
+	tools := make([]string, 0, len(pins))
+	for tool := range pins {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	for _, tool := range tools {
+		fmt.Printf("  %-10s %s\n", tool, pins[tool])
+	}
+	return 0
+}
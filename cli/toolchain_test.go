@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestToolchainPinsParsing(t *testing.T) {
+	root := t.TempDir()
+	config := `# marker
+[workspace]
+name = "test"
+
+[toolchain]
+flutter = "3.19.6"
+bun = "1.1.8" # bridge runtime
+`
+	if err := os.WriteFile(filepath.Join(root, ".lurelands.toml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pins := toolchainPins(root)
+	if pins["flutter"] != "3.19.6" {
+		t.Errorf("flutter pin = %q, want 3.19.6", pins["flutter"])
+	}
+	if _, ok := pins["name"]; ok {
+		t.Error("picked up a key from outside [toolchain]")
+	}
+}
+
+func TestToolchainPinsAbsent(t *testing.T) {
+	if pins := toolchainPins(t.TempDir()); pins != nil {
+		t.Errorf("expected nil pins without a config, got %v", pins)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// tunnel puts the locally running bridge on a public URL so a physical
+// phone off the LAN can join a local game world. cloudflared is
+// preferred (no account needed for quick tunnels) with ngrok as the
+// fallback; either way the public URL is scraped from the tool's own
+// output. With --run, `flutter run` is launched on the chosen device
+// with the URL injected as a dart-define, which is how the app already
+// picks its bridge endpoint in dev builds.
+
+var tunnelURLPattern = regexp.MustCompile(`https://[a-z0-9-]+\.(?:trycloudflare\.com|ngrok-free\.app|ngrok\.io)`)
+
+func tunnelSpec(tool string, port int) runner.Spec {
+	if tool == "cloudflared" {
+		return runner.Spec{Name: "cloudflared", Args: []string{"tunnel", "--url", fmt.Sprintf("http://localhost:%d", port)}}
+	}
+	return runner.Spec{Name: "ngrok", Args: []string{"http", fmt.Sprint(port), "--log", "stdout"}}
+}
+
+// startTunnel launches the tunnel process and resolves the public URL
+// from its output. The returned channel yields the URL once.
+func startTunnel(ctx context.Context, tool string, port int) <-chan string {
+	urls := make(chan string, 1)
+	var once sync.Once
+	go func() {
+		runner.RunStreaming(ctx, tunnelSpec(tool, port), func(line string, _ bool) {
+			if url := tunnelURLPattern.FindString(line); url != "" {
+				once.Do(func() { urls <- url })
+			}
+		})
+		once.Do(func() { close(urls) })
+	}()
+	return urls
+}
+
+func runTunnel(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("tunnel", flag.ContinueOnError)
+	bridgePort := flags.Int("bridge-port", 8080, "local bridge port to expose")
+	withSpacetime := flags.Bool("spacetime", false, "also tunnel the local spacetime instance")
+	spacetimePort := flags.Int("spacetime-port", 3000, "local spacetime port to expose")
+	launch := flags.Bool("run", false, "launch flutter run with the tunnel URL injected")
+	device := flags.String("device", "", "device id for flutter run (with --run)")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	tool := ""
+	for _, candidate := range []string{"cloudflared", "ngrok"} {
+		if _, ok := lookupTool(rootDir, candidate); ok {
+			tool = candidate
+			break
+		}
+	}
+	if tool == "" {
+		return reportError(errToolNotFound("cloudflared (or ngrok)", "tunneling the local stack"))
+	}
+
+	fmt.Printf("tunneling bridge :%d via %s…\n", *bridgePort, tool)
+	bridgeURL, ok := <-startTunnel(ctx, tool, *bridgePort)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "error: tunnel exited before reporting a URL")
+		return 1
+	}
+	fmt.Println("bridge:", bridgeURL)
+
+	spacetimeURL := ""
+	if *withSpacetime {
+		spacetimeURL, ok = <-startTunnel(ctx, tool, *spacetimePort)
+		if !ok {
+			fmt.Fprintln(os.Stderr, "error: spacetime tunnel exited before reporting a URL")
+			return 1
+		}
+		fmt.Println("spacetime:", spacetimeURL)
+	}
+
+	if !*launch {
+		fmt.Printf("\nrun the app against it with:\n  flutter run --dart-define=BRIDGE_URL=%s\n", bridgeURL)
+		fmt.Println("tunnel stays up until ctrl+c")
+		<-ctx.Done()
+		return exitCancelled
+	}
+
+	flutterArgs := []string{"run", "--dart-define=BRIDGE_URL=" + bridgeURL}
+	if spacetimeURL != "" {
+		flutterArgs = append(flutterArgs, "--dart-define=SPACETIME_URL="+spacetimeURL)
+	}
+	if *device != "" {
+		flutterArgs = append(flutterArgs, "-d", *device)
+	}
+	fmt.Println("\nlaunching flutter run against the tunnel…")
+	if err := runner.RunInteractive(ctx, pinnedSpec(rootDir, runner.Spec{
+		Name: "flutter",
+		Args: flutterArgs,
+		Dir:  appDir(rootDir),
+	})); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
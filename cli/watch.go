@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/runner"
+)
+
+// watch closes the schema loop: fsnotify on the module and bridge
+// sources, and on change the right steps rerun automatically — a .rs
+// change means the schema may have moved, so bindings regenerate before
+// the bridge rebuilds; a .ts change rebuilds the bridge alone. Changes
+// are debounced (editors save in bursts, and a regenerate itself writes
+// into the bridge tree). Unlike prebuild, which polls mtimes and warms
+// artifacts opportunistically, watch reacts within a save and reports
+// each cycle's result inline.
+
+const watchDebounce = 2 * time.Second
+
+// watchIgnoredDirs are build outputs and caches that would otherwise
+// retrigger the very builds that write them.
+var watchIgnoredDirs = map[string]bool{
+	".git": true, "node_modules": true, "target": true, "dist": true, "generated": true,
+}
+
+// addWatchTree registers dir and every non-ignored subdirectory;
+// fsnotify does not recurse on its own.
+func addWatchTree(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return err
+		}
+		if watchIgnoredDirs[entry.Name()] {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// watchKind classifies a changed path: "module" for rust sources,
+// "bridge" for bridge TypeScript, "" for noise.
+func watchKind(rootDir, path string) string {
+	switch {
+	case strings.HasPrefix(path, moduleDir(rootDir)) && strings.HasSuffix(path, ".rs"):
+		return "module"
+	case strings.HasPrefix(path, bridgeDir(rootDir)) && strings.HasSuffix(path, ".ts"):
+		return "bridge"
+	}
+	return ""
+}
+
+func runWatch(ctx context.Context, rootDir string, args []string) int {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	defer watcher.Close()
+	watched := 0
+	for _, dir := range []string{moduleDir(rootDir), bridgeDir(rootDir)} {
+		if !fileExists(dir) {
+			continue
+		}
+		if err := addWatchTree(watcher, dir); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		watched++
+	}
+	if watched == 0 {
+		return reportError(errWorkdirMissing(moduleDir(rootDir)))
+	}
+
+	fmt.Println("watch: rebuilding on module/bridge changes — ctrl+c to stop")
+	pending := map[string]time.Time{} // kind -> when the change landed
+	for {
+		var timer <-chan time.Time
+		if len(pending) > 0 {
+			timer = time.After(500 * time.Millisecond)
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Println("\nwatch stopped")
+			return 0
+		case event := <-watcher.Events:
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					addWatchTree(watcher, event.Name)
+				}
+			}
+			if kind := watchKind(rootDir, event.Name); kind != "" {
+				pending[kind] = time.Now()
+			}
+		case err := <-watcher.Errors:
+			fmt.Fprintln(os.Stderr, "watch error:", err)
+		case <-timer:
+		}
+
+		ready := true
+		for _, changedAt := range pending {
+			if time.Since(changedAt) < watchDebounce {
+				ready = false
+			}
+		}
+		if !ready || len(pending) == 0 {
+			continue
+		}
+		_, moduleChanged := pending["module"]
+		pending = map[string]time.Time{}
+		watchBuild(ctx, rootDir, moduleChanged)
+	}
+}
+
+// watchBuild runs one cycle: regenerate bindings when the module moved,
+// then rebuild the bridge, printing a single status line per step.
+func watchBuild(ctx context.Context, rootDir string, moduleChanged bool) {
+	start := time.Now()
+	if moduleChanged {
+		fmt.Printf("[%s] regenerating bindings…\n", time.Now().Format("15:04:05"))
+		if code := runBridgeGenerate(ctx, rootDir, nil); code != 0 {
+			fmt.Printf("[%s] ! bindings failed (exit %d) — fix and save again\n",
+				time.Now().Format("15:04:05"), code)
+			return
+		}
+	}
+	result, err := runner.Run(ctx, pinnedSpec(rootDir, runner.Spec{
+		Name: "bun", Args: []string{"run", "build"}, Dir: bridgeDir(rootDir),
+	}))
+	if err != nil {
+		fmt.Printf("[%s] ! bridge build failed (exit %d) — fix and save again\n",
+			time.Now().Format("15:04:05"), result.ExitCode)
+		return
+	}
+	fmt.Printf("[%s] ok — built in %s\n", time.Now().Format("15:04:05"), time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hipsterreed/lurelands/cli/pkg/workspace"
+)
+
+// workspace (the command) manages a registry of checkouts — the main
+// repo, a fork, a release-branch worktree — stored per user, not per
+// repo, in the OS config dir. `workspace use` picks the active one and
+// every command resolves against it unless --root or LURELANDS_ROOT
+// says otherwise; `workspace use none` goes back to walking up from
+// the current directory.
+
+type workspaceRegistry struct {
+	Active     string            `json:"active,omitempty"`
+	Workspaces map[string]string `json:"workspaces"`
+}
+
+func workspaceRegistryPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "lurelands", "workspaces.json"), nil
+}
+
+func loadWorkspaceRegistry() (*workspaceRegistry, error) {
+	registry := &workspaceRegistry{Workspaces: map[string]string{}}
+	path, err := workspaceRegistryPath()
+	if err != nil {
+		return registry, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return registry, nil
+	}
+	if err != nil {
+		return registry, err
+	}
+	if err := json.Unmarshal(data, registry); err != nil {
+		return registry, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if registry.Workspaces == nil {
+		registry.Workspaces = map[string]string{}
+	}
+	return registry, nil
+}
+
+func saveWorkspaceRegistry(registry *workspaceRegistry) error {
+	path, err := workspaceRegistryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, _ := json.MarshalIndent(registry, "", "  ")
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// activeWorkspaceRoot returns the registered active checkout's root, or
+// "" when none is set (or the registry is unreadable — discovery then
+// falls through to the directory walk).
+func activeWorkspaceRoot() string {
+	registry, err := loadWorkspaceRegistry()
+	if err != nil || registry.Active == "" {
+		return ""
+	}
+	return registry.Workspaces[registry.Active]
+}
+
+func runWorkspace(ctx context.Context, rootDir string, args []string) int {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	registry, err := loadWorkspaceRegistry()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+
+	switch args[0] {
+	case "list":
+		if len(registry.Workspaces) == 0 {
+			fmt.Println("no workspaces registered — `lurelands workspace add <name> <path>`")
+			return 0
+		}
+		names := make([]string, 0, len(registry.Workspaces))
+		for name := range registry.Workspaces {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			marker := " "
+			if name == registry.Active {
+				marker = "*"
+			}
+			fmt.Printf("%s %-16s %s\n", marker, name, registry.Workspaces[name])
+		}
+		return 0
+
+	case "add":
+		if len(args) != 3 {
+			fmt.Fprintln(os.Stderr, "usage: lurelands workspace add <name> <path>")
+			return exitUsage
+		}
+		name, path := args[1], args[2]
+		ws, err := workspace.FromRoot(path)
+		if err != nil {
+			return reportError(err)
+		}
+		registry.Workspaces[name] = ws.Root
+		if err := saveWorkspaceRegistry(registry); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Printf("registered %s -> %s\n", name, ws.Root)
+		return 0
+
+	case "use":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, "usage: lurelands workspace use <name|none>")
+			return exitUsage
+		}
+		name := args[1]
+		if name == "none" {
+			registry.Active = ""
+		} else {
+			if _, ok := registry.Workspaces[name]; !ok {
+				fmt.Fprintf(os.Stderr, "no workspace %q — `lurelands workspace list`\n", name)
+				return 1
+			}
+			registry.Active = name
+		}
+		if err := saveWorkspaceRegistry(registry); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		if name == "none" {
+			fmt.Println("active workspace cleared — commands resolve from the current directory again")
+		} else {
+			fmt.Printf("active workspace is now %s (%s)\n", name, registry.Workspaces[name])
+		}
+		return 0
+
+	case "remove":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, "usage: lurelands workspace remove <name>")
+			return exitUsage
+		}
+		name := args[1]
+		if _, ok := registry.Workspaces[name]; !ok {
+			fmt.Fprintf(os.Stderr, "no workspace %q\n", name)
+			return 1
+		}
+		delete(registry.Workspaces, name)
+		if registry.Active == name {
+			registry.Active = ""
+		}
+		if err := saveWorkspaceRegistry(registry); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+		fmt.Printf("removed %s\n", name)
+		return 0
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown workspace subcommand %q (list, add, use, remove)\n", args[0])
+		return exitUsage
+	}
+}